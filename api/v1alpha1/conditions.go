@@ -17,6 +17,8 @@ const (
 	//   - Progressing
 	//   - Failed
 	//   - Ready: operand successfully deployed and ready
+	//   - Unmanaged: operand resources are not managed, and the status reflects the availability of the components
+	//   - Removed: operand resources are removed
 	Ready string = "Ready"
 
 	// UpdateAnnotation is the condition type used to inform status of updating the annotations.
@@ -27,6 +29,138 @@ const (
 	//   - Completed
 	//   - Failed
 	UpdateAnnotation string = "UpdateAnnotation"
+
+	// CloudCredentialsAvailable is the condition type used to inform whether the Secrets with the cloud credentials
+	// requested from the Cloud Credential Operator are available.
+	//   Status:
+	//   - True
+	//   - False
+	//   Reason:
+	//   - Progressing: waiting for the Cloud Credential Operator to mint the credentials
+	//   - Ready: all the requested credentials are available
+	CloudCredentialsAvailable string = "CloudCredentialsAvailable"
+
+	// CoreControllerAvailable is the condition type used to inform whether the external-secrets core controller
+	// deployment is available.
+	//   Status:
+	//   - True
+	//   - False
+	//   Reason:
+	//   - Progressing: deployment is not yet available
+	//   - Ready: deployment is available
+	CoreControllerAvailable string = "CoreControllerAvailable"
+
+	// WebhookAvailable is the condition type used to inform whether the external-secrets webhook deployment is
+	// available and the webhook service has ready endpoints for serving the admission requests.
+	//   Status:
+	//   - True
+	//   - False
+	//   Reason:
+	//   - Progressing: deployment is not yet available or the service has no ready endpoints
+	//   - Ready: deployment is available and the service has ready endpoints
+	WebhookAvailable string = "WebhookAvailable"
+
+	// CertControllerAvailable is the condition type used to inform whether the external-secrets cert-controller
+	// deployment is available. The condition is present only when cert-manager is not configured for the webhook certificates.
+	//   Status:
+	//   - True
+	//   - False
+	//   Reason:
+	//   - Progressing: deployment is not yet available
+	//   - Ready: deployment is available
+	CertControllerAvailable string = "CertControllerAvailable"
+
+	// BitwardenSDKServerAvailable is the condition type used to inform whether the bitwarden-sdk-server deployment
+	// is available and the service has ready endpoints. The condition is present only when the bitwarden plugin is enabled.
+	//   Status:
+	//   - True
+	//   - False
+	//   Reason:
+	//   - Progressing: deployment is not yet available or the service has no ready endpoints
+	//   - Ready: deployment is available and the service has ready endpoints
+	BitwardenSDKServerAvailable string = "BitwardenSDKServerAvailable"
+
+	// WebhookHealthy is the condition type used to inform whether the external-secrets webhook is verified to be
+	// serving the admission requests, with the caBundle of the ValidatingWebhookConfigurations matching the serving
+	// certificate, the certificate not close to expiry, the TLS handshake with the webhook service succeeding, and
+	// the webhook admitting a dry-run create of a SecretStore.
+	//   Status:
+	//   - True
+	//   - False
+	//   Reason:
+	//   - Failed: one of the verifications failed
+	//   - Ready: all the verifications succeeded
+	WebhookHealthy string = "WebhookHealthy"
+
+	// Progressing is the condition type used to inform whether a rollout of the operand deployments is in progress.
+	//   Status:
+	//   - True
+	//   - False
+	//   Reason:
+	//   - Progressing: rollout of one or more deployments is in progress
+	//   - Completed: rollout of all the deployments is complete
+	Progressing string = "Progressing"
+
+	// UnmanagedResources is the condition type used to warn about the operand resources annotated with
+	// operator.openshift.io/external-secrets-unmanaged=true, which are not reconciled to the desired state. The
+	// condition is present only when such resources exist.
+	//   Status:
+	//   - True
+	//   Reason:
+	//   - Unmanaged: the resources not reconciled are listed in the message
+	UnmanagedResources string = "UnmanagedResources"
+
+	// UnsupportedOverrides is the condition type used to warn about the overrides configured in spec.overrides, which
+	// are not a supported configuration. The condition is present only when overrides are configured.
+	//   Status:
+	//   - True
+	//   Reason:
+	//   - Completed: all the overrides are applied
+	//   - Failed: one or more overrides are not applied, as listed in status.overrides
+	UnsupportedOverrides string = "UnsupportedOverrides"
+
+	// UnsupportedImage is the condition type used to warn about the component images overridden in
+	// spec.controllerConfig.componentConfigs, which are not a supported configuration. The condition is present only
+	// when an image is overridden.
+	//   Status:
+	//   - True
+	//   Reason:
+	//   - Overridden: the components with the overridden images are listed in the message
+	UnsupportedImage string = "UnsupportedImage"
+
+	// Upgradeable is the condition type used to inform whether the operator can be upgraded, with no external-secrets
+	// resources using the API versions or the fields deprecated and dropped in the next versions. The condition is
+	// also reported to OLM in the OperatorCondition of the operator.
+	//   Status:
+	//   - True
+	//   - False
+	//   Reason:
+	//   - Ready: no resources use the deprecated API versions or fields
+	//   - DeprecatedAPIInUse: the resources using them are listed in status.upgradeBlockers
+	Upgradeable string = "Upgradeable"
+
+	// StorageVersionMigrated is the condition type used to inform whether the objects of the external-secrets CRDs
+	// are stored in etcd at the storage version of the CRDs, after the objects stored at the older versions are
+	// rewritten by the operator.
+	//   Status:
+	//   - True
+	//   - False
+	//   Reason:
+	//   - Progressing: migration of the objects is in progress, with the progress in the message
+	//   - Completed: objects of all the CRDs are stored at the storage version
+	StorageVersionMigrated string = "StorageVersionMigrated"
+
+	// ClusterSecretStoresBootstrapped is the condition type used to inform whether the ClusterSecretStores configured
+	// for bootstrapping and for the bitwarden plugin are created. The condition is present only when ClusterSecretStores
+	// are configured.
+	//   Status:
+	//   - True
+	//   - False
+	//   Reason:
+	//   - Progressing: waiting for the external-secrets webhook to be available
+	//   - Failed: ClusterSecretStores with the configured names exist and are not created by the operator
+	//   - Ready: all the configured ClusterSecretStores are created
+	ClusterSecretStoresBootstrapped string = "ClusterSecretStoresBootstrapped"
 )
 
 const (
@@ -37,4 +171,12 @@ const (
 	ReasonInProgress string = "Progressing"
 
 	ReasonCompleted string = "Completed"
+
+	ReasonUnmanaged string = "Unmanaged"
+
+	ReasonRemoved string = "Removed"
+
+	ReasonOverridden string = "Overridden"
+
+	ReasonDeprecatedAPIInUse string = "DeprecatedAPIInUse"
 )
@@ -1,9 +1,11 @@
 package v1alpha1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func init() {
@@ -73,6 +75,112 @@ type ExternalSecretsConfigSpec struct {
 	// controllerConfig is for specifying the configurations for the controller to use while installing the `external-secrets` operand and the plugins.
 	// +optional
 	ControllerConfig ControllerConfig `json:"controllerConfig,omitempty"`
+
+	// bootstrap is for specifying the external-secrets resources to be created once the operand is ready.
+	// +optional
+	Bootstrap *BootstrapConfig `json:"bootstrap,omitempty"`
+
+	// managementState indicates whether the controller manages the resources created for the external-secrets deployment.
+	// When `Managed`, the resources are created and kept in the desired state.
+	// When `Unmanaged`, the resources are not created, modified or deleted, and the availability of the
+	// external-secrets components is still reported in the status.
+	// When `Removed`, the resources created for the external-secrets deployment are deleted, except the namespace,
+	// and this resource is retained.
+	// When `Plan`, the resources are not created or modified, and the changes the controller would make to them
+	// are reported in `status.plan`.
+	// When omitted, the resources are managed.
+	// +kubebuilder:validation:Enum:=Managed;Unmanaged;Removed;Plan
+	// +optional
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
+	// overrides is the list of the patches applied to the resources created for the external-secrets deployment,
+	// for the configurations not available in this API. The patches are applied to the desired state computed by the
+	// controller, and are validated by a dry-run apply before use. An override failing to apply or to validate is
+	// ignored and reported in `status.overrides`. The overridden resources are not a supported configuration, which
+	// is reported with the `UnsupportedOverrides` condition.
+	// This field can have a maximum of 50 entries.
+	// +kubebuilder:validation:MinItems:=0
+	// +kubebuilder:validation:MaxItems:=50
+	// +listType=map
+	// +listMapKey=kind
+	// +listMapKey=name
+	// +optional
+	Overrides []ResourceOverride `json:"overrides,omitempty"`
+}
+
+// ResourceOverride is a patch applied to a resource created for the external-secrets deployment.
+type ResourceOverride struct {
+	// kind is the kind of the resource to be patched.
+	// +kubebuilder:validation:Enum:=Deployment;Service;ServiceAccount;NetworkPolicy;ClusterRole;ClusterRoleBinding;Role;RoleBinding;ValidatingWebhookConfiguration
+	// +required
+	//nolint:kubeapilinter // Kind is a listMapKey and must not have omitempty for proper patch identification
+	Kind string `json:"kind"`
+
+	// name is the name of the resource to be patched.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +required
+	//nolint:kubeapilinter // Name is a listMapKey and must not have omitempty for proper patch identification
+	Name string `json:"name"`
+
+	// patchType is the type of the patch.
+	// `JSONPatch` is a list of operations as defined by RFC 6902.
+	// `StrategicMergePatch` is a partial resource merged with the strategy of the Kubernetes API of the kind.
+	// +kubebuilder:validation:Enum:=JSONPatch;StrategicMergePatch
+	// +required
+	PatchType OverridePatchType `json:"patchType"`
+
+	// patch is the patch in JSON format.
+	// +kubebuilder:validation:MinLength:=2
+	// +kubebuilder:validation:MaxLength:=32768
+	// +required
+	Patch string `json:"patch"`
+}
+
+// OverridePatchType is the type of the patch of a resource override.
+type OverridePatchType string
+
+const (
+	// OverridePatchTypeJSONPatch indicates the patch is a list of operations as defined by RFC 6902.
+	OverridePatchTypeJSONPatch OverridePatchType = "JSONPatch"
+
+	// OverridePatchTypeStrategicMergePatch indicates the patch is a partial resource merged with the strategy of the
+	// Kubernetes API of the kind.
+	OverridePatchTypeStrategicMergePatch OverridePatchType = "StrategicMergePatch"
+)
+
+// BootstrapConfig is for specifying the external-secrets resources to be created once the operand is ready.
+type BootstrapConfig struct {
+	// clusterSecretStores is the list of `clustersecretstores.external-secrets.io` resources to be created once the
+	// external-secrets webhook is available. The resources are labeled as managed by the operator and reconciled to the
+	// configured state, and are removed when removed from this list. Existing clustersecretstores of the same name not
+	// created by the operator are not modified, and are reported in the ClusterSecretStoresBootstrapped condition.
+	// This field can have a maximum of 20 entries.
+	// +kubebuilder:validation:MinItems:=0
+	// +kubebuilder:validation:MaxItems:=20
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ClusterSecretStores []BootstrapClusterSecretStore `json:"clusterSecretStores,omitempty"`
+}
+
+// BootstrapClusterSecretStore is a ClusterSecretStore to be created by the operator.
+type BootstrapClusterSecretStore struct {
+	// name is the name of the ClusterSecretStore resource.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +required
+	//nolint:kubeapilinter // Name is a listMapKey and must not have omitempty for proper patch identification
+	Name string `json:"name"`
+
+	// spec is the `spec` of the ClusterSecretStore resource, as defined by the external-secrets.io/v1 API.
+	// The spec is validated by the external-secrets webhook when the resource is created or updated.
+	// +kubebuilder:validation:Type:=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +required
+	Spec runtime.RawExtension `json:"spec"`
 }
 
 // ExternalSecretsConfigStatus is the most recently observed status of the ExternalSecretsConfig.
@@ -80,15 +188,192 @@ type ExternalSecretsConfigStatus struct {
 	// conditions holds information of the current state of the external-secrets deployment.
 	ConditionalStatus `json:",inline"`
 
-	// externalSecretsImage is the name of the image and the tag used for deploying external-secrets.
+	// componentImages is the list of the images used for deploying the external-secrets components.
+	// +listType=map
+	// +listMapKey=componentName
+	// +optional
+	ComponentImages []ComponentImageStatus `json:"componentImages,omitempty"`
+
+	// cloudIdentityMode is the cloud provider workload identity mode active for the external-secrets core controller.
+	// +kubebuilder:validation:Enum:=None;AWS;Azure;GCP
+	// +optional
+	CloudIdentityMode CloudIdentityMode `json:"cloudIdentityMode,omitempty"`
+
+	// plan is the report of the changes the controller would make to the resources created for the
+	// external-secrets deployment. The plan is present only when `spec.managementState` is `Plan`.
+	// +optional
+	Plan *ManagementPlan `json:"plan,omitempty"`
+
+	// overrides is the list of the results of the overrides configured in `spec.overrides`.
+	// +listType=map
+	// +listMapKey=kind
+	// +listMapKey=name
+	// +optional
+	Overrides []ResourceOverrideStatus `json:"overrides,omitempty"`
+
+	// upgradeBlockers is the list of the external-secrets resources using the API versions or the fields deprecated
+	// and dropped in the next versions of external-secrets, which block the upgrade of the operator. The
+	// `Upgradeable` condition is `False` when not empty.
+	// This field can have a maximum of 50 entries.
+	// +kubebuilder:validation:MaxItems:=50
+	// +listType=atomic
+	// +optional
+	UpgradeBlockers []UpgradeBlocker `json:"upgradeBlockers,omitempty"`
+}
+
+// ComponentImageStatus is the image used for deploying an external-secrets component.
+type ComponentImageStatus struct {
+	// componentName is the name of the external-secrets component.
+	// +required
+	//nolint:kubeapilinter // ComponentName is a listMapKey and must not have omitempty for proper patch identification
+	ComponentName ComponentName `json:"componentName"`
+
+	// image is the name of the image with the tag or the digest used for deploying the component.
+	// +required
+	Image string `json:"image"`
+
+	// overridden is whether the image is overridden in `spec.controllerConfig.componentConfigs`, instead of the
+	// image released with the operator.
+	// +optional
+	Overridden bool `json:"overridden,omitempty"`
+}
+
+// ResourceOverrideStatus is the result of a resource override.
+type ResourceOverrideStatus struct {
+	// kind is the kind of the patched resource.
+	// +required
+	//nolint:kubeapilinter // Kind is a listMapKey and must not have omitempty for proper patch identification
+	Kind string `json:"kind"`
+
+	// name is the name of the patched resource.
+	// +required
+	//nolint:kubeapilinter // Name is a listMapKey and must not have omitempty for proper patch identification
+	Name string `json:"name"`
+
+	// state is the result of the override.
+	// +kubebuilder:validation:Enum:=Applied;Invalid;NotFound
+	// +required
+	State OverrideState `json:"state"`
+
+	// message is the reason of the override not being applied.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// OverrideState is the result of a resource override.
+type OverrideState string
+
+const (
+	// OverrideStateApplied indicates the patch is applied to the resource.
+	OverrideStateApplied OverrideState = "Applied"
+
+	// OverrideStateInvalid indicates the patch failed to apply, or the patched resource failed the dry-run
+	// validation, and the resource is reconciled without the patch.
+	OverrideStateInvalid OverrideState = "Invalid"
+
+	// OverrideStateNotFound indicates no resource of the kind and the name is created for the external-secrets
+	// deployment.
+	OverrideStateNotFound OverrideState = "NotFound"
+)
+
+// UpgradeBlocker is an external-secrets resource blocking the upgrade of the operator.
+type UpgradeBlocker struct {
+	// kind is the kind of the resource.
+	// +required
+	Kind string `json:"kind"`
+
+	// namespace is the namespace of the resource, and is empty for the cluster scoped resources.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// name is the name of the resource.
+	// +required
+	Name string `json:"name"`
+
+	// reason is the deprecated API version or field used by the resource.
+	// +required
+	Reason string `json:"reason"`
+}
+
+// ManagementPlan is the report of the changes the controller would make to the managed resources.
+type ManagementPlan struct {
+	// observedGeneration is the generation of the ExternalSecretsConfig the plan was computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// lastPlannedTime is the time the planned changes or the generation planned for last changed.
 	// +optional
-	ExternalSecretsImage string `json:"externalSecretsImage,omitempty"`
+	LastPlannedTime metav1.Time `json:"lastPlannedTime,omitempty"`
+
+	// changes is the list of the changes the controller would make to the managed resources.
+	// This field can have a maximum of 256 entries.
+	// +kubebuilder:validation:MaxItems:=256
+	// +listType=atomic
+	// +optional
+	Changes []PlannedChange `json:"changes,omitempty"`
+}
+
+// PlannedChange is a change the controller would make to a managed resource.
+type PlannedChange struct {
+	// apiVersion is the API version of the resource.
+	// +required
+	APIVersion string `json:"apiVersion"`
+
+	// kind is the kind of the resource.
+	// +required
+	Kind string `json:"kind"`
+
+	// namespace is the namespace of the resource, and is empty for the cluster scoped resources.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// name is the name of the resource.
+	// +required
+	Name string `json:"name"`
+
+	// action is the change the controller would make to the resource.
+	// +kubebuilder:validation:Enum:=Create;Update;Delete
+	// +required
+	Action PlannedAction `json:"action"`
 
-	// bitwardenSDKServerImage is the name of the image and the tag used for deploying bitwarden-sdk-server.
+	// fields is the list of the paths of the fields of the resource which differ from the desired state,
+	// and is set only for the `Update` action.
+	// +listType=atomic
 	// +optional
-	BitwardenSDKServerImage string `json:"bitwardenSDKServerImage,omitempty"`
+	Fields []string `json:"fields,omitempty"`
 }
 
+// PlannedAction is the change the controller would make to a managed resource.
+type PlannedAction string
+
+const (
+	// PlannedActionCreate indicates the resource does not exist and would be created.
+	PlannedActionCreate PlannedAction = "Create"
+
+	// PlannedActionUpdate indicates the resource differs from the desired state and would be updated.
+	PlannedActionUpdate PlannedAction = "Update"
+
+	// PlannedActionDelete indicates the resource is not desired anymore and would be deleted.
+	PlannedActionDelete PlannedAction = "Delete"
+)
+
+// CloudIdentityMode is the cloud provider workload identity mode used by an external-secrets component.
+type CloudIdentityMode string
+
+const (
+	// CloudIdentityModeNone indicates no cloud provider workload identity is configured.
+	CloudIdentityModeNone CloudIdentityMode = "None"
+
+	// CloudIdentityModeAWS indicates the AWS IAM role is assumed with the web identity token.
+	CloudIdentityModeAWS CloudIdentityMode = "AWS"
+
+	// CloudIdentityModeAzure indicates the Azure AD application is federated with the ServiceAccount token.
+	CloudIdentityModeAzure CloudIdentityMode = "Azure"
+
+	// CloudIdentityModeGCP indicates the Google Cloud service account is impersonated with the ServiceAccount token.
+	CloudIdentityModeGCP CloudIdentityMode = "GCP"
+)
+
 // ApplicationConfig is for specifying the configurations for the external-secrets operand.
 type ApplicationConfig struct {
 	CommonConfigs `json:",inline"`
@@ -159,9 +444,150 @@ type ControllerConfig struct {
 	// +listMapKey=componentName
 	// +optional
 	ComponentConfigs []ComponentConfig `json:"componentConfigs,omitempty"`
+
+	// clusterWorkloadIdentity is for deriving the workload identity configuration of the external-secrets core controller
+	// from the cluster configuration, on clusters installed with short-term credentials (STS on AWS, Workload Identity on
+	// Azure and GCP). When enabled, the operator reads the ServiceAccount issuer from the `authentications.config.openshift.io`
+	// and the platform from the `infrastructures.config.openshift.io` resources, and the cloud credentials from the
+	// environment variables set by OLM on the operator for token based authentication, for configuring the core controller
+	// with a projected ServiceAccount token of the platform specific audience.
+	// The workload identity configured in componentConfigs for the core controller takes precedence over the derived values.
+	// +optional
+	ClusterWorkloadIdentity *ClusterWorkloadIdentityConfig `json:"clusterWorkloadIdentity,omitempty"`
+
+	// credentialsRequests is for requesting the cloud credentials for the external-secrets core controller from the
+	// OpenShift Cloud Credential Operator. For each entry, the operator creates a `credentialsrequests.cloudcredential.openshift.io`
+	// resource in the `openshift-cloud-credential-operator` namespace, and the Cloud Credential Operator writes the minted
+	// credentials into the Secret with the same name in the external-secrets operand namespace.
+	// This requires the Cloud Credential Operator to be installed on the cluster.
+	// This field can have a maximum of 10 entries.
+	// +kubebuilder:validation:MinItems:=0
+	// +kubebuilder:validation:MaxItems:=10
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	CredentialsRequests []CloudCredentialsRequest `json:"credentialsRequests,omitempty"`
+
+	// monitoring is for configuring the monitoring of the external-secrets operand. When the Prometheus Operator
+	// `servicemonitors.monitoring.coreos.com` and `prometheusrules.monitoring.coreos.com` CRDs are installed, the
+	// operator creates the ServiceMonitors for scraping the metrics of the external-secrets components and the
+	// PrometheusRule with the alerts for the external-secrets operand, which is the default behavior.
+	// +optional
+	Monitoring *MonitoringConfig `json:"monitoring,omitempty"`
+
+	// namespaceConfig is for configuring the labels of the external-secrets operand namespace managed by the operator,
+	// for the Pod Security Admission levels and for opting in to the OpenShift cluster monitoring.
+	// The labels are added to the namespace, and the ones no longer configured are not removed, as the namespace
+	// can be created by the user with the labels.
+	// +optional
+	NamespaceConfig *NamespaceConfig `json:"namespaceConfig,omitempty"`
+}
+
+// CloudCredentialsRequest is for requesting cloud credentials from the OpenShift Cloud Credential Operator.
+// +kubebuilder:validation:XValidation:rule="[has(self.aws), has(self.azure), has(self.gcp)].filter(x, x).size() == 1",message="exactly one of aws, azure or gcp must be configured"
+type CloudCredentialsRequest struct {
+	// name is the name of the Secret in the external-secrets operand namespace into which the minted credentials are written.
+	// The CredentialsRequest resource is created with the name prefixed with `external-secrets-`.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=236
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +required
+	//nolint:kubeapilinter // Name is a listMapKey and must not have omitempty for proper patch identification
+	Name string `json:"name"`
+
+	// aws is for requesting an AWS IAM user or role with the permissions defined by the policy statements.
+	// +optional
+	AWS *AWSCredentialsRequest `json:"aws,omitempty"`
+
+	// azure is for requesting an Azure identity with the role bindings.
+	// +optional
+	Azure *AzureCredentialsRequest `json:"azure,omitempty"`
+
+	// gcp is for requesting a Google Cloud service account with the predefined roles.
+	// +optional
+	GCP *GCPCredentialsRequest `json:"gcp,omitempty"`
+}
+
+// AWSCredentialsRequest is for defining the permissions of the requested AWS credentials.
+type AWSCredentialsRequest struct {
+	// statementEntries are the IAM policy statements granted to the requested credentials.
+	// +kubebuilder:validation:MinItems:=1
+	// +kubebuilder:validation:MaxItems:=50
+	// +listType=atomic
+	// +required
+	StatementEntries []AWSStatementEntry `json:"statementEntries,omitempty"`
+}
+
+// AWSStatementEntry is an IAM policy statement.
+type AWSStatementEntry struct {
+	// effect indicates whether the statement allows or denies the actions.
+	// +kubebuilder:validation:Enum:=Allow;Deny
+	// +kubebuilder:default:=Allow
+	// +optional
+	Effect string `json:"effect,omitempty"`
+
+	// action is the list of AWS API actions the statement applies to, e.g. `secretsmanager:GetSecretValue`.
+	// +kubebuilder:validation:MinItems:=1
+	// +kubebuilder:validation:MaxItems:=100
+	// +kubebuilder:validation:items:MinLength:=1
+	// +kubebuilder:validation:items:MaxLength:=128
+	// +listType=atomic
+	// +required
+	Action []string `json:"action,omitempty"`
+
+	// resource is the ARN of the resources the statement applies to.
+	// +kubebuilder:default:="*"
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=2048
+	// +optional
+	Resource string `json:"resource,omitempty"`
+}
+
+// AzureCredentialsRequest is for defining the permissions of the requested Azure credentials.
+type AzureCredentialsRequest struct {
+	// roleBindings are the Azure roles granted to the requested credentials.
+	// +kubebuilder:validation:MinItems:=1
+	// +kubebuilder:validation:MaxItems:=20
+	// +listType=atomic
+	// +required
+	RoleBindings []AzureRoleBinding `json:"roleBindings,omitempty"`
+}
+
+// AzureRoleBinding is an Azure role granted to the requested credentials.
+type AzureRoleBinding struct {
+	// role is the name of the Azure role, e.g. `Key Vault Secrets User`.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=256
+	// +required
+	Role string `json:"role,omitempty"`
+}
+
+// GCPCredentialsRequest is for defining the permissions of the requested Google Cloud credentials.
+type GCPCredentialsRequest struct {
+	// predefinedRoles are the Google Cloud predefined roles granted to the requested credentials, e.g. `roles/secretmanager.secretAccessor`.
+	// +kubebuilder:validation:MinItems:=1
+	// +kubebuilder:validation:MaxItems:=20
+	// +kubebuilder:validation:items:MinLength:=1
+	// +kubebuilder:validation:items:MaxLength:=256
+	// +listType=atomic
+	// +required
+	PredefinedRoles []string `json:"predefinedRoles,omitempty"`
+}
+
+// ClusterWorkloadIdentityConfig is for configuring the operator to derive the workload identity from the cluster configuration.
+type ClusterWorkloadIdentityConfig struct {
+	// mode indicates whether the workload identity configuration is derived from the cluster configuration, which can be indicated by setting Enabled or Disabled.
+	// Enabled: Derives the workload identity configuration of the core controller from the cluster configuration.
+	// Disabled: The workload identity configuration is used only as configured in componentConfigs, which is the default behavior.
+	// +kubebuilder:validation:Enum:=Enabled;Disabled
+	// +kubebuilder:default:=Disabled
+	// +optional
+	Mode Mode `json:"mode,omitempty"`
 }
 
 // ComponentConfig defines configuration overrides for a specific external-secrets component.
+// +kubebuilder:validation:XValidation:rule="self.componentName != 'ExternalSecretsCoreController' || !has(self.deploymentConfigs) || (!has(self.deploymentConfigs.livenessProbe) && !has(self.deploymentConfigs.readinessProbe) && !has(self.deploymentConfigs.startupProbe))",message="probes are not supported for ExternalSecretsCoreController, which has no health endpoint"
+// +kubebuilder:validation:XValidation:rule="!has(self.autoscaling) || self.componentName in ['Webhook', 'BitwardenSDKServer']",message="autoscaling is supported only for Webhook and BitwardenSDKServer"
 type ComponentConfig struct {
 	// componentName identifies which external-secrets component this configuration applies to.
 	// Valid component names: ExternalSecretsCoreController, Webhook, CertController, BitwardenSDKServer.
@@ -182,9 +608,201 @@ type ComponentConfig struct {
 	// +listMapKey=name
 	// +optional
 	OverrideEnv []corev1.EnvVar `json:"overrideEnv,omitempty"`
+
+	// workloadIdentity is for configuring the cloud provider workload identity for this component.
+	// The operator annotates and labels the component's ServiceAccount, and when serviceAccountToken is configured,
+	// projects a bound ServiceAccount token with the given audience into the component's container along with the
+	// environment variables the cloud provider SDKs read for exchanging the token.
+	// +optional
+	WorkloadIdentity *WorkloadIdentityConfig `json:"workloadIdentity,omitempty"`
+
+	// image is for overriding the image of the component container, for testing a hotfix or for pulling the image
+	// from a mirror registry. The image must be referenced by its digest, like `registry.example.com/external-secrets@sha256:<digest>`.
+	// An overridden image is not a supported configuration, and is flagged with the UnsupportedImage condition.
+	// +kubebuilder:validation:MaxLength:=512
+	// +kubebuilder:validation:XValidation:rule="self.matches('^[^@\\\\s]+@sha256:[a-f0-9]{64}$')",message="image must be referenced by its sha256 digest"
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// imagePullPolicy is the pull policy of the image of the component container.
+	// If not specified, defaults to IfNotPresent.
+	// +kubebuilder:validation:Enum:=Always;IfNotPresent;Never
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// imagePullSecrets is the list of the Secrets in the external-secrets operand namespace with the credentials
+	// for pulling the image of the component, like from a mirror registry requiring authentication.
+	// This field can have a maximum of 10 entries.
+	// +kubebuilder:validation:MaxItems:=10
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// autoscaling is for scaling the component deployment with a HorizontalPodAutoscaler, based on the CPU and the
+	// memory utilization of the pods. The replicas of the deployment are then left to the autoscaler.
+	// Autoscaling is supported only for the Webhook and the BitwardenSDKServer components, the other components
+	// run a single active instance.
+	// +optional
+	Autoscaling *AutoscalingConfig `json:"autoscaling,omitempty"`
+}
+
+// AutoscalingConfig is for configuring the HorizontalPodAutoscaler of a component deployment.
+// The utilization targets are percentages of the resource requests of the containers, which must be configured
+// in resources for the autoscaler to compute the utilization.
+// +kubebuilder:validation:XValidation:rule="!has(self.minReplicas) || self.minReplicas <= self.maxReplicas",message="minReplicas must not be greater than maxReplicas"
+type AutoscalingConfig struct {
+	// minReplicas is the lower limit for the number of replicas the deployment can be scaled down to.
+	// If not specified, defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=20
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// maxReplicas is the upper limit for the number of replicas the deployment can be scaled up to.
+	// Must not be less than minReplicas.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=20
+	// +required
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// targetCPUUtilizationPercentage is the average CPU utilization of the pods the autoscaler maintains.
+	// If neither the CPU nor the memory target is specified, defaults to 80.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// targetMemoryUtilizationPercentage is the average memory utilization of the pods the autoscaler maintains.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+}
+
+// WorkloadIdentityConfig is for configuring the cloud provider workload identity for an external-secrets component.
+// +kubebuilder:validation:XValidation:rule="[has(self.aws), has(self.azure), has(self.gcp)].filter(x, x).size() <= 1",message="only one of aws, azure or gcp can be configured"
+// +kubebuilder:validation:XValidation:rule="!has(self.gcp) || !has(self.gcp.workloadIdentityProvider) || has(self.serviceAccountToken)",message="serviceAccountToken must be configured when gcp.workloadIdentityProvider is set"
+type WorkloadIdentityConfig struct {
+	// aws is for configuring the AWS IAM role to be assumed with the ServiceAccount token.
+	// +optional
+	AWS *AWSWorkloadIdentity `json:"aws,omitempty"`
+
+	// azure is for configuring the Azure AD application to be federated with the ServiceAccount token.
+	// +optional
+	Azure *AzureWorkloadIdentity `json:"azure,omitempty"`
+
+	// gcp is for configuring the Google Cloud service account to be impersonated with the ServiceAccount token.
+	// +optional
+	GCP *GCPWorkloadIdentity `json:"gcp,omitempty"`
+
+	// serviceAccountAnnotations are the additional annotations to add on the component's ServiceAccount.
+	// The annotations derived from the aws, azure or gcp configuration take precedence in case of conflicts.
+	// This field can have a maximum of 20 entries.
+	// +mapType=granular
+	// +kubebuilder:validation:MinProperties:=0
+	// +kubebuilder:validation:MaxProperties:=20
+	// +optional
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
+
+	// serviceAccountLabels are the additional labels to add on the component's ServiceAccount.
+	// Labels with the `app.kubernetes.io/` prefix and the `app` label are reserved for the operator and not allowed.
+	// This field can have a maximum of 20 entries.
+	// +kubebuilder:validation:XValidation:rule="self.all(key, !key.startsWith('app.kubernetes.io/') && key != 'app')",message="labels with 'app.kubernetes.io/' prefix or 'app' key are reserved and not allowed"
+	// +mapType=granular
+	// +kubebuilder:validation:MinProperties:=0
+	// +kubebuilder:validation:MaxProperties:=20
+	// +optional
+	ServiceAccountLabels map[string]string `json:"serviceAccountLabels,omitempty"`
+
+	// serviceAccountToken is for projecting a bound ServiceAccount token with a custom audience into the component's container.
+	// +optional
+	ServiceAccountToken *ProjectedServiceAccountToken `json:"serviceAccountToken,omitempty"`
+}
+
+// AWSWorkloadIdentity is for configuring the AWS IAM role to be assumed using the web identity token.
+type AWSWorkloadIdentity struct {
+	// roleARN is the ARN of the IAM role to be assumed. It is set as the `eks.amazonaws.com/role-arn` annotation on the
+	// ServiceAccount and, when serviceAccountToken is configured, as the `AWS_ROLE_ARN` environment variable.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=2048
+	// +kubebuilder:validation:Pattern:=`^arn:aws[a-z-]*:iam::[0-9]{12}:role/.+$`
+	// +required
+	RoleARN string `json:"roleARN,omitempty"`
+
+	// region is the AWS region of the STS endpoint to use. It is set as the `AWS_REGION` environment variable
+	// when serviceAccountToken is configured.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=64
+	// +optional
+	Region string `json:"region,omitempty"`
+}
+
+// AzureWorkloadIdentity is for configuring the Azure AD application federated with the ServiceAccount token.
+type AzureWorkloadIdentity struct {
+	// clientID is the client ID of the Azure AD application or user-assigned managed identity. It is set as the
+	// `azure.workload.identity/client-id` annotation on the ServiceAccount and, when serviceAccountToken is configured,
+	// as the `AZURE_CLIENT_ID` environment variable.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=64
+	// +required
+	ClientID string `json:"clientID,omitempty"`
+
+	// tenantID is the ID of the Azure AD tenant. It is set as the `azure.workload.identity/tenant-id` annotation on the
+	// ServiceAccount and, when serviceAccountToken is configured, as the `AZURE_TENANT_ID` environment variable.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=64
+	// +optional
+	TenantID string `json:"tenantID,omitempty"`
+}
+
+// GCPWorkloadIdentity is for configuring the Google Cloud service account impersonated using the ServiceAccount token.
+type GCPWorkloadIdentity struct {
+	// serviceAccountEmail is the email of the Google Cloud service account. It is set as the
+	// `iam.gke.io/gcp-service-account` annotation on the ServiceAccount.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=254
+	// +required
+	ServiceAccountEmail string `json:"serviceAccountEmail,omitempty"`
+
+	// workloadIdentityProvider is the full resource name of the workload identity pool provider, of the form
+	// `//iam.googleapis.com/projects/<project-number>/locations/global/workloadIdentityPools/<pool-id>/providers/<provider-id>`.
+	// When configured, the operator generates an external account credential configuration for exchanging the projected
+	// ServiceAccount token and sets the `GOOGLE_APPLICATION_CREDENTIALS` environment variable.
+	// +kubebuilder:validation:MaxLength:=512
+	// +kubebuilder:validation:Pattern:=`^//iam\.googleapis\.com/projects/[0-9]+/locations/global/workloadIdentityPools/[^/]+/providers/[^/]+$`
+	// +optional
+	WorkloadIdentityProvider string `json:"workloadIdentityProvider,omitempty"`
+}
+
+// ProjectedServiceAccountToken is for configuring the bound ServiceAccount token projected into the component's container.
+type ProjectedServiceAccountToken struct {
+	// audience is the intended audience of the token. The recipient of the token must identify itself with this audience.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=512
+	// +required
+	Audience string `json:"audience,omitempty"`
+
+	// expirationSeconds is the requested validity duration of the token. The kubelet rotates the token
+	// when it is older than 80 percent of its time to live.
+	// +kubebuilder:default:=3600
+	// +kubebuilder:validation:Minimum:=600
+	// +kubebuilder:validation:Maximum:=86400
+	// +optional
+	ExpirationSeconds int64 `json:"expirationSeconds,omitempty"`
+
+	// mountPath is the directory in the container where the token is made available with the file name `token`.
+	// +kubebuilder:default:="/var/run/secrets/openshift/serviceaccount"
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=256
+	// +kubebuilder:validation:Pattern:=`^/[^:]*$`
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
 }
 
 // DeploymentConfig defines configuration overrides for a Kubernetes Deployment resource.
+// +kubebuilder:validation:XValidation:rule="!has(self.strategy) || !has(self.strategy.type) || self.strategy.type != 'Recreate' || !has(self.strategy.rollingUpdate)",message="strategy.rollingUpdate must not be set when strategy.type is Recreate"
+// +kubebuilder:validation:XValidation:rule="!has(self.progressDeadlineSeconds) || !has(self.minReadySeconds) || self.progressDeadlineSeconds > self.minReadySeconds",message="progressDeadlineSeconds must be greater than minReadySeconds"
 type DeploymentConfig struct {
 	// revisionHistoryLimit specifies the number of old ReplicaSets to retain for rollback purposes.
 	// This allows rolling back to previous deployment versions using 'kubectl rollout undo'.
@@ -195,9 +813,205 @@ type DeploymentConfig struct {
 	// +kubebuilder:validation:Maximum=50
 	// +optional
 	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// strategy specifies the strategy for replacing the existing pods with new ones, either `RollingUpdate`
+	// with the optional `maxSurge` and `maxUnavailable`, or `Recreate`.
+	// If not specified, the pods are replaced with `RollingUpdate`, with 25% `maxSurge` and `maxUnavailable`.
+	// +optional
+	Strategy *appsv1.DeploymentStrategy `json:"strategy,omitempty"`
+
+	// minReadySeconds specifies the minimum number of seconds a new pod must be ready, without any of its
+	// containers crashing, to be considered available.
+	// If not specified, defaults to 0, and the pod is considered available as soon as it is ready.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+
+	// progressDeadlineSeconds specifies the maximum number of seconds for the deployment to make progress, before
+	// the rollout is reported as failed in the deployment status. Must be greater than minReadySeconds.
+	// If not specified, defaults to 600.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=7200
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// livenessProbe specifies the timing of the liveness probe of the component container. When the container has
+	// no liveness probe, the probe is added checking the same endpoint as the readiness probe.
+	// +optional
+	LivenessProbe *ProbeConfig `json:"livenessProbe,omitempty"`
+
+	// readinessProbe specifies the timing of the readiness probe of the component container.
+	// +optional
+	ReadinessProbe *ProbeConfig `json:"readinessProbe,omitempty"`
+
+	// startupProbe specifies the timing of the startup probe of the component container. When the container has
+	// no startup probe, the probe is added checking the same endpoint as the readiness probe. The other probes
+	// are not run until the startup probe succeeds, which allows for a component slow to start, like the
+	// webhook waiting for its serving certificate.
+	// +optional
+	StartupProbe *ProbeConfig `json:"startupProbe,omitempty"`
+}
+
+// ProbeConfig is for tuning a probe of a component container. The endpoint checked by the probe is defined by the
+// operator, and only the timing and the failure threshold are configurable.
+type ProbeConfig struct {
+	// initialDelaySeconds is the number of seconds after the container has started before the probe is run.
+	// If not specified, defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// periodSeconds is how often in seconds the probe is run.
+	// If not specified, defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// timeoutSeconds is the number of seconds after which the probe times out.
+	// If not specified, defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// failureThreshold is the number of consecutive failures of the probe for the container to be considered
+	// failed, restarted for the liveness and the startup probes, and not ready for the readiness probe.
+	// If not specified, defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// NamespaceConfig is for configuring the labels of the external-secrets operand namespace.
+type NamespaceConfig struct {
+	// podSecurity is for configuring the Pod Security Admission levels of the operand namespace. The pods of the
+	// external-secrets components are created in dry-run mode in the namespace before the deployments are applied,
+	// and the configuration is rejected when the API server denies the pods for not satisfying the enforce level.
+	// The pods not satisfying the warn level are reported in the events.
+	// On OpenShift, the synchronization of the Pod Security Admission labels by the cluster is disabled for the
+	// namespace when configured.
+	// +optional
+	PodSecurity *PodSecurityConfig `json:"podSecurity,omitempty"`
+
+	// clusterMonitoring indicates whether the operand namespace is included in the OpenShift cluster monitoring,
+	// which can be indicated by setting Enabled or Disabled.
+	// Enabled: Adds the `openshift.io/cluster-monitoring=true` label to the namespace, for the monitoring resources
+	// in the namespace to be processed by the OpenShift cluster monitoring stack.
+	// Disabled: The label is not added, and the monitoring resources are processed by the user workload monitoring
+	// when enabled in the cluster, which is the default behavior.
+	// +kubebuilder:validation:Enum:=Enabled;Disabled
+	// +kubebuilder:default:=Disabled
+	// +optional
+	ClusterMonitoring Mode `json:"clusterMonitoring,omitempty"`
+}
+
+// PodSecurityLevel is the level of the Pod Security Standards.
+// +kubebuilder:validation:Enum:=privileged;baseline;restricted
+type PodSecurityLevel string
+
+const (
+	// PodSecurityLevelPrivileged is the unrestricted Pod Security Standards level.
+	PodSecurityLevelPrivileged PodSecurityLevel = "privileged"
+
+	// PodSecurityLevelBaseline is the Pod Security Standards level preventing the known privilege escalations.
+	PodSecurityLevelBaseline PodSecurityLevel = "baseline"
+
+	// PodSecurityLevelRestricted is the Pod Security Standards level following the pod hardening best practices.
+	PodSecurityLevelRestricted PodSecurityLevel = "restricted"
+)
+
+// PodSecurityConfig is for configuring the Pod Security Admission levels of the operand namespace.
+type PodSecurityConfig struct {
+	// enforce is the Pod Security Standards level above which the pods are rejected in the namespace.
+	// If not specified, defaults to restricted.
+	// +kubebuilder:default:=restricted
+	// +optional
+	Enforce PodSecurityLevel `json:"enforce,omitempty"`
+
+	// audit is the Pod Security Standards level above which the pods are recorded in the audit log.
+	// If not specified, defaults to restricted.
+	// +kubebuilder:default:=restricted
+	// +optional
+	Audit PodSecurityLevel `json:"audit,omitempty"`
+
+	// warn is the Pod Security Standards level above which a warning is returned for the pods created in the namespace.
+	// If not specified, defaults to restricted.
+	// +kubebuilder:default:=restricted
+	// +optional
+	Warn PodSecurityLevel `json:"warn,omitempty"`
+}
+
+// MonitoringConfig is for configuring the ServiceMonitors and the alerting rules created for the external-secrets operand.
+// +kubebuilder:validation:XValidation:rule="!has(self.alerts) || self.mode == 'Enabled'",message="alerts can only be configured when mode is set to Enabled."
+type MonitoringConfig struct {
+	// mode indicates whether the monitoring resources are created for the external-secrets operand, which can be indicated by setting Enabled or Disabled.
+	// Enabled: Creates the ServiceMonitors and the PrometheusRule when the Prometheus Operator CRDs are installed, which is the default behavior.
+	// Disabled: The ServiceMonitors and the PrometheusRule are not created, and the ones created earlier are removed.
+	// +kubebuilder:validation:Enum:=Enabled;Disabled
+	// +kubebuilder:default:=Enabled
+	// +optional
+	Mode Mode `json:"mode,omitempty"`
+
+	// scrapeInterval is the interval at which the metrics of the external-secrets components are scraped, in the
+	// Prometheus duration format, e.g. `30s` or `1m`.
+	// If not specified, defaults to 30s.
+	// +kubebuilder:default:="30s"
+	// +kubebuilder:validation:MinLength:=2
+	// +kubebuilder:validation:MaxLength:=16
+	// +kubebuilder:validation:Pattern:=`^(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?$`
+	// +optional
+	ScrapeInterval string `json:"scrapeInterval,omitempty"`
+
+	// alerts is for configuring the alerting rules created for the external-secrets operand.
+	// +optional
+	Alerts *AlertsConfig `json:"alerts,omitempty"`
+}
+
+// AlertsConfig is for configuring the alerting rules of the PrometheusRule created for the external-secrets operand.
+type AlertsConfig struct {
+	// mode indicates whether the PrometheusRule is created, which can be indicated by setting Enabled or Disabled.
+	// Enabled: Creates the PrometheusRule with the alerts for the sync errors, the webhook latency and the webhook certificate expiry, which is the default behavior.
+	// Disabled: The PrometheusRule is not created, and the one created earlier is removed.
+	// +kubebuilder:validation:Enum:=Enabled;Disabled
+	// +kubebuilder:default:=Enabled
+	// +optional
+	Mode Mode `json:"mode,omitempty"`
+
+	// syncErrorsThreshold is the number of failed synchronizations of an ExternalSecret within 15 minutes above which
+	// the ExternalSecretSyncErrors alert fires.
+	// If not specified, defaults to 0, where the alert fires on any failed synchronization.
+	// +kubebuilder:default:=0
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=1000
+	// +optional
+	SyncErrorsThreshold int32 `json:"syncErrorsThreshold,omitempty"`
+
+	// webhookLatencyThresholdMilliseconds is the 99th percentile latency of the external-secrets webhook admission
+	// requests above which the ExternalSecretsWebhookHighLatency alert fires.
+	// If not specified, defaults to 1000.
+	// +kubebuilder:default:=1000
+	// +kubebuilder:validation:Minimum:=100
+	// +kubebuilder:validation:Maximum:=30000
+	// +optional
+	WebhookLatencyThresholdMilliseconds int32 `json:"webhookLatencyThresholdMilliseconds,omitempty"`
+
+	// certificateExpiryThresholdDays is the remaining validity of the external-secrets webhook serving certificate
+	// in days below which the ExternalSecretsWebhookCertificateExpiring alert fires. The alert is based on the
+	// operator metrics, and is created in a PrometheusRule in the operator namespace.
+	// If not specified, defaults to 7.
+	// +kubebuilder:default:=7
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=365
+	// +optional
+	CertificateExpiryThresholdDays int32 `json:"certificateExpiryThresholdDays,omitempty"`
 }
 
 // BitwardenSecretManagerProvider is for enabling the bitwarden secrets manager provider and for setting up the additional service required for connecting with the bitwarden server.
+// +kubebuilder:validation:XValidation:rule="!has(self.clusterSecretStore) || self.mode == 'Enabled'",message="clusterSecretStore can only be configured when mode is set to Enabled."
 type BitwardenSecretManagerProvider struct {
 	// mode indicates bitwarden secrets manager provider state, which can be indicated by setting Enabled or Disabled.
 	// Enabled: Enables the Bitwarden provider plugin. The operator will ensure the plugin is deployed and its state is synchronized.
@@ -212,6 +1026,62 @@ type BitwardenSecretManagerProvider struct {
 	// The key names in secret for certificate must be `tls.crt`, for private key must be `tls.key` and for CA certificate key name must be `ca.crt`.
 	// +optional
 	SecretRef *SecretReference `json:"secretRef,omitempty"`
+
+	// clusterSecretStore is for creating a ClusterSecretStore for the bitwarden secrets manager provider, which is
+	// configured with the bitwarden-sdk-server URL and the CA certificate of the bitwarden-sdk-server TLS secret.
+	// The ClusterSecretStore is created once the external-secrets webhook is available and is removed when this
+	// field is unset.
+	// +optional
+	ClusterSecretStore *BitwardenClusterSecretStoreConfig `json:"clusterSecretStore,omitempty"`
+}
+
+// BitwardenClusterSecretStoreConfig is for configuring the ClusterSecretStore created for the bitwarden secrets manager provider.
+type BitwardenClusterSecretStoreConfig struct {
+	// name is the name of the ClusterSecretStore resource.
+	// +kubebuilder:default:="bitwarden-secretsmanager"
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// organizationID is the ID of the bitwarden organization the secrets are fetched from.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=128
+	// +required
+	OrganizationID string `json:"organizationID,omitempty"`
+
+	// projectID is the ID of the bitwarden project the secrets are fetched from.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=128
+	// +required
+	ProjectID string `json:"projectID,omitempty"`
+
+	// credentialsSecretRef is the reference to the Kubernetes secret containing the bitwarden machine account access token.
+	// +required
+	CredentialsSecretRef BitwardenCredentialsSecretReference `json:"credentialsSecretRef"`
+}
+
+// BitwardenCredentialsSecretReference is the reference to the key of a secret containing the bitwarden access token.
+type BitwardenCredentialsSecretReference struct {
+	// name of the secret resource being referred to.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +required
+	Name string `json:"name,omitempty"`
+
+	// namespace of the secret resource being referred to.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=63
+	// +required
+	Namespace string `json:"namespace,omitempty"`
+
+	// key is the key in the secret data containing the access token.
+	// +kubebuilder:default:="token"
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +optional
+	Key string `json:"key,omitempty"`
 }
 
 // WebhookConfig is for configuring external-secrets webhook specifics.
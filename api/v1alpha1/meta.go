@@ -132,6 +132,13 @@ const (
 
 	// ManagementStateUnmanaged indicates the User is responsible for the resource lifecycle.
 	ManagementStateUnmanaged ManagementState = "Unmanaged"
+
+	// ManagementStateRemoved indicates the Operator removes the resources it created.
+	ManagementStateRemoved ManagementState = "Removed"
+
+	// ManagementStatePlan indicates the Operator only reports the changes it would make to the resources,
+	// without making them.
+	ManagementStatePlan ManagementState = "Plan"
 )
 
 // Mode indicates the operational state of the optional features.
@@ -0,0 +1,161 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	SchemeBuilder.Register(&SecretStoreTemplate{}, &SecretStoreTemplateList{})
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+
+// SecretStoreTemplateList is a list of SecretStoreTemplate objects.
+type SecretStoreTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is the standard list's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata"`
+
+	Items []SecretStoreTemplate `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=secretstoretemplates,scope=Cluster,categories={external-secrets-operator, external-secrets},shortName=sst;secretstoretemplate
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].message"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:metadata:labels={"app.kubernetes.io/name=secretstoretemplate", "app.kubernetes.io/part-of=external-secrets-operator"}
+
+// SecretStoreTemplate describes the SecretStore, and optionally the ServiceAccount and the RoleBinding, to be created
+// in every namespace matching the namespace selector, for onboarding the namespaces with a uniform setup.
+//
+// The resources are created when a namespace starts matching the selector, kept in the configured state, and removed
+// when the namespace stops matching the selector or when the SecretStoreTemplate is deleted. Existing resources of
+// the same name not created from the SecretStoreTemplate are not modified, and are reported in the Ready condition.
+//
+// +operator-sdk:csv:customresourcedefinitions:displayName="SecretStoreTemplate"
+type SecretStoreTemplate struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is the standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +required
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec is the specification of the desired behavior of the SecretStoreTemplate.
+	// +required
+	Spec SecretStoreTemplateSpec `json:"spec,omitempty"`
+
+	// status is the most recently observed status of the SecretStoreTemplate.
+	// +optional
+	Status SecretStoreTemplateStatus `json:"status,omitempty"`
+}
+
+// SecretStoreTemplateSpec is the specification of the desired behavior of the SecretStoreTemplate.
+// +kubebuilder:validation:XValidation:rule="(has(self.namespaceSelector.matchLabels) && size(self.namespaceSelector.matchLabels) > 0) || (has(self.namespaceSelector.matchExpressions) && size(self.namespaceSelector.matchExpressions) > 0)",message="namespaceSelector must have matchLabels or matchExpressions configured"
+// +kubebuilder:validation:XValidation:rule="!has(self.roleBinding) || has(self.serviceAccount)",message="serviceAccount must be configured when roleBinding is configured"
+type SecretStoreTemplateSpec struct {
+	// namespaceSelector is the label selector for the namespaces the resources are to be created in.
+	// The selector must not be empty, for avoiding the resources to be created in all the namespaces. The `openshift`
+	// namespace and the namespaces prefixed with `openshift-` or `kube-` are never selected.
+	// +required
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+
+	// serviceAccount is the ServiceAccount to be created in the selected namespaces, which can be referred in the
+	// SecretStore for authenticating with the provider, for example, with the Vault Kubernetes auth.
+	// +optional
+	ServiceAccount *SecretStoreTemplateServiceAccount `json:"serviceAccount,omitempty"`
+
+	// roleBinding is the RoleBinding to be created in the selected namespaces, for granting the role to the ServiceAccount.
+	// +optional
+	RoleBinding *SecretStoreTemplateRoleBinding `json:"roleBinding,omitempty"`
+
+	// secretStore is the SecretStore to be created in the selected namespaces.
+	// +required
+	SecretStore SecretStoreTemplateSecretStore `json:"secretStore"`
+}
+
+// SecretStoreTemplateServiceAccount is the ServiceAccount to be created in the selected namespaces.
+type SecretStoreTemplateServiceAccount struct {
+	// name is the name of the ServiceAccount.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +required
+	Name string `json:"name,omitempty"`
+
+	// annotations to be added to the ServiceAccount, for example, for configuring the cloud workload identity.
+	// The `$(NAMESPACE)` variable in the values is replaced with the name of the namespace.
+	// This field can have a maximum of 20 entries.
+	// +mapType=granular
+	// +kubebuilder:validation:MinProperties:=0
+	// +kubebuilder:validation:MaxProperties:=20
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// SecretStoreTemplateRoleBinding is the RoleBinding to be created in the selected namespaces.
+type SecretStoreTemplateRoleBinding struct {
+	// name is the name of the RoleBinding.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +required
+	Name string `json:"name,omitempty"`
+
+	// roleRef is the reference to the Role in the selected namespace or to the ClusterRole to be bound to the ServiceAccount.
+	// The ClusterRole can only be `system:auth-delegator`, and a Role can only be bound when the operator is granted
+	// all the permissions of the Role.
+	// +required
+	RoleRef SecretStoreTemplateRoleRef `json:"roleRef"`
+}
+
+// SecretStoreTemplateRoleRef is the reference to the role to be bound.
+type SecretStoreTemplateRoleRef struct {
+	// kind is the kind of the role being referred to.
+	// +kubebuilder:validation:Enum:=Role;ClusterRole
+	// +required
+	Kind string `json:"kind,omitempty"`
+
+	// name is the name of the role being referred to.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +required
+	Name string `json:"name,omitempty"`
+}
+
+// SecretStoreTemplateSecretStore is the SecretStore to be created in the selected namespaces.
+type SecretStoreTemplateSecretStore struct {
+	// name is the name of the SecretStore.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +required
+	Name string `json:"name,omitempty"`
+
+	// spec is the `spec` of the SecretStore resource, as defined by the external-secrets.io/v1 API.
+	// The `$(NAMESPACE)` variable in the string values is replaced with the name of the namespace.
+	// +kubebuilder:validation:Type:=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +required
+	Spec runtime.RawExtension `json:"spec"`
+}
+
+// SecretStoreTemplateStatus is the most recently observed status of the SecretStoreTemplate.
+type SecretStoreTemplateStatus struct {
+	// conditions holds information of the current state of the SecretStoreTemplate.
+	ConditionalStatus `json:",inline"`
+
+	// namespaces is the list of namespaces the resources are created in.
+	// +listType=set
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/openshift/external-secrets-operator/pkg/diagnostics"
+)
+
+const (
+	// diagnoseCommand is the name of the subcommand collecting the diagnostics bundle for support cases.
+	diagnoseCommand = "diagnose"
+)
+
+// runDiagnose collects the state of the operator and the operand from the cluster into a gzip compressed tar
+// archive written to the output file, with the data of the secrets redacted.
+func runDiagnose(args []string, out io.Writer) error {
+	var (
+		kubeconfig string
+		output     string
+		opts       diagnostics.Options
+	)
+
+	flags := flag.NewFlagSet(diagnoseCommand, flag.ContinueOnError)
+	flags.StringVar(&kubeconfig, "kubeconfig", "", "Path of the kubeconfig file for accessing the cluster. "+
+		"When not set, the in-cluster configuration or the KUBECONFIG environment variable is used.")
+	flags.StringVar(&output, "output", fmt.Sprintf("external-secrets-diagnostics-%s.tar.gz", time.Now().UTC().Format("20060102-150405")),
+		"Path of the file the diagnostics archive is written to. Use - to write to the standard output.")
+	flags.StringVar(&opts.OperatorNamespace, "operator-namespace", "external-secrets-operator", "Namespace the operator is installed in.")
+	flags.StringVar(&opts.OperandNamespace, "operand-namespace", "external-secrets", "Namespace the external-secrets operand is installed in.")
+	flags.Func("namespaces", "Comma separated list of the namespaces collected in addition to the operator and the operand namespaces.",
+		func(value string) error {
+			opts.Namespaces = append(opts.Namespaces, strings.Split(value, ",")...)
+			return nil
+		})
+	flags.Int64Var(&opts.LogTailLines, "log-tail-lines", 500, "Number of the most recent log lines collected from each container.")
+	setImageEnvVars := addImageFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := setImageEnvVars(); err != nil {
+		return err
+	}
+
+	config, err := loadClusterConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	if output == "-" {
+		return diagnostics.Collect(ctx, config, scheme, opts, out)
+	}
+	file, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", output, err)
+	}
+	if err := diagnostics.Collect(ctx, config, scheme, opts, file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+	_, err = fmt.Fprintf(out, "Diagnostics written to %s\n", output)
+	return err
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+	escontroller "github.com/openshift/external-secrets-operator/pkg/controller/external_secrets"
+)
+
+const (
+	// diffCommand is the name of the subcommand printing the changes the operator would make to the resources in
+	// the cluster.
+	diffCommand = "diff"
+)
+
+// runDiff compares the resources the operator would create for the ExternalSecretsConfig and the
+// ExternalSecretsManager with the resources in the cluster, and writes the field level differences to out
+// without making any change. The configuration is read from the cluster, unless passed in the files.
+func runDiff(args []string, out io.Writer) error {
+	var (
+		kubeconfig  string
+		configFile  string
+		managerFile string
+	)
+
+	flags := flag.NewFlagSet(diffCommand, flag.ContinueOnError)
+	flags.StringVar(&kubeconfig, "kubeconfig", "", "Path of the kubeconfig file for accessing the cluster. "+
+		"When not set, the in-cluster configuration or the KUBECONFIG environment variable is used.")
+	flags.StringVar(&configFile, "config", "", "Path of the file containing the externalsecretsconfigs.operator.openshift.io object to compare. "+
+		"When not set, the object in the cluster is used.")
+	flags.StringVar(&managerFile, "manager", "", "Path of the file containing the externalsecretsmanagers.operator.openshift.io object "+
+		"with the global configuration. When not set, the object in the cluster is used.")
+	setImageEnvVars := addImageFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := setImageEnvVars(); err != nil {
+		return err
+	}
+
+	config, err := loadClusterConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	esc := &operatorv1alpha1.ExternalSecretsConfig{}
+	if configFile != "" {
+		err = decodeFile(configFile, esc)
+	} else {
+		err = c.Get(ctx, types.NamespacedName{Name: common.ExternalSecretsConfigObjectName}, esc)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read externalsecretsconfigs.operator.openshift.io: %w", err)
+	}
+	esm := &operatorv1alpha1.ExternalSecretsManager{}
+	if managerFile != "" {
+		err = decodeFile(managerFile, esm)
+	} else if err = c.Get(ctx, types.NamespacedName{Name: common.ExternalSecretsManagerObjectName}, esm); errors.IsNotFound(err) {
+		err = nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read externalsecretsmanagers.operator.openshift.io: %w", err)
+	}
+
+	changes, err := escontroller.Plan(ctx, config, scheme, esc, esm)
+	if err != nil {
+		return fmt.Errorf("failed to compute changes: %w", err)
+	}
+	return escontroller.WriteChanges(out, changes)
+}
+
+// loadClusterConfig returns the configuration for accessing the cluster from the kubeconfig file, or from the
+// in-cluster configuration or the KUBECONFIG environment variable when the file is not set.
+func loadClusterConfig(kubeconfig string) (*rest.Config, error) {
+	var (
+		config *rest.Config
+		err    error
+	)
+	if kubeconfig != "" {
+		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	} else {
+		config, err = ctrl.GetConfig()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cluster configuration: %w", err)
+	}
+	return config, nil
+}
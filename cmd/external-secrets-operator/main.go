@@ -22,6 +22,7 @@ import (
 	"crypto/x509"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -112,11 +113,26 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		if command, ok := map[string]func([]string, io.Writer) error{
+			renderCommand:   runRender,
+			diffCommand:     runDiff,
+			diagnoseCommand: runDiagnose,
+		}[os.Args[1]]; ok {
+			if err := command(os.Args[2:], os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[1], err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	var (
 		enableLeaderElection bool
 		probeAddr            string
 		logLevel             int
 		enableHTTP2          bool
+		enableWebhooks       bool
 		secureMetrics        bool
 		metricsAddr          string
 		metricsCerts         string
@@ -134,6 +150,8 @@ func main() {
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", true,
+		"If set, the admission webhooks validating the operator resources are served by the webhook server")
 	flag.IntVar(&logLevel, "v", 1, "operator log verbosity")
 	flag.StringVar(&metricsCerts, "metrics-cert-dir", "",
 		"Secret name containing the certificates for the metrics server which should be present in operator namespace. "+
@@ -222,7 +240,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := operator.StartControllers(ctx, mgr); err != nil {
+	if err := operator.StartControllers(ctx, mgr, enableWebhooks); err != nil {
 		setupLog.Error(err, "failed to start controllers")
 		os.Exit(1)
 	}
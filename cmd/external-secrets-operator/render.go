@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	escontroller "github.com/openshift/external-secrets-operator/pkg/controller/external_secrets"
+)
+
+const (
+	// renderCommand is the name of the subcommand printing the operand resources for a configuration.
+	renderCommand = "render"
+)
+
+// runRender renders the resources created by the operator for the ExternalSecretsConfig and the
+// ExternalSecretsManager read from the files passed in the arguments, and writes them to out as a
+// multi-document YAML stream. The operand images are read from the same environment variables used by
+// the operator, and can be overridden with the flags.
+func runRender(args []string, out io.Writer) error {
+	var (
+		configFile  string
+		managerFile string
+	)
+
+	flags := flag.NewFlagSet(renderCommand, flag.ContinueOnError)
+	flags.StringVar(&configFile, "config", "", "Path of the file containing the externalsecretsconfigs.operator.openshift.io object to render.")
+	flags.StringVar(&managerFile, "manager", "", "Path of the file containing the externalsecretsmanagers.operator.openshift.io object "+
+		"with the global configuration. Optional.")
+	setImageEnvVars := addImageFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if configFile == "" {
+		return fmt.Errorf("--config is required")
+	}
+	if err := setImageEnvVars(); err != nil {
+		return err
+	}
+
+	esc := &operatorv1alpha1.ExternalSecretsConfig{}
+	if err := decodeFile(configFile, esc); err != nil {
+		return err
+	}
+	var esm *operatorv1alpha1.ExternalSecretsManager
+	if managerFile != "" {
+		esm = &operatorv1alpha1.ExternalSecretsManager{}
+		if err := decodeFile(managerFile, esm); err != nil {
+			return err
+		}
+	}
+
+	objects, err := escontroller.Render(scheme, esc, esm)
+	if err != nil {
+		return fmt.Errorf("failed to render resources for %s: %w", configFile, err)
+	}
+
+	encoder := json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme, json.SerializerOptions{Yaml: true})
+	for _, object := range objects {
+		unstructured.RemoveNestedField(object.Object, "metadata", "creationTimestamp")
+		unstructured.RemoveNestedField(object.Object, "status")
+		if _, err := fmt.Fprintln(out, "---"); err != nil {
+			return err
+		}
+		if err := encoder.Encode(object, out); err != nil {
+			return fmt.Errorf("failed to encode %s %s: %w", object.GetKind(), object.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// addImageFlags adds the flags for overriding the environment variables with the operand images used by the
+// operator, and returns the function setting the environment variables to the flag values.
+func addImageFlags(flags *flag.FlagSet) func() error {
+	imageEnvVarFlags := make(map[string]*string)
+	for flagName, envVar := range map[string]string{
+		"external-secrets-image":         "RELATED_IMAGE_EXTERNAL_SECRETS",
+		"external-secrets-image-version": "OPERAND_EXTERNAL_SECRETS_IMAGE_VERSION",
+		"bitwarden-sdk-server-image":     "RELATED_IMAGE_BITWARDEN_SDK_SERVER",
+		"bitwarden-sdk-server-version":   "BITWARDEN_SDK_SERVER_IMAGE_VERSION",
+	} {
+		imageEnvVarFlags[envVar] = flags.String(flagName, os.Getenv(envVar), fmt.Sprintf("Value of the %s environment variable.", envVar))
+	}
+	return func() error {
+		for envVar, value := range imageEnvVarFlags {
+			if err := os.Setenv(envVar, *value); err != nil {
+				return fmt.Errorf("failed to set %s environment variable: %w", envVar, err)
+			}
+		}
+		return nil
+	}
+}
+
+// decodeFile decodes the object in the YAML or JSON file into obj.
+func decodeFile(path string, obj runtime.Object) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if _, _, err := serializer.NewCodecFactory(scheme).UniversalDeserializer().Decode(data, nil, obj); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+)
+
+const (
+	// FieldManager is the field manager of the fields applied by the operator with server-side apply.
+	FieldManager = "external-secrets-operator"
+)
+
+// FieldPath is the path of a field, as the names of the fields from the root of the object.
+type FieldPath []string
+
+// YieldFields is an apply option for leaving the fields at the paths to the other field managers, when owned by
+// them. The fields are applied only when not owned by another field manager, while the ownership of the other fields
+// applied is taken over from the other field managers.
+type YieldFields []FieldPath
+
+// ApplyToApply implements client.ApplyOption. The yielded fields are removed from the applied configuration, and
+// the option does not set any of the apply options.
+func (YieldFields) ApplyToApply(*client.ApplyOptions) {}
+
+// getYieldFields returns the paths of the fields yielded with the YieldFields apply options.
+func getYieldFields(opts ...client.ApplyOption) []FieldPath {
+	var paths []FieldPath
+	for _, opt := range opts {
+		if fields, ok := opt.(YieldFields); ok {
+			paths = append(paths, fields...)
+		}
+	}
+	return paths
+}
+
+// String returns the path with the names of the fields joined with dots.
+func (p FieldPath) String() string {
+	return strings.Join(p, ".")
+}
+
+// IsRelated returns whether the paths are the same, or one is the path of a parent field of the other.
+func (p FieldPath) IsRelated(other FieldPath) bool {
+	n := min(len(p), len(other))
+	return slices.Equal(p[:n], other[:n])
+}
+
+// ApplyConfiguration returns the content of obj applied with server-side apply, which are the fields set in obj
+// except the status and the metadata set by the API server. The unset fields, including the zero structs converted
+// to empty maps, are not applied, as the operator would otherwise own them. The yielded fields owned by another
+// field manager in live are removed, when live is not nil.
+func ApplyConfiguration(obj client.Object, live client.Object, yield ...FieldPath) (map[string]interface{}, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s to unstructured: %w", obj.GetName(), err)
+	}
+	// copy to avoid modifying the content shared with obj, when obj is unstructured.
+	content = runtime.DeepCopyJSON(content)
+	delete(content, "status")
+	if metadata, ok := content["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"creationTimestamp", "deletionTimestamp", "deletionGracePeriodSeconds",
+			"generation", "managedFields", "resourceVersion", "selfLink", "uid"} {
+			delete(metadata, field)
+		}
+	}
+	pruneEmptyFields(content, reflect.ValueOf(obj))
+
+	if live == nil {
+		return content, nil
+	}
+	for _, path := range yield {
+		owned, err := isFieldOwnedByOthers(live.GetManagedFields(), path)
+		if err != nil {
+			return nil, err
+		}
+		if owned {
+			removeField(content, path)
+		}
+	}
+	return content, nil
+}
+
+// pruneEmptyFields removes the nil values from the fields of the map, recursively, and the fields of obj with a zero
+// struct value, which are converted to maps instead of being omitted like the other unset fields. The empty maps and
+// lists set explicitly, like the pointers to empty structs, are kept as they are meaningful, for example an empty
+// namespaceSelector selecting all the namespaces, or an emptyDir volume source.
+func pruneEmptyFields(content map[string]interface{}, obj reflect.Value) {
+	fields := map[string]reflect.Value{}
+	if obj = indirect(obj); obj.Kind() == reflect.Struct {
+		addStructFields(fields, obj)
+	}
+	for key, value := range content {
+		field := fields[key]
+		_, isMap := value.(map[string]interface{})
+		if value == nil || (isMap && field.Kind() == reflect.Struct && field.IsZero()) {
+			delete(content, key)
+			continue
+		}
+		pruneEmptyValue(value, field)
+	}
+}
+
+// addStructFields adds the exported fields of the struct to fields by their JSON names, including the fields of the
+// inlined structs.
+func addStructFields(fields map[string]reflect.Value, obj reflect.Value) {
+	for i := range obj.NumField() {
+		field, value := obj.Type().Field(i), obj.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch {
+		case name == "-":
+		case name == "" && (field.Anonymous || slices.Contains(strings.Split(options, ","), "inline")):
+			if value.Kind() == reflect.Struct {
+				addStructFields(fields, value)
+			}
+		case name == "":
+			fields[field.Name] = value
+		default:
+			fields[name] = value
+		}
+	}
+}
+
+// pruneEmptyValue prunes the empty fields of the maps in the value, converted from obj.
+func pruneEmptyValue(value interface{}, obj reflect.Value) {
+	obj = indirect(obj)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		pruneEmptyFields(v, obj)
+	case []interface{}:
+		for i, item := range v {
+			var itemObj reflect.Value
+			if (obj.Kind() == reflect.Slice || obj.Kind() == reflect.Array) && i < obj.Len() {
+				itemObj = obj.Index(i)
+			}
+			pruneEmptyValue(item, itemObj)
+		}
+	}
+}
+
+// indirect returns the value pointed to by the pointers and the interfaces in v, or the zero Value when nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// removeField removes the field at the path from the content, and the maps left empty by the removal.
+func removeField(content map[string]interface{}, path FieldPath) {
+	if len(path) == 1 {
+		delete(content, path[0])
+		return
+	}
+	child, ok := content[path[0]].(map[string]interface{})
+	if !ok {
+		return
+	}
+	removeField(child, path[1:])
+	if len(child) == 0 {
+		delete(content, path[0])
+	}
+}
+
+// isFieldOwnedByOthers returns whether the field at the path, or any of its parent or child fields, is owned by a
+// field manager other than the operator, including the field managers updating the subresources like scale.
+func isFieldOwnedByOthers(managedFields []metav1.ManagedFieldsEntry, path FieldPath) (bool, error) {
+	for _, entry := range managedFields {
+		if entry.Manager == FieldManager {
+			continue
+		}
+		paths, err := ManagedFieldPaths(entry)
+		if err != nil {
+			return false, err
+		}
+		for _, owned := range paths {
+			if owned.IsRelated(path) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// ManagedFieldPaths returns the paths of the fields in the managed fields entry. The paths end at the lists, and the fields of the list items are not returned separately, since the
+// list items are identified by keys depending on the schema of the resource.
+func ManagedFieldPaths(entry metav1.ManagedFieldsEntry) ([]FieldPath, error) {
+	set := &fieldpath.Set{}
+	if entry.FieldsV1 != nil {
+		if err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return nil, fmt.Errorf("failed to decode fields managed by %s: %w", entry.Manager, err)
+		}
+	}
+	return fieldSetPaths(set), nil
+}
+
+// ContentFieldPaths returns the paths of the fields set in the content of an apply configuration, in the same form
+// as ManagedFieldPaths.
+func ContentFieldPaths(content map[string]interface{}) []FieldPath {
+	return fieldSetPaths(contentFieldSet(content))
+}
+
+// AppliedManagedFieldsEntry returns the managed fields entry of the operator for the content of an apply
+// configuration applied with server-side apply, with the lists recorded as atomic.
+func AppliedManagedFieldsEntry(content map[string]interface{}) (metav1.ManagedFieldsEntry, error) {
+	fields, err := contentFieldSet(content).ToJSON()
+	if err != nil {
+		return metav1.ManagedFieldsEntry{}, fmt.Errorf("failed to encode applied fields: %w", err)
+	}
+	return metav1.ManagedFieldsEntry{
+		Manager:    FieldManager,
+		Operation:  metav1.ManagedFieldsOperationApply,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: fields},
+	}, nil
+}
+
+// contentFieldSet returns the set of the fields in the content of an apply configuration, with the lists as leaves.
+// The fields identifying the object, which are not recorded in the managed fields, are not included.
+func contentFieldSet(content map[string]interface{}) *fieldpath.Set {
+	set := &fieldpath.Set{}
+	for key, value := range content {
+		switch key {
+		case "apiVersion", "kind":
+			continue
+		case "metadata":
+			if metadata, ok := value.(map[string]interface{}); ok {
+				for field, v := range metadata {
+					if field == "name" || field == "namespace" {
+						continue
+					}
+					addContentFields(fieldpath.MakePathOrDie("metadata", field), v, set)
+				}
+			}
+			continue
+		}
+		addContentFields(fieldpath.MakePathOrDie(key), value, set)
+	}
+	return set
+}
+
+// addContentFields adds the fields in the value at the path to the set.
+func addContentFields(path fieldpath.Path, value interface{}, set *fieldpath.Set) {
+	if m, ok := value.(map[string]interface{}); ok && len(m) > 0 {
+		for key, v := range m {
+			addContentFields(append(path.Copy(), fieldpath.PathElement{FieldName: &key}), v, set)
+		}
+		return
+	}
+	set.Insert(path)
+}
+
+// fieldSetPaths returns the paths of the leaf fields in the set, ending at the lists.
+func fieldSetPaths(set *fieldpath.Set) []FieldPath {
+	var paths []FieldPath
+	set.Leaves().Iterate(func(path fieldpath.Path) {
+		var names FieldPath
+		for _, element := range path {
+			if element.FieldName == nil {
+				break
+			}
+			names = append(names, *element.FieldName)
+		}
+		if len(names) > 0 && !slices.ContainsFunc(paths, func(p FieldPath) bool { return slices.Equal(p, names) }) {
+			paths = append(paths, names)
+		}
+	})
+	return paths
+}
+
+// IsDryRun returns whether the apply options request a dry-run, for which the changes are validated by the API
+// server without being persisted.
+func IsDryRun(opts ...client.ApplyOption) bool {
+	return len((&client.ApplyOptions{}).ApplyOptions(opts).DryRun) > 0
+}
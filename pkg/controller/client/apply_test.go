@@ -0,0 +1,162 @@
+package client
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestApplyConfiguration(t *testing.T) {
+	replicas := FieldPath{"spec", "replicas"}
+
+	tests := []struct {
+		name          string
+		yield         []FieldPath
+		managedFields []metav1.ManagedFieldsEntry
+		live          bool
+		want          map[string]interface{}
+	}{
+		{
+			name:  "server set metadata, status and empty fields are not applied",
+			yield: []FieldPath{replicas},
+			want: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "external-secrets", "labels": map[string]interface{}{"app": "external-secrets"}},
+				"spec":     map[string]interface{}{"replicas": int64(2)},
+			},
+		},
+		{
+			name:  "yielded field applied when not owned by other field managers",
+			yield: []FieldPath{replicas},
+			live:  true,
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: FieldManager, Operation: metav1.ManagedFieldsOperationApply, FieldsType: "FieldsV1",
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)}},
+			},
+			want: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "external-secrets", "labels": map[string]interface{}{"app": "external-secrets"}},
+				"spec":     map[string]interface{}{"replicas": int64(2)},
+			},
+		},
+		{
+			name:  "yielded field owned by another field manager is not applied",
+			yield: []FieldPath{replicas},
+			live:  true,
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kube-controller-manager", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "scale",
+					FieldsType: "FieldsV1", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)}},
+			},
+			want: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "external-secrets", "labels": map[string]interface{}{"app": "external-secrets"}},
+			},
+		},
+		{
+			name: "field not yielded applied when owned by another field manager",
+			live: true,
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kube-controller-manager", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "scale",
+					FieldsType: "FieldsV1", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)}},
+			},
+			want: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "external-secrets", "labels": map[string]interface{}{"app": "external-secrets"}},
+				"spec":     map[string]interface{}{"replicas": int64(2)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "external-secrets",
+					Labels:          map[string]string{"app": "external-secrets"},
+					ResourceVersion: "10",
+					UID:             "uid",
+					Generation:      2,
+				},
+				Spec:   appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+				Status: appsv1.DeploymentStatus{Replicas: 2},
+			}
+			var live *appsv1.Deployment
+			if tt.live {
+				live = obj.DeepCopy()
+				live.SetManagedFields(tt.managedFields)
+			}
+
+			var got map[string]interface{}
+			var err error
+			if live != nil {
+				got, err = ApplyConfiguration(obj, live, tt.yield...)
+			} else {
+				got, err = ApplyConfiguration(obj, nil, tt.yield...)
+			}
+			if err != nil {
+				t.Fatalf("ApplyConfiguration() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplyConfiguration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyConfigurationEmptyFields(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  client.Object
+		path []string
+		want map[string]interface{}
+	}{
+		{
+			name: "empty namespaceSelector selecting all namespaces is applied",
+			obj: &networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "allow-egress"},
+				Spec: networkingv1.NetworkPolicySpec{
+					Egress: []networkingv1.NetworkPolicyEgressRule{
+						{To: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}}},
+					},
+				},
+			},
+			path: []string{"spec", "egress"},
+			want: map[string]interface{}{"to": []interface{}{map[string]interface{}{"namespaceSelector": map[string]interface{}{}}}},
+		},
+		{
+			name: "emptyDir volume source is applied",
+			obj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "external-secrets"},
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Volumes: []corev1.Volume{{Name: "tmp", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+						},
+					},
+				},
+			},
+			path: []string{"spec", "template", "spec", "volumes"},
+			want: map[string]interface{}{"name": "tmp", "emptyDir": map[string]interface{}{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyConfiguration(tt.obj, nil)
+			if err != nil {
+				t.Fatalf("ApplyConfiguration() unexpected error: %v", err)
+			}
+			items, _, _ := unstructured.NestedSlice(got, tt.path...)
+			if len(items) != 1 || !reflect.DeepEqual(items[0], tt.want) {
+				t.Errorf("ApplyConfiguration() %s = %v, want [%v]", strings.Join(tt.path, "."), items, tt.want)
+			}
+			if _, exist := got["spec"].(map[string]interface{})["strategy"]; exist {
+				t.Errorf("ApplyConfiguration() unexpected unset spec.strategy applied")
+			}
+		})
+	}
+}
@@ -6,9 +6,12 @@ import (
 	"reflect"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/util/retry"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
 type CtrlClientImpl struct {
@@ -26,6 +29,7 @@ type CtrlClient interface {
 	Create(context.Context, client.Object, ...client.CreateOption) error
 	Delete(context.Context, client.Object, ...client.DeleteOption) error
 	Patch(context.Context, client.Object, client.Patch, ...client.PatchOption) error
+	Apply(context.Context, client.Object, ...client.ApplyOption) error
 	Exists(context.Context, client.ObjectKey, client.Object) (bool, error)
 }
 
@@ -68,6 +72,10 @@ func (c *CtrlClientImpl) UpdateWithRetry(
 		if !ok {
 			return fmt.Errorf("failed to create new instance of %T: type does not implement client.Object", obj)
 		}
+		// unstructured objects are fetched with the kind of the object being updated.
+		if u, ok := current.(*unstructured.Unstructured); ok {
+			u.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+		}
 		if err := c.Client.Get(ctx, key, current); err != nil {
 			return fmt.Errorf("failed to fetch latest %q for update: %w", key, err)
 		}
@@ -95,6 +103,48 @@ func (c *CtrlClientImpl) Patch(
 	return c.Client.Patch(ctx, obj, patch, opts...)
 }
 
+// Apply applies the fields set in obj with server-side apply, as the operator field manager taking over the
+// ownership of the fields from the other field managers, except the fields yielded with the YieldFields option. obj
+// is updated with the object returned by the API server.
+func (c *CtrlClientImpl) Apply(
+	ctx context.Context, obj client.Object, opts ...client.ApplyOption,
+) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Client.Scheme())
+	if err != nil {
+		return err
+	}
+	var live client.Object
+	yield := getYieldFields(opts...)
+	if len(yield) > 0 {
+		current, ok := obj.DeepCopyObject().(client.Object)
+		if !ok {
+			return fmt.Errorf("failed to create new instance of %T: type does not implement client.Object", obj)
+		}
+		if err := c.Client.Get(ctx, client.ObjectKeyFromObject(obj), current); err == nil {
+			live = current
+		} else if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to fetch %q for apply: %w", client.ObjectKeyFromObject(obj), err)
+		}
+	}
+
+	content, err := ApplyConfiguration(obj, live, yield...)
+	if err != nil {
+		return err
+	}
+	applied := &unstructured.Unstructured{Object: content}
+	applied.SetGroupVersionKind(gvk)
+	opts = append([]client.ApplyOption{client.FieldOwner(FieldManager), client.ForceOwnership}, opts...)
+	if err := c.Client.Apply(ctx, client.ApplyConfigurationFromUnstructured(applied), opts...); err != nil {
+		return err
+	}
+
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		u.SetUnstructuredContent(applied.UnstructuredContent())
+		return nil
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(applied.UnstructuredContent(), obj)
+}
+
 func (c *CtrlClientImpl) Exists(ctx context.Context, key client.ObjectKey, obj client.Object) (bool, error) {
 	if err := c.Client.Get(ctx, key, obj); err != nil {
 		if errors.IsNotFound(err) {
@@ -0,0 +1,64 @@
+package common
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorclient "github.com/openshift/external-secrets-operator/pkg/controller/client"
+)
+
+// HasObjectDrifted returns whether the fetched object has drifted from the desired object applied by the operator with
+// server-side apply, based on the fields owned by the operator in the managed fields of the fetched object. The
+// object has drifted when it was not applied by the operator, when the fields owned by the operator are not the
+// fields set in the desired object, because the desired fields changed or other field managers took over the
+// ownership of the fields, or when the values of the desired fields are different. The fields not owned by the
+// operator, like the ones defaulted by the API server or set by other controllers, are ignored. Any failure to
+// compare the objects is reported as a drift, for the apply to report the error. The yielded fields owned by other
+// field managers are not compared, as they are not applied.
+func HasObjectDrifted(desired, fetched client.Object, yield ...operatorclient.FieldPath) bool {
+	index := slices.IndexFunc(fetched.GetManagedFields(), func(entry metav1.ManagedFieldsEntry) bool {
+		return entry.Manager == operatorclient.FieldManager && entry.Operation == metav1.ManagedFieldsOperationApply &&
+			entry.Subresource == ""
+	})
+	if index < 0 {
+		return true
+	}
+	owned, err := operatorclient.ManagedFieldPaths(fetched.GetManagedFields()[index])
+	if err != nil {
+		return true
+	}
+
+	content, err := operatorclient.ApplyConfiguration(desired, fetched, yield...)
+	if err != nil {
+		return true
+	}
+	if !fieldPathsMatch(operatorclient.ContentFieldPaths(content), owned) {
+		return true
+	}
+
+	current, err := runtime.DefaultUnstructuredConverter.ToUnstructured(fetched)
+	if err != nil {
+		return true
+	}
+	for _, field := range []string{"apiVersion", "kind"} {
+		delete(content, field)
+	}
+	return UnstructuredFieldsModified(content, current)
+}
+
+// fieldPathsMatch returns whether each of the desired paths is related to an owned path, and each of the owned paths
+// is related to a desired path. The paths are compared for relation instead of equality, as the fields with an
+// atomic schema are owned as a whole.
+func fieldPathsMatch(desired, owned []operatorclient.FieldPath) bool {
+	for _, paths := range [][2][]operatorclient.FieldPath{{desired, owned}, {owned, desired}} {
+		for _, path := range paths[0] {
+			if !slices.ContainsFunc(paths[1], path.IsRelated) {
+				return false
+			}
+		}
+	}
+	return true
+}
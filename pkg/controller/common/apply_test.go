@@ -0,0 +1,147 @@
+package common
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	operatorclient "github.com/openshift/external-secrets-operator/pkg/controller/client"
+)
+
+func testApplyDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "external-secrets",
+			Namespace: "external-secrets",
+			Labels:    map[string]string{"app": "external-secrets"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "external-secrets", Image: "external-secrets:v1"}},
+				},
+			},
+		},
+	}
+}
+
+// testAppliedEntry returns the managed fields entry of the operator for obj applied with server-side apply.
+func testAppliedEntry(t *testing.T, obj *appsv1.Deployment) v1.ManagedFieldsEntry {
+	t.Helper()
+	content, err := operatorclient.ApplyConfiguration(obj, nil)
+	if err != nil {
+		t.Fatalf("failed to build apply configuration: %v", err)
+	}
+	entry, err := operatorclient.AppliedManagedFieldsEntry(content)
+	if err != nil {
+		t.Fatalf("failed to build managed fields entry: %v", err)
+	}
+	return entry
+}
+
+func TestHasObjectDrifted(t *testing.T) {
+	replicasOwner := v1.ManagedFieldsEntry{
+		Manager:     "kube-controller-manager",
+		Operation:   v1.ManagedFieldsOperationUpdate,
+		Subresource: "scale",
+		FieldsType:  "FieldsV1",
+		FieldsV1:    &v1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+	}
+
+	tests := []struct {
+		name    string
+		desired func(*appsv1.Deployment)
+		fetched func(*testing.T, *appsv1.Deployment)
+		yield   []operatorclient.FieldPath
+		want    bool
+	}{
+		{
+			name:    "not applied by the operator",
+			fetched: func(t *testing.T, d *appsv1.Deployment) {},
+			want:    true,
+		},
+		{
+			name: "applied with fields defaulted by the API server",
+			fetched: func(t *testing.T, d *appsv1.Deployment) {
+				d.SetManagedFields([]v1.ManagedFieldsEntry{testAppliedEntry(t, d)})
+				d.Spec.ProgressDeadlineSeconds = ptr.To(int32(600))
+				d.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullIfNotPresent
+			},
+			want: false,
+		},
+		{
+			name: "applied field modified",
+			fetched: func(t *testing.T, d *appsv1.Deployment) {
+				d.SetManagedFields([]v1.ManagedFieldsEntry{testAppliedEntry(t, d)})
+				d.Spec.Template.Spec.Containers[0].Image = "external-secrets:v0"
+			},
+			want: true,
+		},
+		{
+			name: "field added to desired object",
+			desired: func(d *appsv1.Deployment) {
+				d.SetAnnotations(map[string]string{"example.com/team": "platform"})
+			},
+			fetched: func(t *testing.T, d *appsv1.Deployment) {
+				d.SetManagedFields([]v1.ManagedFieldsEntry{testAppliedEntry(t, d)})
+			},
+			want: true,
+		},
+		{
+			name: "field removed from desired object",
+			fetched: func(t *testing.T, d *appsv1.Deployment) {
+				d.SetAnnotations(map[string]string{"example.com/team": "platform"})
+				d.SetManagedFields([]v1.ManagedFieldsEntry{testAppliedEntry(t, d)})
+			},
+			want: true,
+		},
+		{
+			name: "yielded field owned by another field manager",
+			fetched: func(t *testing.T, d *appsv1.Deployment) {
+				d.Spec.Replicas = nil
+				d.SetManagedFields([]v1.ManagedFieldsEntry{testAppliedEntry(t, d), replicasOwner})
+				d.Spec.Replicas = ptr.To(int32(3))
+			},
+			yield: []operatorclient.FieldPath{{"spec", "replicas"}},
+			want:  false,
+		},
+		{
+			name: "yielded field released by the other field managers",
+			fetched: func(t *testing.T, d *appsv1.Deployment) {
+				d.Spec.Replicas = nil
+				d.SetManagedFields([]v1.ManagedFieldsEntry{testAppliedEntry(t, d)})
+				d.Spec.Replicas = ptr.To(int32(3))
+			},
+			yield: []operatorclient.FieldPath{{"spec", "replicas"}},
+			want:  true,
+		},
+		{
+			name: "field not yielded owned by another field manager",
+			fetched: func(t *testing.T, d *appsv1.Deployment) {
+				d.Spec.Replicas = nil
+				d.SetManagedFields([]v1.ManagedFieldsEntry{testAppliedEntry(t, d), replicasOwner})
+				d.Spec.Replicas = ptr.To(int32(3))
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := testApplyDeployment()
+			if tt.desired != nil {
+				tt.desired(desired)
+			}
+			fetched := testApplyDeployment()
+			tt.fetched(t, fetched)
+
+			if got := HasObjectDrifted(desired, fetched, tt.yield...); got != tt.want {
+				t.Errorf("HasObjectDrifted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
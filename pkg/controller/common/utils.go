@@ -17,6 +17,7 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -241,381 +242,6 @@ func DecodeNetworkPolicyObjBytes(objBytes []byte) *networkingv1.NetworkPolicy {
 	return result
 }
 
-func HasObjectChanged(desired, fetched client.Object, metaState *ResourceMetadata) bool {
-	if reflect.TypeOf(desired) != reflect.TypeOf(fetched) {
-		panic("both objects to be compared must be of same type")
-	}
-
-	var objectModified bool
-	//nolint:forcetypeassert // Type assertion for fetched is safe - type equality checked the first before switch.
-	switch d := desired.(type) {
-	case *certmanagerv1.Certificate:
-		f := fetched.(*certmanagerv1.Certificate)
-		objectModified = certificateSpecModified(d, f)
-	case *rbacv1.ClusterRole:
-		f := fetched.(*rbacv1.ClusterRole)
-		objectModified = rbacRoleRulesModified[*rbacv1.ClusterRole](d, f)
-	case *rbacv1.ClusterRoleBinding:
-		f := fetched.(*rbacv1.ClusterRoleBinding)
-		objectModified = rbacRoleBindingRefModified[*rbacv1.ClusterRoleBinding](d, f) ||
-			rbacRoleBindingSubjectsModified[*rbacv1.ClusterRoleBinding](d, f)
-	case *appsv1.Deployment:
-		f := fetched.(*appsv1.Deployment)
-		objectModified = deploymentSpecModified(d, f, metaState)
-	case *rbacv1.Role:
-		f := fetched.(*rbacv1.Role)
-		objectModified = rbacRoleRulesModified[*rbacv1.Role](d, f)
-	case *rbacv1.RoleBinding:
-		f := fetched.(*rbacv1.RoleBinding)
-		objectModified = rbacRoleBindingRefModified[*rbacv1.RoleBinding](d, f) ||
-			rbacRoleBindingSubjectsModified[*rbacv1.RoleBinding](d, f)
-	case *corev1.Service:
-		f := fetched.(*corev1.Service)
-		objectModified = serviceSpecModified(d, f)
-	case *networkingv1.NetworkPolicy:
-		f := fetched.(*networkingv1.NetworkPolicy)
-		objectModified = networkPolicySpecModified(d, f)
-	case *webhook.ValidatingWebhookConfiguration:
-		f := fetched.(*webhook.ValidatingWebhookConfiguration)
-		objectModified = validatingWebHookSpecModified(d, f)
-	case *corev1.ServiceAccount:
-		objectModified = false
-	default:
-		panic(fmt.Sprintf("unsupported object type: %T", desired))
-	}
-
-	return objectModified || ObjectMetadataModified(desired, fetched, metaState)
-}
-
-func ObjectMetadataModified(desired, fetched client.Object, metaState *ResourceMetadata) bool {
-	// Check if labels have changed
-	if !reflect.DeepEqual(desired.GetLabels(), fetched.GetLabels()) {
-		return true
-	}
-
-	// Compare only managed annotation keys to avoid infinite reconcile loops caused by
-	// annotations managed by external controllers.
-	return annotationMapsModified(desired.GetAnnotations(), fetched.GetAnnotations(), metaState.DeletedAnnotationKeys)
-}
-
-// annotationMapsModified checks whether managed annotation keys differ between two annotation maps.
-// It returns true if any desired key is missing or has a different value in fetchedAnnotations, or if any
-// previously-managed key still exists in fetchedAnnotations but is no longer in the current set.
-// Extra annotations in fetchedAnnotations that are not in desiredAnnotations (e.g. added by
-// external controllers like deployment.kubernetes.io/revision) are intentionally ignored.
-func annotationMapsModified(desiredAnnotations, fetchedAnnotations map[string]string, deletedAnnotationKeys []string) bool {
-	for k, v := range desiredAnnotations {
-		if fetchedAnnotations[k] != v {
-			return true
-		}
-	}
-	for _, k := range deletedAnnotationKeys {
-		if _, exists := fetchedAnnotations[k]; exists {
-			return true
-		}
-	}
-	return false
-}
-
-func certificateSpecModified(desired, fetched *certmanagerv1.Certificate) bool {
-	return !reflect.DeepEqual(desired.Spec, fetched.Spec)
-}
-
-func deploymentSpecModified(desired, fetched *appsv1.Deployment, metaState *ResourceMetadata) bool {
-	if desired.Spec.Replicas != nil && !reflect.DeepEqual(desired.Spec.Replicas, fetched.Spec.Replicas) {
-		return true
-	}
-
-	if desired.Spec.Template.Spec.ServiceAccountName != fetched.Spec.Template.Spec.ServiceAccountName ||
-		desired.Spec.Template.Spec.AutomountServiceAccountToken != nil {
-		if !reflect.DeepEqual(desired.Spec.Template.Spec.AutomountServiceAccountToken, fetched.Spec.Template.Spec.AutomountServiceAccountToken) {
-			return true
-		}
-	}
-
-	if desired.Spec.Template.Spec.DNSPolicy != "" && desired.Spec.Template.Spec.DNSPolicy != fetched.Spec.Template.Spec.DNSPolicy {
-		return true
-	}
-
-	if desired.Spec.Template.Labels != nil && !reflect.DeepEqual(desired.Spec.Template.Labels, fetched.Spec.Template.Labels) {
-		return true
-	}
-
-	// Check pod template annotations using managed-keys comparison
-	if annotationMapsModified(desired.Spec.Template.Annotations, fetched.Spec.Template.Annotations, metaState.DeletedAnnotationKeys) {
-		return true
-	}
-
-	// Check volumes
-	if !volumesEqual(desired.Spec.Template.Spec.Volumes, fetched.Spec.Template.Spec.Volumes) {
-		return true
-	}
-
-	if desired.Spec.Template.Spec.NodeSelector != nil && !reflect.DeepEqual(desired.Spec.Template.Spec.NodeSelector, fetched.Spec.Template.Spec.NodeSelector) {
-		return true
-	}
-
-	if desired.Spec.Template.Spec.Affinity != nil && !reflect.DeepEqual(desired.Spec.Template.Spec.Affinity, fetched.Spec.Template.Spec.Affinity) {
-		return true
-	}
-
-	if desired.Spec.Template.Spec.Tolerations != nil && !reflect.DeepEqual(desired.Spec.Template.Spec.Tolerations, fetched.Spec.Template.Spec.Tolerations) {
-		return true
-	}
-
-	if desired.Spec.RevisionHistoryLimit != nil && !reflect.DeepEqual(desired.Spec.RevisionHistoryLimit, fetched.Spec.RevisionHistoryLimit) {
-		return true
-	}
-
-	// Check regular containers
-	if len(desired.Spec.Template.Spec.Containers) != len(fetched.Spec.Template.Spec.Containers) {
-		return true
-	}
-	fetchedContainers := make(map[string]*corev1.Container)
-	for i := range fetched.Spec.Template.Spec.Containers {
-		fetchedContainers[fetched.Spec.Template.Spec.Containers[i].Name] = &fetched.Spec.Template.Spec.Containers[i]
-	}
-	for i := range desired.Spec.Template.Spec.Containers {
-		desiredContainer := &desired.Spec.Template.Spec.Containers[i]
-		fetchedContainer, exists := fetchedContainers[desiredContainer.Name]
-		if !exists {
-			return true
-		}
-		if containerSpecModified(desiredContainer, fetchedContainer) {
-			return true
-		}
-	}
-
-	// Check init containers
-	if len(desired.Spec.Template.Spec.InitContainers) != len(fetched.Spec.Template.Spec.InitContainers) {
-		return true
-	}
-	fetchedInitContainers := make(map[string]*corev1.Container)
-	for i := range fetched.Spec.Template.Spec.InitContainers {
-		fetchedInitContainers[fetched.Spec.Template.Spec.InitContainers[i].Name] = &fetched.Spec.Template.Spec.InitContainers[i]
-	}
-	for i := range desired.Spec.Template.Spec.InitContainers {
-		desiredInitContainer := &desired.Spec.Template.Spec.InitContainers[i]
-		fetchedInitContainer, exists := fetchedInitContainers[desiredInitContainer.Name]
-		if !exists {
-			return true
-		}
-		if containerSpecModified(desiredInitContainer, fetchedInitContainer) {
-			return true
-		}
-	}
-
-	return false
-}
-
-func containerSpecModified(desiredContainer, fetchedContainer *corev1.Container) bool {
-	// Check basic container properties
-	if !reflect.DeepEqual(desiredContainer.Args, fetchedContainer.Args) ||
-		desiredContainer.Name != fetchedContainer.Name ||
-		desiredContainer.Image != fetchedContainer.Image ||
-		desiredContainer.ImagePullPolicy != fetchedContainer.ImagePullPolicy {
-		return true
-	}
-
-	// Check environment variables
-	if !reflect.DeepEqual(desiredContainer.Env, fetchedContainer.Env) {
-		return true
-	}
-
-	// Check ports
-	if len(desiredContainer.Ports) != len(fetchedContainer.Ports) {
-		return true
-	}
-	for _, fetchedPort := range fetchedContainer.Ports {
-		matched := false
-		for _, desiredPort := range desiredContainer.Ports {
-			if fetchedPort.ContainerPort == desiredPort.ContainerPort {
-				matched = true
-				break
-			}
-		}
-		if !matched {
-			return true
-		}
-	}
-
-	// ReadinessProbe nil checks
-	if (desiredContainer.ReadinessProbe == nil) != (fetchedContainer.ReadinessProbe == nil) {
-		return true
-	}
-	if desiredContainer.ReadinessProbe != nil && fetchedContainer.ReadinessProbe != nil {
-		if (desiredContainer.ReadinessProbe.HTTPGet == nil) != (fetchedContainer.ReadinessProbe.HTTPGet == nil) {
-			return true
-		}
-		if desiredContainer.ReadinessProbe.HTTPGet != nil && fetchedContainer.ReadinessProbe.HTTPGet != nil {
-			if desiredContainer.ReadinessProbe.HTTPGet.Path != fetchedContainer.ReadinessProbe.HTTPGet.Path {
-				return true
-			}
-		}
-	}
-
-	// SecurityContext nil check
-	if desiredContainer.SecurityContext != nil && !reflect.DeepEqual(*desiredContainer.SecurityContext, *fetchedContainer.SecurityContext) {
-		return true
-	}
-
-	// Check volume mounts
-	if !reflect.DeepEqual(desiredContainer.VolumeMounts, fetchedContainer.VolumeMounts) {
-		return true
-	}
-
-	// Check resources
-	if !reflect.DeepEqual(desiredContainer.Resources, fetchedContainer.Resources) {
-		return true
-	}
-
-	return false
-}
-
-func volumesEqual(desired, fetched []corev1.Volume) bool {
-	if len(desired) == 0 && len(fetched) == 0 {
-		return true
-	}
-	if len(desired) != len(fetched) {
-		return false
-	}
-
-	// Create a map of fetched volumes by name for easier lookup
-	fetchedMap := make(map[string]corev1.Volume)
-	for _, v := range fetched {
-		fetchedMap[v.Name] = v
-	}
-
-	// Check each desired volume exists and matches in fetched
-	for _, desiredVol := range desired {
-		fetchedVol, exists := fetchedMap[desiredVol.Name]
-		if !exists {
-			return false
-		}
-
-		// Compare volume sources
-		// Check ConfigMap volume
-		if desiredVol.ConfigMap != nil {
-			if fetchedVol.ConfigMap == nil {
-				return false
-			}
-			if desiredVol.ConfigMap.Name != fetchedVol.ConfigMap.Name {
-				return false
-			}
-		}
-
-		// Check Secret volume
-		if desiredVol.Secret != nil {
-			if fetchedVol.Secret == nil {
-				return false
-			}
-			if desiredVol.Secret.SecretName != fetchedVol.Secret.SecretName {
-				return false
-			}
-			if !reflect.DeepEqual(desiredVol.Secret.Items, fetchedVol.Secret.Items) {
-				return false
-			}
-		}
-
-		// Check EmptyDir volume
-		if desiredVol.EmptyDir != nil {
-			if fetchedVol.EmptyDir == nil {
-				return false
-			}
-		}
-
-		// Add other volume types as needed (PVC, HostPath, etc.)
-	}
-
-	return true
-}
-
-func serviceSpecModified(desired, fetched *corev1.Service) bool {
-	if desired.Spec.Type != fetched.Spec.Type ||
-		!reflect.DeepEqual(desired.Spec.Ports, fetched.Spec.Ports) ||
-		!reflect.DeepEqual(desired.Spec.Selector, fetched.Spec.Selector) {
-		return true
-	}
-
-	return false
-}
-
-func networkPolicySpecModified(desired, fetched *networkingv1.NetworkPolicy) bool {
-	if !reflect.DeepEqual(desired.Spec.PodSelector, fetched.Spec.PodSelector) ||
-		!reflect.DeepEqual(desired.Spec.PolicyTypes, fetched.Spec.PolicyTypes) ||
-		!reflect.DeepEqual(desired.Spec.Ingress, fetched.Spec.Ingress) ||
-		!reflect.DeepEqual(desired.Spec.Egress, fetched.Spec.Egress) {
-		return true
-	}
-
-	return false
-}
-
-func rbacRoleRulesModified[Object *rbacv1.Role | *rbacv1.ClusterRole](desired, fetched Object) bool {
-	//nolint:forcetypeassert // Type assertion is safe - generic constraint guarantees type match
-	switch any(desired).(type) {
-	case *rbacv1.ClusterRole:
-		return !reflect.DeepEqual(any(desired).(*rbacv1.ClusterRole).Rules, any(fetched).(*rbacv1.ClusterRole).Rules)
-	case *rbacv1.Role:
-		return !reflect.DeepEqual(any(desired).(*rbacv1.Role).Rules, any(fetched).(*rbacv1.Role).Rules)
-	default:
-		panic(fmt.Sprintf("unsupported object type %T", desired))
-	}
-}
-
-func rbacRoleBindingRefModified[Object *rbacv1.RoleBinding | *rbacv1.ClusterRoleBinding](desired, fetched Object) bool {
-	//nolint:forcetypeassert // Type assertion is safe - generic constraint guarantees type match
-	switch any(desired).(type) {
-	case *rbacv1.ClusterRoleBinding:
-		return !reflect.DeepEqual(any(desired).(*rbacv1.ClusterRoleBinding).RoleRef, any(fetched).(*rbacv1.ClusterRoleBinding).RoleRef)
-	case *rbacv1.RoleBinding:
-		return !reflect.DeepEqual(any(desired).(*rbacv1.RoleBinding).RoleRef, any(fetched).(*rbacv1.RoleBinding).RoleRef)
-	default:
-		panic(fmt.Sprintf("unsupported object type %T", desired))
-	}
-}
-
-func rbacRoleBindingSubjectsModified[Object *rbacv1.RoleBinding | *rbacv1.ClusterRoleBinding](desired, fetched Object) bool {
-	//nolint:forcetypeassert // Type assertion is safe - generic constraint guarantees type match
-	switch any(desired).(type) {
-	case *rbacv1.ClusterRoleBinding:
-		return !reflect.DeepEqual(any(desired).(*rbacv1.ClusterRoleBinding).Subjects, any(fetched).(*rbacv1.ClusterRoleBinding).Subjects)
-	case *rbacv1.RoleBinding:
-		return !reflect.DeepEqual(any(desired).(*rbacv1.RoleBinding).Subjects, any(fetched).(*rbacv1.RoleBinding).Subjects)
-	default:
-		panic(fmt.Sprintf("unsupported object type %T", desired))
-	}
-}
-
-func validatingWebHookSpecModified(desired, fetched *webhook.ValidatingWebhookConfiguration) bool {
-	if len(desired.Webhooks) != len(fetched.Webhooks) {
-		return true
-	}
-
-	fetchedWebhooksMap := make(map[string]webhook.ValidatingWebhook)
-	for _, wh := range fetched.Webhooks {
-		fetchedWebhooksMap[wh.Name] = wh
-	}
-
-	for _, desiredWh := range desired.Webhooks {
-		fetchedWh, ok := fetchedWebhooksMap[desiredWh.Name]
-		if !ok {
-			return true
-		}
-
-		if !reflect.DeepEqual(desiredWh.SideEffects, fetchedWh.SideEffects) ||
-			!reflect.DeepEqual(desiredWh.TimeoutSeconds, fetchedWh.TimeoutSeconds) ||
-			!reflect.DeepEqual(desiredWh.AdmissionReviewVersions, fetchedWh.AdmissionReviewVersions) ||
-			!reflect.DeepEqual(desiredWh.ClientConfig.Service.Name, fetchedWh.ClientConfig.Service.Name) ||
-			!reflect.DeepEqual(desiredWh.ClientConfig.Service.Path, fetchedWh.ClientConfig.Service.Path) ||
-			!reflect.DeepEqual(desiredWh.Rules, fetchedWh.Rules) {
-			return true
-		}
-	}
-
-	return false
-}
-
 // ParseBool is for parsing a string value as a boolean value. This is very specific to the values
 // read from CR which allows only `true` or `false` as values.
 func ParseBool(val string) bool {
@@ -666,6 +292,12 @@ func AddFinalizer(ctx context.Context, obj client.Object, opClient operatorclien
 				return fmt.Errorf("failed to fetch %q after updating finalizers: %w", namespacedName, err)
 			}
 			updated.DeepCopyInto(o)
+		case *operatorv1alpha1.SecretStoreTemplate:
+			updated := &operatorv1alpha1.SecretStoreTemplate{}
+			if err := opClient.Get(ctx, namespacedName, updated); err != nil {
+				return fmt.Errorf("failed to fetch %q after updating finalizers: %w", namespacedName, err)
+			}
+			updated.DeepCopyInto(o)
 		default:
 			return fmt.Errorf("adding finalizer to %T object not handled", obj)
 		}
@@ -820,3 +452,54 @@ func DecodeDataFromB64Json(data []byte) ([]byte, error) {
 	}
 	return decodedData[:n], nil
 }
+
+// UnstructuredFieldsModified returns whether any of the fields in desired is missing or different in fetched.
+// Fields present only in fetched, like the ones defaulted by the API server, are ignored. Lists are compared
+// element-wise and must have the same length. The strings which are quantities, like the resource requests and
+// limits, are compared by their value, as the API server stores them in the canonical form. Both the values must be
+// of the form produced by the unstructured JSON decoding, with integral numbers as int64.
+func UnstructuredFieldsModified(desired, fetched any) bool {
+	switch d := desired.(type) {
+	case map[string]any:
+		f, ok := fetched.(map[string]any)
+		if !ok {
+			return true
+		}
+		for k, v := range d {
+			fv, exist := f[k]
+			if !exist || UnstructuredFieldsModified(v, fv) {
+				return true
+			}
+		}
+		return false
+	case []any:
+		f, ok := fetched.([]any)
+		if !ok || len(d) != len(f) {
+			return true
+		}
+		for i := range d {
+			if UnstructuredFieldsModified(d[i], f[i]) {
+				return true
+			}
+		}
+		return false
+	case string:
+		f, ok := fetched.(string)
+		if !ok {
+			return true
+		}
+		return d != f && !quantitiesEqual(d, f)
+	default:
+		return !reflect.DeepEqual(desired, fetched)
+	}
+}
+
+// quantitiesEqual returns whether both the values are quantities and are equal, like "1000m" and "1".
+func quantitiesEqual(desired, fetched string) bool {
+	d, err := resource.ParseQuantity(desired)
+	if err != nil {
+		return false
+	}
+	f, err := resource.ParseQuantity(fetched)
+	return err == nil && d.Cmp(f) == 0
+}
@@ -74,107 +74,6 @@ func TestUpdateResourceAnnotations(t *testing.T) {
 	}
 }
 
-func TestObjectMetadataModified(t *testing.T) {
-	tests := []struct {
-		name               string
-		metadata           ResourceMetadata
-		desiredAnnotations map[string]string
-		fetchedAnnotations map[string]string
-		want               bool
-	}{
-		{
-			name: "managed annotation changed - returns true",
-			metadata: ResourceMetadata{
-				Annotations:           map[string]string{"user-key": "new-value"},
-				DeletedAnnotationKeys: []string{},
-			},
-			desiredAnnotations: map[string]string{
-				"user-key": "new-value",
-			},
-			fetchedAnnotations: map[string]string{
-				"user-key": "old-value",
-			},
-			want: true,
-		},
-		{
-			name: "unmanaged annotation added by external actor - ignored, returns false",
-			metadata: ResourceMetadata{
-				Annotations:           map[string]string{"user-key": "same-value"},
-				DeletedAnnotationKeys: []string{},
-			},
-			desiredAnnotations: map[string]string{
-				"user-key": "same-value",
-			},
-			fetchedAnnotations: map[string]string{
-				"user-key":                          "same-value",
-				"deployment.kubernetes.io/revision": "4",
-			},
-			want: false,
-		},
-		{
-			name: "managed annotation removed from desired - returns true",
-			metadata: ResourceMetadata{
-				Annotations:           map[string]string{},
-				DeletedAnnotationKeys: []string{"removed-key"},
-			},
-			desiredAnnotations: map[string]string{},
-			fetchedAnnotations: map[string]string{
-				"removed-key": "value",
-			},
-			want: true,
-		},
-		{
-			name:               "empty desired annotations - external annotations ignored",
-			metadata:           ResourceMetadata{},
-			desiredAnnotations: map[string]string{},
-			fetchedAnnotations: map[string]string{
-				"deployment.kubernetes.io/revision": "4",
-			},
-			want: false,
-		},
-		{
-			name:               "both nil - no change",
-			metadata:           ResourceMetadata{},
-			desiredAnnotations: nil,
-			fetchedAnnotations: nil,
-			want:               false,
-		},
-		{
-			name: "new managed annotation added - returns true",
-			metadata: ResourceMetadata{
-				Annotations:           map[string]string{"new-key": "value"},
-				DeletedAnnotationKeys: []string{},
-			},
-			desiredAnnotations: map[string]string{
-				"new-key": "value",
-			},
-			fetchedAnnotations: map[string]string{},
-			want:               true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			desired := &corev1.ConfigMap{
-				ObjectMeta: v1.ObjectMeta{
-					Name:        "test",
-					Annotations: tt.desiredAnnotations,
-				},
-			}
-			fetched := &corev1.ConfigMap{
-				ObjectMeta: v1.ObjectMeta{
-					Name:        "test",
-					Annotations: tt.fetchedAnnotations,
-				},
-			}
-			got := ObjectMetadataModified(desired, fetched, &tt.metadata)
-			if got != tt.want {
-				t.Errorf("ObjectMetadataModified() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestGetPreviouslyAppliedAnnotationKeys(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -396,91 +295,87 @@ func TestRemoveObsoleteAnnotations(t *testing.T) {
 	})
 }
 
-func TestDeploymentObjectChanged(t *testing.T) {
-	t.Run("unmanaged annotation added by foreign actor should not lead to change", func(t *testing.T) {
-		// Deployment with external annotation on fetched should NOT trigger change
-		// when desired has no managed annotations — external annotations are ignored
-		fetched := appsv1.Deployment{
-			ObjectMeta: v1.ObjectMeta{
-				Annotations: map[string]string{
-					"deployment.kubernetes.io/revision": "4",
+func TestUnstructuredFieldsModified(t *testing.T) {
+	fetched := map[string]any{
+		"provider": map[string]any{
+			"vault": map[string]any{
+				"server":  "https://vault.example.com",
+				"version": "v2",
+				"auth": map[string]any{
+					"kubernetes": map[string]any{"role": "eso"},
 				},
 			},
-		}
-
-		desired := appsv1.Deployment{
-			ObjectMeta: v1.ObjectMeta{
-				Annotations: map[string]string{},
-			},
-		}
-
-		managedMetaState := ResourceMetadata{}
-		if HasObjectChanged(&desired, &fetched, &managedMetaState) {
-			t.Fatal("expected no change when fetched only has external annotations")
-		}
-	})
+		},
+		"retrySettings": map[string]any{"maxRetries": int64(3)},
+		"conditions":    []any{map[string]any{"namespaces": []any{"team-a"}, "namespaceRegexes": []any{}}},
+		"resources":     map[string]any{"limits": map[string]any{"cpu": "1", "memory": "128Mi"}},
+	}
 
-	t.Run("managed annotation updated by foreign actor should trigger change", func(t *testing.T) {
-		desired := appsv1.Deployment{
-			ObjectMeta: v1.ObjectMeta{
-				Annotations: map[string]string{
-					"my-annotation": "new-value",
+	tests := []struct {
+		name    string
+		desired map[string]any
+		want    bool
+	}{
+		{
+			name: "fields defaulted by server are ignored",
+			desired: map[string]any{
+				"provider": map[string]any{
+					"vault": map[string]any{"server": "https://vault.example.com"},
 				},
+				"retrySettings": map[string]any{"maxRetries": int64(3)},
+				"conditions":    []any{map[string]any{"namespaces": []any{"team-a"}}},
 			},
-		}
-
-		fetched := appsv1.Deployment{
-			ObjectMeta: v1.ObjectMeta{
-				Annotations: map[string]string{
-					"my-annotation":                     "old-value",
-					"deployment.kubernetes.io/revision": "4",
+		},
+		{
+			name: "scalar value changed",
+			desired: map[string]any{
+				"provider": map[string]any{
+					"vault": map[string]any{"server": "https://vault-new.example.com"},
 				},
 			},
-		}
-
-		managedMetaState := ResourceMetadata{
-			Annotations:           map[string]string{"my-annotation": "new-value"},
-			DeletedAnnotationKeys: []string{},
-		}
-
-		if !HasObjectChanged(&desired, &fetched, &managedMetaState) {
-			t.Fatal("expected change when managed annotation value differs")
-		}
-	})
-
-	t.Run("unmanaged pod annotation added by foreign actor should not lead to change", func(t *testing.T) {
-		desired := appsv1.Deployment{
-			Spec: appsv1.DeploymentSpec{
-				Template: corev1.PodTemplateSpec{
-					ObjectMeta: v1.ObjectMeta{
-						Annotations: map[string]string{
-							"user-key": "value",
-						},
-					},
-				},
+			want: true,
+		},
+		{
+			name: "field missing in fetched",
+			desired: map[string]any{
+				"refreshInterval": int64(60),
 			},
-		}
-
-		fetched := appsv1.Deployment{
-			Spec: appsv1.DeploymentSpec{
-				Template: corev1.PodTemplateSpec{
-					ObjectMeta: v1.ObjectMeta{
-						Annotations: map[string]string{
-							"user-key":                          "value",
-							"kubectl.kubernetes.io/restartedAt": "2024-01-01",
-						},
-					},
-				},
+			want: true,
+		},
+		{
+			name: "list length changed",
+			desired: map[string]any{
+				"conditions": []any{map[string]any{"namespaces": []any{"team-a", "team-b"}}},
 			},
-		}
-
-		managedMetaState := ResourceMetadata{
-			Annotations:           map[string]string{"user-key": "value"},
-			DeletedAnnotationKeys: []string{},
-		}
+			want: true,
+		},
+		{
+			name: "type changed",
+			desired: map[string]any{
+				"provider": "vault",
+			},
+			want: true,
+		},
+		{
+			name: "quantities in a different form are equal",
+			desired: map[string]any{
+				"resources": map[string]any{"limits": map[string]any{"cpu": "1000m", "memory": "131072Ki"}},
+			},
+		},
+		{
+			name: "quantity value changed",
+			desired: map[string]any{
+				"resources": map[string]any{"limits": map[string]any{"cpu": "500m"}},
+			},
+			want: true,
+		},
+	}
 
-		if HasObjectChanged(&desired, &fetched, &managedMetaState) {
-			t.Fatal("expected no change when fetched pod template only has extra external annotations")
-		}
-	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnstructuredFieldsModified(tt.desired, fetched); got != tt.want {
+				t.Errorf("UnstructuredFieldsModified() = %v, want %v", got, tt.want)
+			}
+		})
+	}
 }
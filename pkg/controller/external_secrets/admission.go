@@ -0,0 +1,302 @@
+package external_secrets
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+)
+
+// +kubebuilder:webhook:path=/validate-operator-openshift-io-v1alpha1-externalsecretsconfig,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.openshift.io,resources=externalsecretsconfigs,verbs=create;update,versions=v1alpha1,name=vexternalsecretsconfig.operator.openshift.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-operator-openshift-io-v1alpha1-externalsecretsmanager,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.openshift.io,resources=externalsecretsmanagers,verbs=update,versions=v1alpha1,name=vexternalsecretsmanager.operator.openshift.io,admissionReviewVersions=v1
+
+var (
+	// applicationConfigPath is the path of the external-secrets operand configuration in the ExternalSecretsConfig.
+	applicationConfigPath = field.NewPath("spec", "applicationConfig")
+
+	// globalConfigPath is the path of the global configuration in the ExternalSecretsManager.
+	globalConfigPath = field.NewPath("spec", "globalConfig")
+)
+
+// externalSecretsConfigValidator validates the ExternalSecretsConfig resources at admission.
+type externalSecretsConfigValidator struct {
+	r *Reconciler
+}
+
+// externalSecretsManagerValidator validates the ExternalSecretsManager resources at admission.
+type externalSecretsManagerValidator struct {
+	r *Reconciler
+}
+
+// commonConfigSource is the common configuration of the external-secrets operand set in either the
+// ExternalSecretsConfig or the ExternalSecretsManager, along with its path in the resource.
+type commonConfigSource struct {
+	config *operatorv1alpha1.CommonConfigs
+	path   *field.Path
+	// admitted is whether the configuration is of the resource being admitted.
+	admitted bool
+}
+
+// SetupWebhookWithManager registers the validating admission webhooks of the ExternalSecretsConfig and
+// ExternalSecretsManager resources with the manager's webhook server.
+func (r *Reconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&operatorv1alpha1.ExternalSecretsConfig{}).
+		WithValidator(&externalSecretsConfigValidator{r: r}).
+		Complete(); err != nil {
+		return fmt.Errorf("failed to set up externalsecretsconfigs.operator.openshift.io webhook: %w", err)
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&operatorv1alpha1.ExternalSecretsManager{}).
+		WithValidator(&externalSecretsManagerValidator{r: r}).
+		Complete(); err != nil {
+		return fmt.Errorf("failed to set up externalsecretsmanagers.operator.openshift.io webhook: %w", err)
+	}
+	return nil
+}
+
+func (v *externalSecretsConfigValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj)
+}
+
+// ValidateUpdate validates the ExternalSecretsConfig only when the spec is updated, for the updates of the metadata
+// and the status, like the removal of the finalizers of a resource being deleted, to not be rejected.
+func (v *externalSecretsConfigValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	if !isSpecUpdated(oldObj, newObj) {
+		return nil, nil
+	}
+	return v.validate(ctx, newObj)
+}
+
+func (v *externalSecretsConfigValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate runs the validations done during the reconciliation of the ExternalSecretsConfig, along with the
+// cross-checks with the global configuration in the ExternalSecretsManager.
+func (v *externalSecretsConfigValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	esc, ok := obj.(*operatorv1alpha1.ExternalSecretsConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected an ExternalSecretsConfig object, got %T", obj)
+	}
+	esm := &operatorv1alpha1.ExternalSecretsManager{}
+	if err := v.r.getSingleton(ctx, "externalsecretsmanagers.operator.openshift.io", common.ExternalSecretsManagerObjectName, esm); err != nil {
+		return nil, err
+	}
+
+	errs := validateCommonConfigs(&esc.Spec.ApplicationConfig.CommonConfigs, applicationConfigPath)
+	warnings, crossErrs := crossValidateCommonConfigs(esc, esm, true)
+	errs = append(errs, crossErrs...)
+
+	// the optional features and the issuer may be installed after the configuration, their existence is only
+	// warned about and the reconciliation fails until they exist.
+	for _, err := range append(v.r.validateOptionalFeatures(esc), v.r.validateIssuerRef(esc)...) {
+		warnings = append(warnings, err.Error())
+	}
+	if len(errs) > 0 {
+		return warnings, errors.NewInvalid(operatorv1alpha1.GroupVersion.WithKind("ExternalSecretsConfig").GroupKind(), esc.GetName(), errs)
+	}
+	return warnings, nil
+}
+
+func (v *externalSecretsManagerValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj)
+}
+
+// ValidateUpdate validates the ExternalSecretsManager only when the spec is updated, for the updates of the metadata
+// and the status to not be rejected.
+func (v *externalSecretsManagerValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	if !isSpecUpdated(oldObj, newObj) {
+		return nil, nil
+	}
+	return v.validate(ctx, newObj)
+}
+
+func (v *externalSecretsManagerValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate validates the global configuration in the ExternalSecretsManager, along with the cross-checks with
+// the external-secrets operand configuration in the ExternalSecretsConfig.
+func (v *externalSecretsManagerValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	esm, ok := obj.(*operatorv1alpha1.ExternalSecretsManager)
+	if !ok {
+		return nil, fmt.Errorf("expected an ExternalSecretsManager object, got %T", obj)
+	}
+	if esm.Spec.GlobalConfig == nil {
+		return nil, nil
+	}
+	esc := &operatorv1alpha1.ExternalSecretsConfig{}
+	if err := v.r.getSingleton(ctx, "externalsecretsconfigs.operator.openshift.io", common.ExternalSecretsConfigObjectName, esc); err != nil {
+		return nil, err
+	}
+
+	errs := validateCommonConfigs(&esm.Spec.GlobalConfig.CommonConfigs, globalConfigPath)
+	warnings, crossErrs := crossValidateCommonConfigs(esc, esm, false)
+	errs = append(errs, crossErrs...)
+	if len(errs) > 0 {
+		return warnings, errors.NewInvalid(operatorv1alpha1.GroupVersion.WithKind("ExternalSecretsManager").GroupKind(), esm.GetName(), errs)
+	}
+	return warnings, nil
+}
+
+// isSpecUpdated returns whether the spec of the resource is updated, which is not validated when the resource is
+// being deleted.
+func isSpecUpdated(oldObj, newObj runtime.Object) bool {
+	switch obj := newObj.(type) {
+	case *operatorv1alpha1.ExternalSecretsConfig:
+		old, ok := oldObj.(*operatorv1alpha1.ExternalSecretsConfig)
+		return obj.GetDeletionTimestamp() == nil && (!ok || !equality.Semantic.DeepEqual(old.Spec, obj.Spec))
+	case *operatorv1alpha1.ExternalSecretsManager:
+		old, ok := oldObj.(*operatorv1alpha1.ExternalSecretsManager)
+		return obj.GetDeletionTimestamp() == nil && (!ok || !equality.Semantic.DeepEqual(old.Spec, obj.Spec))
+	}
+	return true
+}
+
+// getSingleton fetches the named singleton operator resource into obj, which is left empty when the resource
+// does not exist.
+func (r *Reconciler) getSingleton(ctx context.Context, resource, name string, obj client.Object) error {
+	if err := r.UncachedClient.Get(ctx, types.NamespacedName{Name: name}, obj); err != nil && !errors.IsNotFound(err) {
+		return errors.NewInternalError(fmt.Errorf("failed to fetch %s %q for validation: %w", resource, name, err))
+	}
+	return nil
+}
+
+// validateCommonConfigs validates the common configuration of the external-secrets operand at the given path.
+func validateCommonConfigs(config *operatorv1alpha1.CommonConfigs, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if config.Resources != nil {
+		errs = append(errs, validateResourceRequirements(*config.Resources, fldPath)...)
+	}
+	if config.Affinity != nil {
+		errs = append(errs, validateAffinityRules(config.Affinity, fldPath)...)
+	}
+	if len(config.Tolerations) > 0 {
+		errs = append(errs, validateTolerationsConfig(config.Tolerations, fldPath)...)
+	}
+	if len(config.NodeSelector) > 0 {
+		errs = append(errs, validateNodeSelectorConfig(config.NodeSelector, fldPath)...)
+	}
+	return errs
+}
+
+// validateIssuerRef verifies the issuer referred for obtaining the certificates from cert-manager exists.
+func (r *Reconciler) validateIssuerRef(esc *operatorv1alpha1.ExternalSecretsConfig) field.ErrorList {
+	if !isCertManagerConfigEnabled(esc) || !r.IsCertManagerInstalled() || esc.Spec.ControllerConfig.CertProvider.CertManager.IssuerRef == nil {
+		return nil
+	}
+	issuerRef := esc.Spec.ControllerConfig.CertProvider.CertManager.IssuerRef
+	issuerRefPath := field.NewPath("spec", "controllerConfig", "certProvider", "certManager", "issuerRef")
+	// kind is validated to be either Issuer or ClusterIssuer case-insensitively, and defaults to Issuer.
+	kind := issuerKind
+	if strings.EqualFold(issuerRef.Kind, clusterIssuerKind) {
+		kind = clusterIssuerKind
+	}
+	exists, err := r.getIssuer(v1.ObjectReference{Name: issuerRef.Name, Kind: kind, Group: issuerGroup}, getNamespace(esc))
+	if err != nil {
+		return field.ErrorList{field.InternalError(issuerRefPath, err)}
+	}
+	if !exists {
+		return field.ErrorList{field.NotFound(issuerRefPath.Child("name"), issuerRef.Name)}
+	}
+	return nil
+}
+
+// crossValidateCommonConfigs validates the common configuration applied on the external-secrets deployments, which
+// is made of the fields set in the ExternalSecretsConfig and, for the fields not set in it, the ones set in the
+// ExternalSecretsManager. The errors are reported only for the fields of the resource being admitted, and the
+// warnings for the ExternalSecretsManager fields overridden by the ExternalSecretsConfig.
+func crossValidateCommonConfigs(esc *operatorv1alpha1.ExternalSecretsConfig, esm *operatorv1alpha1.ExternalSecretsManager, escAdmitted bool) (admission.Warnings, field.ErrorList) {
+	if esm.Spec.GlobalConfig == nil {
+		return nil, nil
+	}
+	escConfig := commonConfigSource{config: &esc.Spec.ApplicationConfig.CommonConfigs, path: applicationConfigPath, admitted: escAdmitted}
+	esmConfig := commonConfigSource{config: &esm.Spec.GlobalConfig.CommonConfigs, path: globalConfigPath, admitted: !escAdmitted}
+
+	var warnings admission.Warnings
+	for _, override := range []struct {
+		name   string
+		escSet bool
+		esmSet bool
+	}{
+		{name: "resources", escSet: escConfig.config.Resources != nil, esmSet: esmConfig.config.Resources != nil},
+		{name: "affinity", escSet: escConfig.config.Affinity != nil, esmSet: esmConfig.config.Affinity != nil},
+		{name: "tolerations", escSet: escConfig.config.Tolerations != nil, esmSet: esmConfig.config.Tolerations != nil},
+		{name: "nodeSelector", escSet: escConfig.config.NodeSelector != nil, esmSet: esmConfig.config.NodeSelector != nil},
+		{name: "proxy", escSet: escConfig.config.Proxy != nil, esmSet: esmConfig.config.Proxy != nil},
+	} {
+		if override.escSet && override.esmSet {
+			warnings = append(warnings, fmt.Sprintf("%s is overridden by %s of externalsecretsconfigs.operator.openshift.io %q",
+				globalConfigPath.Child(override.name), applicationConfigPath.Child(override.name), common.ExternalSecretsConfigObjectName))
+		}
+	}
+
+	nodeSelector := escConfig
+	if escConfig.config.NodeSelector == nil {
+		nodeSelector = esmConfig
+	}
+	affinity := escConfig
+	if escConfig.config.Affinity == nil {
+		affinity = esmConfig
+	}
+	if !isNodeSelectorSatisfiable(nodeSelector.config.NodeSelector, affinity.config.Affinity) {
+		detail := fmt.Sprintf("no node can match both %s and the required node affinity of %s",
+			nodeSelector.path.Child("nodeSelector"), affinity.path.Child("affinity"))
+		switch {
+		case nodeSelector.admitted:
+			return warnings, field.ErrorList{field.Invalid(nodeSelector.path.Child("nodeSelector"), nodeSelector.config.NodeSelector, detail)}
+		case affinity.admitted:
+			return warnings, field.ErrorList{field.Invalid(affinity.path.Child("affinity"), "", detail)}
+		}
+	}
+	return warnings, nil
+}
+
+// isNodeSelectorSatisfiable returns whether a node having the labels in the node selector can satisfy any of the
+// required node selector terms of the affinity. Only the match expressions on the node selector labels are checked.
+func isNodeSelectorSatisfiable(nodeSelector map[string]string, affinity *corev1.Affinity) bool {
+	if len(nodeSelector) == 0 || affinity == nil || affinity.NodeAffinity == nil ||
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+	terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) == 0 {
+		return true
+	}
+	for _, term := range terms {
+		if !slices.ContainsFunc(term.MatchExpressions, func(requirement corev1.NodeSelectorRequirement) bool {
+			value, ok := nodeSelector[requirement.Key]
+			if !ok {
+				return false
+			}
+			switch requirement.Operator {
+			case corev1.NodeSelectorOpIn:
+				return !slices.Contains(requirement.Values, value)
+			case corev1.NodeSelectorOpNotIn:
+				return slices.Contains(requirement.Values, value)
+			case corev1.NodeSelectorOpDoesNotExist:
+				return true
+			}
+			return false
+		}) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,400 @@
+package external_secrets
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+// testRequiredNodeAffinity returns an affinity requiring the nodes to have the label with one of the values.
+func testRequiredNodeAffinity(key string, values ...string) *corev1.Affinity {
+	return &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{Key: key, Operator: corev1.NodeSelectorOpIn, Values: values},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// testSingletonGetCalls returns the Get stub returning the ExternalSecretsConfig and ExternalSecretsManager, or
+// the not found error when nil.
+func testSingletonGetCalls(esc *operatorv1alpha1.ExternalSecretsConfig, esm *operatorv1alpha1.ExternalSecretsManager) func(context.Context, types.NamespacedName, client.Object) error {
+	return func(ctx context.Context, ns types.NamespacedName, obj client.Object) error {
+		switch o := obj.(type) {
+		case *operatorv1alpha1.ExternalSecretsConfig:
+			if esc == nil {
+				return errors.NewNotFound(schema.GroupResource{}, ns.Name)
+			}
+			esc.DeepCopyInto(o)
+		case *operatorv1alpha1.ExternalSecretsManager:
+			if esm == nil {
+				return errors.NewNotFound(schema.GroupResource{}, ns.Name)
+			}
+			esm.DeepCopyInto(o)
+		}
+		return nil
+	}
+}
+
+func TestExternalSecretsConfigValidator(t *testing.T) {
+	tests := []struct {
+		name         string
+		preReq       func(*Reconciler, *fakes.FakeCtrlClient)
+		esc          func(*operatorv1alpha1.ExternalSecretsConfig)
+		esm          func(*operatorv1alpha1.ExternalSecretsManager)
+		wantWarnings admission.Warnings
+		wantErr      string
+	}{
+		{
+			name: "valid configuration without externalsecretsmanager",
+			preReq: func(r *Reconciler, m *fakes.FakeCtrlClient) {
+				m.GetCalls(testSingletonGetCalls(nil, nil))
+			},
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ApplicationConfig.NodeSelector = map[string]string{"node-role.kubernetes.io/worker": ""}
+			},
+		},
+		{
+			name: "invalid tolerations",
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ApplicationConfig.Tolerations = []corev1.Toleration{
+					{Key: "key", Operator: corev1.TolerationOpExists, Value: "test"},
+				}
+			},
+			wantErr: `ExternalSecretsConfig.operator.openshift.io "cluster" is invalid: spec.applicationConfig.tolerations[0].operator: Invalid value: "test": value must be empty when ` + "`operator`" + ` is 'Exists'`,
+		},
+		{
+			name: "invalid resource requirements",
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ApplicationConfig.Resources = &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				}
+			},
+			wantErr: `ExternalSecretsConfig.operator.openshift.io "cluster" is invalid: spec.applicationConfig.resources.requests: Invalid value: "200m": must be less than or equal to cpu limit of 100m`,
+		},
+		{
+			name: "cert-manager enabled but not installed",
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ControllerConfig.CertProvider = &operatorv1alpha1.CertProvidersConfig{
+					CertManager: &operatorv1alpha1.CertManagerConfig{
+						Mode:      operatorv1alpha1.Enabled,
+						IssuerRef: &operatorv1alpha1.ObjectReference{Name: testIssuerName},
+					},
+				}
+			},
+			wantWarnings: admission.Warnings{`spec.controllerConfig.certProvider.certManager.mode: Invalid value: "Enabled": cert-manager is not installed`},
+		},
+		{
+			name: "cert-manager issuer does not exist",
+			preReq: func(r *Reconciler, m *fakes.FakeCtrlClient) {
+				r.optionalResourcesList[certificateCRDGKV] = struct{}{}
+				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+					if _, ok := obj.(*certmanagerv1.ClusterIssuer); ok && ns.Name == testIssuerName {
+						return false, nil
+					}
+					return true, nil
+				})
+			},
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ControllerConfig.CertProvider = &operatorv1alpha1.CertProvidersConfig{
+					CertManager: &operatorv1alpha1.CertManagerConfig{
+						Mode:      operatorv1alpha1.Enabled,
+						IssuerRef: &operatorv1alpha1.ObjectReference{Name: testIssuerName, Kind: "clusterissuer"},
+					},
+				}
+			},
+			wantWarnings: admission.Warnings{`spec.controllerConfig.certProvider.certManager.issuerRef.name: Not found: "test-issuer"`},
+		},
+		{
+			name: "cert-manager issuer exists",
+			preReq: func(r *Reconciler, m *fakes.FakeCtrlClient) {
+				r.optionalResourcesList[certificateCRDGKV] = struct{}{}
+				m.ExistsReturns(true, nil)
+			},
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ControllerConfig.CertProvider = &operatorv1alpha1.CertProvidersConfig{
+					CertManager: &operatorv1alpha1.CertManagerConfig{
+						Mode:      operatorv1alpha1.Enabled,
+						IssuerRef: &operatorv1alpha1.ObjectReference{Name: testIssuerName},
+					},
+				}
+			},
+		},
+		{
+			name: "credentials requests configured but Cloud Credential Operator not installed",
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ControllerConfig.CredentialsRequests = []operatorv1alpha1.CloudCredentialsRequest{{Name: "aws"}}
+			},
+			wantWarnings: admission.Warnings{"spec.controllerConfig.credentialsRequests: Forbidden: Cloud Credential Operator is not installed"},
+		},
+		{
+			name: "node selector conflicts with externalsecretsmanager required node affinity",
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ApplicationConfig.NodeSelector = map[string]string{"zone": "a"}
+			},
+			esm: func(esm *operatorv1alpha1.ExternalSecretsManager) {
+				esm.Spec.GlobalConfig = &operatorv1alpha1.GlobalConfig{
+					CommonConfigs: operatorv1alpha1.CommonConfigs{Affinity: testRequiredNodeAffinity("zone", "b", "c")},
+				}
+			},
+			wantErr: `ExternalSecretsConfig.operator.openshift.io "cluster" is invalid: spec.applicationConfig.nodeSelector: Invalid value: {"zone":"a"}: no node can match both spec.applicationConfig.nodeSelector and the required node affinity of spec.globalConfig.affinity`,
+		},
+		{
+			name: "node selector satisfying externalsecretsmanager required node affinity",
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ApplicationConfig.NodeSelector = map[string]string{"zone": "b"}
+			},
+			esm: func(esm *operatorv1alpha1.ExternalSecretsManager) {
+				esm.Spec.GlobalConfig = &operatorv1alpha1.GlobalConfig{
+					CommonConfigs: operatorv1alpha1.CommonConfigs{Affinity: testRequiredNodeAffinity("zone", "b", "c")},
+				}
+			},
+		},
+		{
+			name: "externalsecretsmanager global configuration overridden",
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ApplicationConfig.Resources = &corev1.ResourceRequirements{}
+				esc.Spec.ApplicationConfig.Tolerations = []corev1.Toleration{}
+			},
+			esm: func(esm *operatorv1alpha1.ExternalSecretsManager) {
+				esm.Spec.GlobalConfig = &operatorv1alpha1.GlobalConfig{
+					CommonConfigs: operatorv1alpha1.CommonConfigs{
+						Resources:   &corev1.ResourceRequirements{},
+						Tolerations: []corev1.Toleration{{Key: "key", Operator: corev1.TolerationOpExists}},
+					},
+				}
+			},
+			wantWarnings: admission.Warnings{
+				`spec.globalConfig.resources is overridden by spec.applicationConfig.resources of externalsecretsconfigs.operator.openshift.io "cluster"`,
+				`spec.globalConfig.tolerations is overridden by spec.applicationConfig.tolerations of externalsecretsconfigs.operator.openshift.io "cluster"`,
+			},
+		},
+		{
+			name: "externalsecretsmanager fetch fails",
+			preReq: func(r *Reconciler, m *fakes.FakeCtrlClient) {
+				m.GetReturns(commontest.ErrTestClient)
+			},
+			wantErr: `Internal error occurred: failed to fetch externalsecretsmanagers.operator.openshift.io "cluster" for validation: test client error`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			mock := &fakes.FakeCtrlClient{}
+			esc := commontest.TestExternalSecretsConfig()
+			if tt.esc != nil {
+				tt.esc(esc)
+			}
+			esm := commontest.TestExternalSecretsManager()
+			if tt.esm != nil {
+				tt.esm(esm)
+			}
+			mock.GetCalls(testSingletonGetCalls(esc, esm))
+			if tt.preReq != nil {
+				tt.preReq(r, mock)
+			}
+			r.UncachedClient = mock
+
+			v := &externalSecretsConfigValidator{r: r}
+			warnings, err := v.ValidateCreate(context.Background(), esc)
+			if (tt.wantErr != "" || err != nil) && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("ValidateCreate() err: %v, wantErr: %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(warnings, tt.wantWarnings) {
+				t.Errorf("ValidateCreate() warnings: %q, wantWarnings: %q", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestExternalSecretsConfigValidateUpdate(t *testing.T) {
+	issuerMissing := func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+		esc.Spec.ControllerConfig.CertProvider = &operatorv1alpha1.CertProvidersConfig{
+			CertManager: &operatorv1alpha1.CertManagerConfig{
+				Mode:      operatorv1alpha1.Enabled,
+				IssuerRef: &operatorv1alpha1.ObjectReference{Name: testIssuerName},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		old          func(*operatorv1alpha1.ExternalSecretsConfig)
+		updated      func(*operatorv1alpha1.ExternalSecretsConfig)
+		wantWarnings admission.Warnings
+		wantErr      string
+	}{
+		{
+			name: "finalizer removed while the issuer does not exist",
+			old: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				issuerMissing(esc)
+				esc.SetFinalizers([]string{finalizer})
+				esc.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+			},
+			updated: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.SetFinalizers(nil)
+			},
+		},
+		{
+			name: "labels updated while the configuration is invalid",
+			old: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ApplicationConfig.Tolerations = []corev1.Toleration{
+					{Key: "key", Operator: corev1.TolerationOpExists, Value: "test"},
+				}
+			},
+			updated: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.SetLabels(map[string]string{"team": "security"})
+			},
+		},
+		{
+			name: "spec updated while the issuer does not exist",
+			updated: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				issuerMissing(esc)
+			},
+			wantWarnings: admission.Warnings{`spec.controllerConfig.certProvider.certManager.issuerRef.name: Not found: "test-issuer"`},
+		},
+		{
+			name: "spec updated with invalid tolerations",
+			updated: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ApplicationConfig.Tolerations = []corev1.Toleration{
+					{Key: "key", Operator: corev1.TolerationOpExists, Value: "test"},
+				}
+			},
+			wantErr: `ExternalSecretsConfig.operator.openshift.io "cluster" is invalid: spec.applicationConfig.tolerations[0].operator: Invalid value: "test": value must be empty when ` + "`operator`" + ` is 'Exists'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			r.optionalResourcesList[certificateCRDGKV] = struct{}{}
+			mock := &fakes.FakeCtrlClient{}
+			mock.ExistsReturns(false, nil)
+			r.UncachedClient = mock
+			r.CtrlClient = mock
+
+			old := commontest.TestExternalSecretsConfig()
+			if tt.old != nil {
+				tt.old(old)
+			}
+			updated := old.DeepCopy()
+			tt.updated(updated)
+			mock.GetCalls(testSingletonGetCalls(updated, commontest.TestExternalSecretsManager()))
+
+			v := &externalSecretsConfigValidator{r: r}
+			warnings, err := v.ValidateUpdate(context.Background(), old, updated)
+			if (tt.wantErr != "" || err != nil) && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("ValidateUpdate() err: %v, wantErr: %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(warnings, tt.wantWarnings) {
+				t.Errorf("ValidateUpdate() warnings: %q, wantWarnings: %q", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestExternalSecretsManagerValidator(t *testing.T) {
+	tests := []struct {
+		name         string
+		esc          func(*operatorv1alpha1.ExternalSecretsConfig)
+		esm          func(*operatorv1alpha1.ExternalSecretsManager)
+		escNotFound  bool
+		wantWarnings admission.Warnings
+		wantErr      string
+	}{
+		{
+			name: "global configuration not set",
+		},
+		{
+			name: "invalid node selector",
+			esm: func(esm *operatorv1alpha1.ExternalSecretsManager) {
+				esm.Spec.GlobalConfig = &operatorv1alpha1.GlobalConfig{
+					CommonConfigs: operatorv1alpha1.CommonConfigs{NodeSelector: map[string]string{"node/Label/2": "value"}},
+				}
+			},
+			escNotFound: true,
+			wantErr:     `ExternalSecretsManager.operator.openshift.io "cluster" is invalid: spec.globalConfig.nodeSelector: Invalid value: "node/Label/2": a qualified name must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]') with an optional DNS subdomain prefix and '/' (e.g. 'example.com/MyName')`,
+		},
+		{
+			name: "required node affinity conflicts with externalsecretsconfig node selector",
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ApplicationConfig.NodeSelector = map[string]string{"zone": "a"}
+			},
+			esm: func(esm *operatorv1alpha1.ExternalSecretsManager) {
+				esm.Spec.GlobalConfig = &operatorv1alpha1.GlobalConfig{
+					CommonConfigs: operatorv1alpha1.CommonConfigs{Affinity: testRequiredNodeAffinity("zone", "b")},
+				}
+			},
+			wantErr: `ExternalSecretsManager.operator.openshift.io "cluster" is invalid: spec.globalConfig.affinity: Invalid value: "": no node can match both spec.applicationConfig.nodeSelector and the required node affinity of spec.globalConfig.affinity`,
+		},
+		{
+			name: "required node affinity overridden by externalsecretsconfig",
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ApplicationConfig.NodeSelector = map[string]string{"zone": "a"}
+				esc.Spec.ApplicationConfig.Affinity = testRequiredNodeAffinity("zone", "a")
+			},
+			esm: func(esm *operatorv1alpha1.ExternalSecretsManager) {
+				esm.Spec.GlobalConfig = &operatorv1alpha1.GlobalConfig{
+					CommonConfigs: operatorv1alpha1.CommonConfigs{Affinity: testRequiredNodeAffinity("zone", "b")},
+				}
+			},
+			wantWarnings: admission.Warnings{
+				`spec.globalConfig.affinity is overridden by spec.applicationConfig.affinity of externalsecretsconfigs.operator.openshift.io "cluster"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			mock := &fakes.FakeCtrlClient{}
+			esc := commontest.TestExternalSecretsConfig()
+			if tt.esc != nil {
+				tt.esc(esc)
+			}
+			if tt.escNotFound {
+				esc = nil
+			}
+			esm := commontest.TestExternalSecretsManager()
+			if tt.esm != nil {
+				tt.esm(esm)
+			}
+			mock.GetCalls(testSingletonGetCalls(esc, esm))
+			r.UncachedClient = mock
+
+			v := &externalSecretsManagerValidator{r: r}
+			warnings, err := v.ValidateUpdate(context.Background(), commontest.TestExternalSecretsManager(), esm)
+			if (tt.wantErr != "" || err != nil) && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("ValidateUpdate() err: %v, wantErr: %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(warnings, tt.wantWarnings) {
+				t.Errorf("ValidateUpdate() warnings: %q, wantWarnings: %q", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
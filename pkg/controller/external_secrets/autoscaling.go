@@ -0,0 +1,199 @@
+package external_secrets
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	operatorclient "github.com/openshift/external-secrets-operator/pkg/controller/client"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+)
+
+// defaultTargetCPUUtilizationPercentage is the CPU utilization target of the HorizontalPodAutoscalers, when no
+// utilization target is configured.
+const defaultTargetCPUUtilizationPercentage int32 = 80
+
+// autoscalingTarget is an external-secrets component deployment, which can be scaled with a HorizontalPodAutoscaler.
+type autoscalingTarget struct {
+	componentName operatorv1alpha1.ComponentName
+	// deploymentName is the name of the deployment scaled, the HorizontalPodAutoscaler is created with the same
+	// name as the deployment.
+	deploymentName string
+	// enabled is whether the component is deployed.
+	enabled bool
+}
+
+// getAutoscalingTargets returns the deployments of the external-secrets components supporting autoscaling.
+func getAutoscalingTargets(esc *operatorv1alpha1.ExternalSecretsConfig) []autoscalingTarget {
+	return []autoscalingTarget{
+		{
+			componentName:  operatorv1alpha1.Webhook,
+			deploymentName: webhookDeploymentName,
+			enabled:        true,
+		},
+		{
+			componentName:  operatorv1alpha1.BitwardenSDKServer,
+			deploymentName: bitwardenDeploymentName,
+			enabled:        isBitwardenConfigEnabled(esc),
+		},
+	}
+}
+
+// getAutoscalingConfig returns the autoscaling configuration of the component, or nil when not configured.
+func getAutoscalingConfig(esc *operatorv1alpha1.ExternalSecretsConfig, componentName operatorv1alpha1.ComponentName) *operatorv1alpha1.AutoscalingConfig {
+	for _, config := range esc.Spec.ControllerConfig.ComponentConfigs {
+		if config.ComponentName == componentName {
+			return config.Autoscaling
+		}
+	}
+	return nil
+}
+
+// getAutoscaledFields returns the fields of the deployment of a component configured with autoscaling, which are
+// yielded to the HorizontalPodAutoscaler when applied. The fields of the other deployments are not yielded, and the
+// replicas scaled by the users are restored.
+func getAutoscaledFields(esc *operatorv1alpha1.ExternalSecretsConfig, assetName string) operatorclient.YieldFields {
+	componentName, _, err := getComponentNameFromAsset(assetName)
+	if err != nil || getAutoscalingConfig(esc, componentName) == nil {
+		return nil
+	}
+	return operatorclient.YieldFields{{"spec", "replicas"}}
+}
+
+// retainAutoscaledReplicas sets the replicas of the desired deployment of a component configured with autoscaling to
+// the current replicas of the fetched deployment. The current replicas are applied until the HorizontalPodAutoscaler
+// scales the deployment and takes over the ownership of the replicas, which are then yielded to the autoscaler when
+// applied, so that the deployment is not scaled to the replicas of the asset on enabling autoscaling.
+func retainAutoscaledReplicas(esc *operatorv1alpha1.ExternalSecretsConfig, assetName string, desired, fetched *appsv1.Deployment) {
+	componentName, _, err := getComponentNameFromAsset(assetName)
+	if err != nil || getAutoscalingConfig(esc, componentName) == nil || fetched.Spec.Replicas == nil {
+		return
+	}
+	desired.Spec.Replicas = ptr.To(*fetched.Spec.Replicas)
+}
+
+// createOrApplyAutoscalers creates or updates the HorizontalPodAutoscalers of the external-secrets components
+// configured with autoscaling, and removes the ones not required anymore.
+func (r *Reconciler) createOrApplyAutoscalers(esc *operatorv1alpha1.ExternalSecretsConfig, resourceMetadata common.ResourceMetadata, externalSecretsConfigCreateRecon bool) error {
+	for _, target := range getAutoscalingTargets(esc) {
+		config := getAutoscalingConfig(esc, target.componentName)
+		if config == nil || !target.enabled {
+			if err := r.deleteAutoscaler(esc, target.deploymentName); err != nil {
+				return err
+			}
+			continue
+		}
+
+		desired := getHorizontalPodAutoscalerObject(esc, target.deploymentName, config, resourceMetadata)
+		autoscalerName := fmt.Sprintf("%s/%s", desired.GetNamespace(), desired.GetName())
+		r.log.V(4).Info("reconciling horizontalpodautoscaler resource", "name", autoscalerName)
+
+		fetched := &autoscalingv2.HorizontalPodAutoscaler{}
+		exist, err := r.Exists(r.ctx, client.ObjectKeyFromObject(desired), fetched)
+		if err != nil {
+			return common.FromClientError(err, "failed to check %s horizontalpodautoscaler resource already exists", autoscalerName)
+		}
+		if exist && externalSecretsConfigCreateRecon {
+			r.eventRecorder.Eventf(esc, corev1.EventTypeWarning, "ResourceAlreadyExists", "%s horizontalpodautoscaler resource already exists", autoscalerName)
+		}
+		switch {
+		case exist && r.hasObjectDrifted(desired, fetched):
+			r.log.V(1).Info("horizontalpodautoscaler has been modified, updating to desired state", "name", autoscalerName)
+			common.RemoveObsoleteAnnotations(desired, resourceMetadata)
+			if err := r.Apply(r.ctx, desired); err != nil {
+				return common.FromClientError(err, "failed to update %s horizontalpodautoscaler resource", autoscalerName)
+			}
+			r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "horizontalpodautoscaler resource %s updated", autoscalerName)
+		case !exist:
+			if err := r.Apply(r.ctx, desired); err != nil {
+				return common.FromClientError(err, "failed to create %s horizontalpodautoscaler resource", autoscalerName)
+			}
+			r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "horizontalpodautoscaler resource %s created", autoscalerName)
+		default:
+			r.log.V(4).Info("horizontalpodautoscaler resource already exists and is in expected state", "name", autoscalerName)
+		}
+	}
+
+	return nil
+}
+
+// deleteAutoscaler removes the HorizontalPodAutoscaler of a component created earlier, which is not required anymore.
+// The replicas of the deployment are left as last scaled by the autoscaler.
+func (r *Reconciler) deleteAutoscaler(esc *operatorv1alpha1.ExternalSecretsConfig, deploymentName string) error {
+	key := client.ObjectKey{
+		Name:      deploymentName,
+		Namespace: getNamespace(esc),
+	}
+	fetched := &autoscalingv2.HorizontalPodAutoscaler{}
+	exist, err := r.Exists(r.ctx, key, fetched)
+	if err != nil {
+		return common.FromClientError(err, "failed to check %s horizontalpodautoscaler resource already exists", key)
+	}
+	if !exist || fetched.GetLabels()[requestEnqueueLabelKey] != requestEnqueueLabelValue {
+		return nil
+	}
+
+	if err := r.Delete(r.ctx, fetched); err != nil {
+		return common.FromClientError(err, "failed to delete %s horizontalpodautoscaler resource", key)
+	}
+	r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "horizontalpodautoscaler resource %s deleted", key)
+	return nil
+}
+
+// getHorizontalPodAutoscalerObject returns the HorizontalPodAutoscaler scaling the component deployment on the
+// utilization targets configured.
+func getHorizontalPodAutoscalerObject(esc *operatorv1alpha1.ExternalSecretsConfig, deploymentName string, config *operatorv1alpha1.AutoscalingConfig, resourceMetadata common.ResourceMetadata) *autoscalingv2.HorizontalPodAutoscaler {
+	targets := []struct {
+		resource   corev1.ResourceName
+		percentage *int32
+	}{
+		{resource: corev1.ResourceCPU, percentage: config.TargetCPUUtilizationPercentage},
+		{resource: corev1.ResourceMemory, percentage: config.TargetMemoryUtilizationPercentage},
+	}
+	if config.TargetCPUUtilizationPercentage == nil && config.TargetMemoryUtilizationPercentage == nil {
+		targets[0].percentage = ptr.To(defaultTargetCPUUtilizationPercentage)
+	}
+
+	var metrics []autoscalingv2.MetricSpec
+	for _, target := range targets {
+		if target.percentage == nil {
+			continue
+		}
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: target.resource,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: ptr.To(*target.percentage),
+				},
+			},
+		})
+	}
+
+	autoscaler := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deploymentName,
+			Namespace: getNamespace(esc),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       deploymentName,
+			},
+			MinReplicas: ptr.To(ptr.Deref(config.MinReplicas, 1)),
+			MaxReplicas: config.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
+	common.ApplyResourceMetadata(autoscaler, resourceMetadata)
+
+	return autoscaler
+}
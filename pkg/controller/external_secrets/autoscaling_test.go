@@ -0,0 +1,303 @@
+package external_secrets
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	operatorclient "github.com/openshift/external-secrets-operator/pkg/controller/client"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+func TestCreateOrApplyAutoscalers(t *testing.T) {
+	webhookAutoscaling := func(config *operatorv1alpha1.AutoscalingConfig) func(*operatorv1alpha1.ExternalSecretsConfig) {
+		return func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+			esc.Spec.ControllerConfig.ComponentConfigs = []operatorv1alpha1.ComponentConfig{
+				{ComponentName: operatorv1alpha1.Webhook, Autoscaling: config},
+			}
+		}
+	}
+
+	tests := []struct {
+		name        string
+		esc         func(*operatorv1alpha1.ExternalSecretsConfig)
+		existing    func(*operatorv1alpha1.ExternalSecretsConfig) []*autoscalingv2.HorizontalPodAutoscaler
+		wantApplied []string
+		wantDeleted []string
+		verify      func(*testing.T, *autoscalingv2.HorizontalPodAutoscaler)
+	}{
+		{
+			name: "autoscaling not configured",
+		},
+		{
+			name:        "webhook autoscaler created with the default CPU target",
+			esc:         webhookAutoscaling(&operatorv1alpha1.AutoscalingConfig{MaxReplicas: 3}),
+			wantApplied: []string{webhookDeploymentName},
+			verify: func(t *testing.T, hpa *autoscalingv2.HorizontalPodAutoscaler) {
+				ref := hpa.Spec.ScaleTargetRef
+				if ref.Kind != "Deployment" || ref.Name != webhookDeploymentName || ref.APIVersion != "apps/v1" {
+					t.Errorf("scaleTargetRef = %+v, want the webhook deployment", ref)
+				}
+				if ptr.Deref(hpa.Spec.MinReplicas, 0) != 1 || hpa.Spec.MaxReplicas != 3 {
+					t.Errorf("replicas = %v-%d, want 1-3", hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas)
+				}
+				if len(hpa.Spec.Metrics) != 1 || hpa.Spec.Metrics[0].Resource.Name != corev1.ResourceCPU ||
+					ptr.Deref(hpa.Spec.Metrics[0].Resource.Target.AverageUtilization, 0) != defaultTargetCPUUtilizationPercentage {
+					t.Errorf("metrics = %+v, want the default CPU utilization target", hpa.Spec.Metrics)
+				}
+			},
+		},
+		{
+			name: "webhook autoscaler created with the memory target",
+			esc: webhookAutoscaling(&operatorv1alpha1.AutoscalingConfig{
+				MinReplicas:                       ptr.To(int32(2)),
+				MaxReplicas:                       5,
+				TargetMemoryUtilizationPercentage: ptr.To(int32(70)),
+			}),
+			wantApplied: []string{webhookDeploymentName},
+			verify: func(t *testing.T, hpa *autoscalingv2.HorizontalPodAutoscaler) {
+				if ptr.Deref(hpa.Spec.MinReplicas, 0) != 2 || hpa.Spec.MaxReplicas != 5 {
+					t.Errorf("replicas = %v-%d, want 2-5", hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas)
+				}
+				if len(hpa.Spec.Metrics) != 1 || hpa.Spec.Metrics[0].Resource.Name != corev1.ResourceMemory ||
+					ptr.Deref(hpa.Spec.Metrics[0].Resource.Target.AverageUtilization, 0) != 70 {
+					t.Errorf("metrics = %+v, want only the memory utilization target", hpa.Spec.Metrics)
+				}
+			},
+		},
+		{
+			name: "webhook autoscaler in desired state not updated",
+			esc:  webhookAutoscaling(&operatorv1alpha1.AutoscalingConfig{MaxReplicas: 3}),
+			existing: func(esc *operatorv1alpha1.ExternalSecretsConfig) []*autoscalingv2.HorizontalPodAutoscaler {
+				config := getAutoscalingConfig(esc, operatorv1alpha1.Webhook)
+				return []*autoscalingv2.HorizontalPodAutoscaler{
+					testApplied(getHorizontalPodAutoscalerObject(esc, webhookDeploymentName, config, testResourceMetadata(esc))),
+				}
+			},
+		},
+		{
+			name: "webhook autoscaler with modified replicas updated",
+			esc:  webhookAutoscaling(&operatorv1alpha1.AutoscalingConfig{MaxReplicas: 3}),
+			existing: func(esc *operatorv1alpha1.ExternalSecretsConfig) []*autoscalingv2.HorizontalPodAutoscaler {
+				config := &operatorv1alpha1.AutoscalingConfig{MaxReplicas: 10}
+				return []*autoscalingv2.HorizontalPodAutoscaler{
+					testApplied(getHorizontalPodAutoscalerObject(esc, webhookDeploymentName, config, testResourceMetadata(esc))),
+				}
+			},
+			wantApplied: []string{webhookDeploymentName},
+		},
+		{
+			name: "autoscalers no longer configured deleted",
+			existing: func(esc *operatorv1alpha1.ExternalSecretsConfig) []*autoscalingv2.HorizontalPodAutoscaler {
+				config := &operatorv1alpha1.AutoscalingConfig{MaxReplicas: 3}
+				return []*autoscalingv2.HorizontalPodAutoscaler{
+					getHorizontalPodAutoscalerObject(esc, webhookDeploymentName, config, testResourceMetadata(esc)),
+					getHorizontalPodAutoscalerObject(esc, bitwardenDeploymentName, config, testResourceMetadata(esc)),
+				}
+			},
+			wantDeleted: []string{webhookDeploymentName, bitwardenDeploymentName},
+		},
+		{
+			name: "autoscaler of disabled bitwarden-sdk-server deleted",
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ControllerConfig.ComponentConfigs = []operatorv1alpha1.ComponentConfig{
+					{ComponentName: operatorv1alpha1.BitwardenSDKServer, Autoscaling: &operatorv1alpha1.AutoscalingConfig{MaxReplicas: 3}},
+				}
+			},
+			existing: func(esc *operatorv1alpha1.ExternalSecretsConfig) []*autoscalingv2.HorizontalPodAutoscaler {
+				config := getAutoscalingConfig(esc, operatorv1alpha1.BitwardenSDKServer)
+				return []*autoscalingv2.HorizontalPodAutoscaler{
+					getHorizontalPodAutoscalerObject(esc, bitwardenDeploymentName, config, testResourceMetadata(esc)),
+				}
+			},
+			wantDeleted: []string{bitwardenDeploymentName},
+		},
+		{
+			name: "autoscaler not created by the operator not deleted",
+			existing: func(esc *operatorv1alpha1.ExternalSecretsConfig) []*autoscalingv2.HorizontalPodAutoscaler {
+				hpa := getHorizontalPodAutoscalerObject(esc, webhookDeploymentName, &operatorv1alpha1.AutoscalingConfig{MaxReplicas: 3}, common.ResourceMetadata{})
+				return []*autoscalingv2.HorizontalPodAutoscaler{hpa}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			_ = autoscalingv2.AddToScheme(r.Scheme)
+			mock := &fakes.FakeCtrlClient{}
+			r.CtrlClient = mock
+			esc := commontest.TestExternalSecretsConfig()
+			if tt.esc != nil {
+				tt.esc(esc)
+			}
+			var existing []*autoscalingv2.HorizontalPodAutoscaler
+			if tt.existing != nil {
+				existing = tt.existing(esc)
+			}
+
+			mock.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+				for _, hpa := range existing {
+					if hpa.GetName() == ns.Name && hpa.GetNamespace() == ns.Namespace {
+						hpa.DeepCopyInto(obj.(*autoscalingv2.HorizontalPodAutoscaler))
+						return true, nil
+					}
+				}
+				return false, nil
+			})
+			var applied []*autoscalingv2.HorizontalPodAutoscaler
+			mock.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
+				applied = append(applied, obj.(*autoscalingv2.HorizontalPodAutoscaler))
+				return nil
+			})
+
+			if err := r.createOrApplyAutoscalers(esc, testResourceMetadata(esc), false); err != nil {
+				t.Fatalf("createOrApplyAutoscalers() unexpected error: %v", err)
+			}
+
+			if len(applied) != len(tt.wantApplied) {
+				t.Fatalf("applied %d horizontalpodautoscalers, want %v", len(applied), tt.wantApplied)
+			}
+			for i, hpa := range applied {
+				if hpa.GetName() != tt.wantApplied[i] || hpa.GetNamespace() != commontest.TestExternalSecretsNamespace {
+					t.Errorf("applied horizontalpodautoscaler %s/%s, want %s", hpa.GetNamespace(), hpa.GetName(), tt.wantApplied[i])
+				}
+				if hpa.GetLabels()[requestEnqueueLabelKey] != requestEnqueueLabelValue {
+					t.Errorf("expected managed label on horizontalpodautoscaler, got %v", hpa.GetLabels())
+				}
+				if tt.verify != nil {
+					tt.verify(t, hpa)
+				}
+			}
+
+			if mock.DeleteCallCount() != len(tt.wantDeleted) {
+				t.Fatalf("deleted %d horizontalpodautoscalers, want %v", mock.DeleteCallCount(), tt.wantDeleted)
+			}
+			for i := range tt.wantDeleted {
+				_, obj, _ := mock.DeleteArgsForCall(i)
+				if obj.GetName() != tt.wantDeleted[i] {
+					t.Errorf("deleted horizontalpodautoscaler %s, want %s", obj.GetName(), tt.wantDeleted[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAutoscaledDeploymentReplicas(t *testing.T) {
+	// scaled is the managed fields entry of the HorizontalPodAutoscaler scaling the deployment.
+	scaled := metav1.ManagedFieldsEntry{
+		Manager: "kube-controller-manager", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "scale",
+		FieldsType: "FieldsV1", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+	}
+
+	tests := []struct {
+		name        string
+		autoscaling bool
+		// modify updates the existing webhook deployment applied by the operator.
+		modify       func(*appsv1.Deployment)
+		wantReplicas *int32
+	}{
+		{
+			name:        "current replicas applied until the autoscaler scales the deployment",
+			autoscaling: true,
+			modify: func(d *appsv1.Deployment) {
+				d.Spec.Replicas = ptr.To(int32(3))
+				d.Labels["app"] = "modified"
+			},
+			wantReplicas: ptr.To(int32(3)),
+		},
+		{
+			name:        "replicas scaled by the autoscaler are not a drift",
+			autoscaling: true,
+			modify: func(d *appsv1.Deployment) {
+				// the ownership of the replicas is moved to the autoscaler when it scales the deployment.
+				d.Spec.Replicas = nil
+				testApplied(d)
+				d.Spec.Replicas = ptr.To(int32(5))
+				d.ManagedFields = append(d.ManagedFields, scaled)
+			},
+		},
+		{
+			name: "replicas of the asset applied without autoscaling",
+			modify: func(d *appsv1.Deployment) {
+				d.Spec.Replicas = ptr.To(int32(3))
+			},
+			wantReplicas: ptr.To(int32(1)),
+		},
+		{
+			name: "replicas scaled by the users restored without autoscaling",
+			modify: func(d *appsv1.Deployment) {
+				d.Spec.Replicas = nil
+				testApplied(d)
+				d.Spec.Replicas = ptr.To(int32(5))
+				d.ManagedFields = append(d.ManagedFields, scaled)
+			},
+			wantReplicas: ptr.To(int32(1)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			_ = appsv1.AddToScheme(r.Scheme)
+			mock := &fakes.FakeCtrlClient{}
+			r.CtrlClient = mock
+			esc := commontest.TestExternalSecretsConfig()
+			t.Setenv(externalsecretsImageEnvVarName, commontest.TestExternalSecretsImageName)
+
+			existing, err := r.getDeploymentObject(webhookDeploymentAssetName, esc, testResourceMetadata(esc))
+			if err != nil {
+				t.Fatalf("getDeploymentObject() unexpected error: %v", err)
+			}
+			testApplied(existing)
+			tt.modify(existing)
+			mock.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+				existing.DeepCopyInto(obj.(*appsv1.Deployment))
+				return true, nil
+			})
+
+			if tt.autoscaling {
+				esc.Spec.ControllerConfig.ComponentConfigs = []operatorv1alpha1.ComponentConfig{
+					{ComponentName: operatorv1alpha1.Webhook, Autoscaling: &operatorv1alpha1.AutoscalingConfig{MaxReplicas: 5}},
+				}
+			}
+			if _, err := r.createOrApplyDeploymentFromAsset(esc, webhookDeploymentAssetName, testResourceMetadata(esc), false, nil); err != nil {
+				t.Fatalf("createOrApplyDeploymentFromAsset() unexpected error: %v", err)
+			}
+
+			if tt.wantReplicas == nil {
+				if mock.ApplyCallCount() != 0 {
+					t.Errorf("deployment applied %d times, want not applied", mock.ApplyCallCount())
+				}
+				return
+			}
+			if mock.ApplyCallCount() != 1 {
+				t.Fatalf("deployment applied %d times, want once", mock.ApplyCallCount())
+			}
+			_, obj, opts := mock.ApplyArgsForCall(0)
+			if replicas := obj.(*appsv1.Deployment).Spec.Replicas; replicas == nil || *replicas != *tt.wantReplicas {
+				t.Errorf("applied replicas = %v, want %d", replicas, *tt.wantReplicas)
+			}
+			// the replicas are yielded to the other field managers only for the autoscaled deployments.
+			var yielded []operatorclient.FieldPath
+			for _, opt := range opts {
+				if fields, ok := opt.(operatorclient.YieldFields); ok {
+					yielded = append(yielded, fields...)
+				}
+			}
+			if (len(yielded) > 0) != tt.autoscaling {
+				t.Errorf("applied with yielded fields %v, want yielded %v", yielded, tt.autoscaling)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package external_secrets
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+)
+
+// errBootstrapConflict is returned when a ClusterSecretStore configured for bootstrapping exists and is not created
+// by the operator.
+var errBootstrapConflict = errors.New("clustersecretstore exists and is not created by the operator")
+
+// createOrApplyBootstrapClusterSecretStores creates or updates the ClusterSecretStore resources configured for
+// bootstrapping and for the bitwarden plugin, once the external-secrets webhook is available for validating them,
+// and removes the ones created earlier which are no longer configured. The result is reported in the
+// ClusterSecretStoresBootstrapped condition, and the reconciliation continues while waiting for the webhook.
+func (r *Reconciler) createOrApplyBootstrapClusterSecretStores(esc *operatorv1alpha1.ExternalSecretsConfig, resourceMetadata common.ResourceMetadata) error {
+	var stores []operatorv1alpha1.BootstrapClusterSecretStore
+	if esc.Spec.Bootstrap != nil {
+		stores = esc.Spec.Bootstrap.ClusterSecretStores
+	}
+	bitwardenStore := getBitwardenClusterSecretStoreConfig(esc)
+
+	if len(stores) == 0 && bitwardenStore == nil {
+		apimeta.RemoveStatusCondition(&esc.Status.Conditions, operatorv1alpha1.ClusterSecretStoresBootstrapped)
+		return r.deleteStaleBootstrapClusterSecretStores(esc, nil)
+	}
+
+	cond := metav1.Condition{
+		Type:               operatorv1alpha1.ClusterSecretStoresBootstrapped,
+		Status:             metav1.ConditionFalse,
+		Reason:             operatorv1alpha1.ReasonInProgress,
+		ObservedGeneration: esc.GetGeneration(),
+	}
+	available, err := r.isWebhookAvailable(esc)
+	if err != nil {
+		return err
+	}
+	if !available {
+		r.bootstrapPending = true
+		cond.Message = fmt.Sprintf("waiting for %s deployment to be available to create the clustersecretstores", webhookDeploymentName)
+		apimeta.SetStatusCondition(&esc.Status.Conditions, cond)
+		return nil
+	}
+
+	desiredObjects := make([]*unstructured.Unstructured, 0, len(stores)+1)
+	for _, store := range stores {
+		desired, err := getBootstrapClusterSecretStoreObject(store, resourceMetadata)
+		if err != nil {
+			return err
+		}
+		desiredObjects = append(desiredObjects, desired)
+	}
+	if bitwardenStore != nil {
+		desired, err := r.getBitwardenClusterSecretStoreObject(esc, bitwardenStore, resourceMetadata)
+		if err != nil {
+			return err
+		}
+		desiredObjects = append(desiredObjects, desired)
+	}
+
+	desiredNames := make([]string, 0, len(desiredObjects))
+	for _, desired := range desiredObjects {
+		if slices.Contains(desiredNames, desired.GetName()) {
+			return common.NewIrrecoverableError(fmt.Errorf("clustersecretstore %s is configured more than once", desired.GetName()),
+				"bitwarden clustersecretstore name must not be same as a bootstrap clustersecretstore name")
+		}
+		desiredNames = append(desiredNames, desired.GetName())
+	}
+
+	var conflicts []string
+	for _, desired := range desiredObjects {
+		if err := r.createOrApplyBootstrapClusterSecretStore(esc, desired, resourceMetadata); err != nil {
+			if !errors.Is(err, errBootstrapConflict) {
+				return err
+			}
+			conflicts = append(conflicts, desired.GetName())
+		}
+	}
+
+	cond.Status, cond.Reason, cond.Message = metav1.ConditionTrue, operatorv1alpha1.ReasonReady, "all the configured clustersecretstores are created"
+	if len(conflicts) > 0 {
+		cond.Status, cond.Reason = metav1.ConditionFalse, operatorv1alpha1.ReasonFailed
+		cond.Message = fmt.Sprintf("clustersecretstores %s exist without the %s label and are not managed by the operator",
+			strings.Join(conflicts, ", "), bootstrapResourceLabelKey)
+	}
+	apimeta.SetStatusCondition(&esc.Status.Conditions, cond)
+
+	return r.deleteStaleBootstrapClusterSecretStores(esc, desiredNames)
+}
+
+// createOrApplyBootstrapClusterSecretStore creates the ClusterSecretStore resource, or updates it when it differs from
+// the desired state. A ClusterSecretStore of the same name created by the users, without the bootstrap label, is not
+// adopted, and errBootstrapConflict is returned.
+func (r *Reconciler) createOrApplyBootstrapClusterSecretStore(esc *operatorv1alpha1.ExternalSecretsConfig, desired *unstructured.Unstructured, resourceMetadata common.ResourceMetadata) error {
+	storeName := desired.GetName()
+	r.log.V(4).Info("reconciling bootstrap clustersecretstore resource", "name", storeName)
+
+	fetched := &unstructured.Unstructured{}
+	fetched.SetGroupVersionKind(clusterSecretStoreGVK)
+	exist, err := r.Exists(r.ctx, client.ObjectKeyFromObject(desired), fetched)
+	if err != nil {
+		return common.FromClientError(err, "failed to check %s clustersecretstore resource already exists", storeName)
+	}
+	if exist && !isBootstrapResource(fetched) {
+		r.log.V(1).Info("clustersecretstore not created by the operator exists, skipping", "name", storeName)
+		r.eventRecorder.Eventf(esc, corev1.EventTypeWarning, "ResourceConflict", "clustersecretstore resource %s exists and is not managed by the operator", storeName)
+		return errBootstrapConflict
+	}
+
+	switch {
+	case exist && r.hasObjectDrifted(desired, fetched):
+		r.log.V(1).Info("clustersecretstore has been modified, updating to desired state", "name", storeName)
+		common.RemoveObsoleteAnnotations(desired, resourceMetadata)
+		if err := r.Apply(r.ctx, desired); err != nil {
+			return common.FromClientError(err, "failed to update %s clustersecretstore resource", storeName)
+		}
+		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "clustersecretstore resource %s updated", storeName)
+	case !exist:
+		if err := r.Apply(r.ctx, desired); err != nil {
+			return common.FromClientError(err, "failed to create %s clustersecretstore resource", storeName)
+		}
+		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "clustersecretstore resource %s created", storeName)
+	default:
+		r.log.V(4).Info("clustersecretstore resource already exists and is in expected state", "name", storeName)
+	}
+
+	return nil
+}
+
+// isBootstrapResource returns whether the resource is labeled as created by the operator from the bootstrap configuration.
+func isBootstrapResource(obj client.Object) bool {
+	return obj.GetLabels()[bootstrapResourceLabelKey] == "true"
+}
+
+// deleteStaleBootstrapClusterSecretStores removes the bootstrap ClusterSecretStore resources which are not configured anymore.
+func (r *Reconciler) deleteStaleBootstrapClusterSecretStores(esc *operatorv1alpha1.ExternalSecretsConfig, desiredNames []string) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(clusterSecretStoreGVK.GroupVersion().WithKind(clusterSecretStoreGVK.Kind + "List"))
+	if err := r.List(r.ctx, list, client.MatchingLabels{bootstrapResourceLabelKey: "true"}); err != nil {
+		return common.FromClientError(err, "failed to list bootstrap clustersecretstore resources")
+	}
+
+	for i := range list.Items {
+		item := &list.Items[i]
+		if slices.Contains(desiredNames, item.GetName()) {
+			continue
+		}
+		if err := r.Delete(r.ctx, item); err != nil {
+			return common.FromClientError(err, "failed to delete %s clustersecretstore resource", item.GetName())
+		}
+		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "clustersecretstore resource %s deleted", item.GetName())
+	}
+
+	return nil
+}
+
+// isWebhookAvailable returns whether the external-secrets webhook deployment is available.
+func (r *Reconciler) isWebhookAvailable(esc *operatorv1alpha1.ExternalSecretsConfig) (bool, error) {
+	key := types.NamespacedName{Name: webhookDeploymentName, Namespace: getNamespace(esc)}
+	deployment := &appsv1.Deployment{}
+	exist, err := r.Exists(r.ctx, key, deployment)
+	if err != nil {
+		return false, common.FromClientError(err, "failed to fetch %s deployment", key)
+	}
+	return exist && isDeploymentAvailable(deployment), nil
+}
+
+// isDeploymentAvailable returns whether the deployment has the Available condition set to true.
+func isDeploymentAvailable(deployment *appsv1.Deployment) bool {
+	for _, cond := range deployment.Status.Conditions {
+		if cond.Type == appsv1.DeploymentAvailable {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// getBootstrapClusterSecretStoreObject returns the ClusterSecretStore resource for the bootstrap configuration.
+func getBootstrapClusterSecretStoreObject(store operatorv1alpha1.BootstrapClusterSecretStore, resourceMetadata common.ResourceMetadata) (*unstructured.Unstructured, error) {
+	spec := make(map[string]interface{})
+	if len(store.Spec.Raw) > 0 {
+		if err := utiljson.Unmarshal(store.Spec.Raw, &spec); err != nil {
+			return nil, common.NewIrrecoverableError(err, "failed to decode spec of bootstrap clustersecretstore %s", store.Name)
+		}
+	}
+
+	clusterSecretStore := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": spec,
+		},
+	}
+	clusterSecretStore.SetGroupVersionKind(clusterSecretStoreGVK)
+	clusterSecretStore.SetName(store.Name)
+	common.ApplyResourceMetadata(clusterSecretStore, resourceMetadata)
+	common.UpdateResourceLabels(clusterSecretStore, map[string]string{bootstrapResourceLabelKey: "true"})
+
+	return clusterSecretStore, nil
+}
+
+// getBitwardenClusterSecretStoreConfig returns the ClusterSecretStore config of the bitwarden plugin, when the
+// plugin is enabled and the ClusterSecretStore is configured.
+func getBitwardenClusterSecretStoreConfig(esc *operatorv1alpha1.ExternalSecretsConfig) *operatorv1alpha1.BitwardenClusterSecretStoreConfig {
+	if !isBitwardenConfigEnabled(esc) {
+		return nil
+	}
+	return esc.Spec.Plugins.BitwardenSecretManagerProvider.ClusterSecretStore
+}
+
+// getBitwardenClusterSecretStoreObject returns the ClusterSecretStore resource for the bitwarden secrets manager
+// provider, configured with the bitwarden-sdk-server URL and the CA certificate from the bitwarden-sdk-server TLS secret.
+func (r *Reconciler) getBitwardenClusterSecretStoreObject(esc *operatorv1alpha1.ExternalSecretsConfig, store *operatorv1alpha1.BitwardenClusterSecretStoreConfig, resourceMetadata common.ResourceMetadata) (*unstructured.Unstructured, error) {
+	caBundle, err := r.getBitwardenCABundle(esc)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterSecretStore := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"provider": map[string]interface{}{
+					"bitwardensecretsmanager": map[string]interface{}{
+						"auth": map[string]interface{}{
+							"secretRef": map[string]interface{}{
+								"credentials": map[string]interface{}{
+									"key":       store.CredentialsSecretRef.Key,
+									"name":      store.CredentialsSecretRef.Name,
+									"namespace": store.CredentialsSecretRef.Namespace,
+								},
+							},
+						},
+						"bitwardenServerSDKURL": fmt.Sprintf(bitwardenSDKServerURLFmt, getNamespace(esc)),
+						"caBundle":              caBundle,
+						"organizationID":        store.OrganizationID,
+						"projectID":             store.ProjectID,
+					},
+				},
+			},
+		},
+	}
+	clusterSecretStore.SetGroupVersionKind(clusterSecretStoreGVK)
+	clusterSecretStore.SetName(store.Name)
+	common.ApplyResourceMetadata(clusterSecretStore, resourceMetadata)
+	common.UpdateResourceLabels(clusterSecretStore, map[string]string{bootstrapResourceLabelKey: "true"})
+
+	return clusterSecretStore, nil
+}
+
+// getBitwardenCABundle returns the base64 encoded CA certificate from the bitwarden-sdk-server TLS secret, which is
+// either configured by the user or created by cert-manager.
+func (r *Reconciler) getBitwardenCABundle(esc *operatorv1alpha1.ExternalSecretsConfig) (string, error) {
+	key := types.NamespacedName{Name: getBitwardenTLSSecretName(esc), Namespace: getNamespace(esc)}
+
+	secret := &corev1.Secret{}
+	exist, err := r.UncachedClient.Exists(r.ctx, key, secret)
+	if err != nil {
+		return "", common.FromClientError(err, "failed to fetch %s bitwarden-sdk-server TLS secret", key)
+	}
+	if !exist || len(secret.Data[bitwardenCACertKey]) == 0 {
+		return "", common.NewRetryRequiredError(fmt.Errorf("%s not found in %s secret", bitwardenCACertKey, key),
+			"waiting for bitwarden-sdk-server CA certificate to create bitwarden clustersecretstore")
+	}
+
+	return base64.StdEncoding.EncodeToString(secret.Data[bitwardenCACertKey]), nil
+}
+
+// getBitwardenTLSSecretName returns the name of the bitwarden-sdk-server TLS secret, which is either configured by
+// the user or created by cert-manager.
+func getBitwardenTLSSecretName(esc *operatorv1alpha1.ExternalSecretsConfig) string {
+	if bitwarden := esc.Spec.Plugins.BitwardenSecretManagerProvider; bitwarden != nil && bitwarden.SecretRef != nil && bitwarden.SecretRef.Name != "" {
+		return bitwarden.SecretRef.Name
+	}
+	return bitwardenTLSSecretName
+}
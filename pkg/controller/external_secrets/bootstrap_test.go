@@ -0,0 +1,336 @@
+package external_secrets
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+func testBootstrapConfig() *operatorv1alpha1.BootstrapConfig {
+	return &operatorv1alpha1.BootstrapConfig{
+		ClusterSecretStores: []operatorv1alpha1.BootstrapClusterSecretStore{
+			{
+				Name: "vault",
+				Spec: runtime.RawExtension{
+					Raw: []byte(`{"provider":{"vault":{"server":"https://vault.example.com","path":"secret","version":"v2"}}}`),
+				},
+			},
+		},
+	}
+}
+
+// webhookAvailable returns an Exists stub reporting the webhook deployment with the given availability, and
+// delegating the other objects to clusterSecretStore.
+func webhookAvailable(available bool, clusterSecretStore func(*unstructured.Unstructured) bool) func(context.Context, types.NamespacedName, client.Object) (bool, error) {
+	return func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			status := corev1.ConditionFalse
+			if available {
+				status = corev1.ConditionTrue
+			}
+			o.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: status}}
+			return true, nil
+		case *unstructured.Unstructured:
+			if clusterSecretStore == nil {
+				return false, nil
+			}
+			return clusterSecretStore(o), nil
+		}
+		return false, nil
+	}
+}
+
+func TestCreateOrApplyBootstrapClusterSecretStores(t *testing.T) {
+	tests := []struct {
+		name       string
+		bootstrap  *operatorv1alpha1.BootstrapConfig
+		preReq     func(*fakes.FakeCtrlClient)
+		wantApply  int
+		wantDelete int
+		wantReason string
+		wantErr    string
+	}{
+		{
+			name: "bootstrap not configured",
+		},
+		{
+			name:      "webhook not available",
+			bootstrap: testBootstrapConfig(),
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(webhookAvailable(false, nil))
+			},
+			wantReason: operatorv1alpha1.ReasonInProgress,
+		},
+		{
+			name:      "clustersecretstore created",
+			bootstrap: testBootstrapConfig(),
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(webhookAvailable(true, nil))
+			},
+			wantApply:  1,
+			wantReason: operatorv1alpha1.ReasonReady,
+		},
+		{
+			name:      "clustersecretstore created by the users is not adopted",
+			bootstrap: testBootstrapConfig(),
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(webhookAvailable(true, func(u *unstructured.Unstructured) bool {
+					u.SetName("vault")
+					return true
+				}))
+			},
+			wantReason: operatorv1alpha1.ReasonFailed,
+		},
+		{
+			name:      "clustersecretstore modified is updated",
+			bootstrap: testBootstrapConfig(),
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(webhookAvailable(true, func(u *unstructured.Unstructured) bool {
+					esc := commontest.TestExternalSecretsConfig()
+					desired, _ := getBootstrapClusterSecretStoreObject(testBootstrapConfig().ClusterSecretStores[0], testResourceMetadata(esc))
+					testApplied(desired).DeepCopyInto(u)
+					_ = unstructured.SetNestedField(u.Object, "v1", "spec", "provider", "vault", "version")
+					return true
+				}))
+			},
+			wantApply:  1,
+			wantReason: operatorv1alpha1.ReasonReady,
+		},
+		{
+			name:      "clustersecretstore with server defaulted fields is not updated",
+			bootstrap: testBootstrapConfig(),
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(webhookAvailable(true, func(u *unstructured.Unstructured) bool {
+					esc := commontest.TestExternalSecretsConfig()
+					desired, _ := getBootstrapClusterSecretStoreObject(testBootstrapConfig().ClusterSecretStores[0], testResourceMetadata(esc))
+					testApplied(desired).DeepCopyInto(u)
+					_ = unstructured.SetNestedField(u.Object, int64(0), "spec", "refreshInterval")
+					return true
+				}))
+			},
+			wantReason: operatorv1alpha1.ReasonReady,
+		},
+		{
+			name: "clustersecretstore no longer configured is deleted",
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ListCalls(func(ctx context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					stale := unstructured.Unstructured{}
+					stale.SetGroupVersionKind(clusterSecretStoreGVK)
+					stale.SetName("stale")
+					list.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{stale}
+					return nil
+				})
+			},
+			wantDelete: 1,
+		},
+		{
+			name: "invalid clustersecretstore spec",
+			bootstrap: &operatorv1alpha1.BootstrapConfig{
+				ClusterSecretStores: []operatorv1alpha1.BootstrapClusterSecretStore{
+					{Name: "invalid", Spec: runtime.RawExtension{Raw: []byte(`["provider"]`)}},
+				},
+			},
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(webhookAvailable(true, nil))
+			},
+			wantErr: "failed to decode spec of bootstrap clustersecretstore invalid: json: cannot unmarshal array into Go value of type map[string]interface {}",
+		},
+		{
+			name:      "clustersecretstore creation fails",
+			bootstrap: testBootstrapConfig(),
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(webhookAvailable(true, nil))
+				m.ApplyReturns(commontest.ErrTestClient)
+			},
+			wantApply: 1,
+			wantErr:   "failed to create vault clustersecretstore resource: test client error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			mock := &fakes.FakeCtrlClient{}
+			r.CtrlClient = mock
+			if tt.preReq != nil {
+				tt.preReq(mock)
+			}
+
+			esc := commontest.TestExternalSecretsConfig()
+			esc.Spec.Bootstrap = tt.bootstrap
+
+			err := r.createOrApplyBootstrapClusterSecretStores(esc, testResourceMetadata(esc))
+			if (tt.wantErr != "" || err != nil) && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Expected error: %v, got: %v", tt.wantErr, err)
+			}
+			if mock.ApplyCallCount() != tt.wantApply {
+				t.Errorf("Apply called %d times, want %d", mock.ApplyCallCount(), tt.wantApply)
+			}
+			if mock.DeleteCallCount() != tt.wantDelete {
+				t.Errorf("Delete called %d times, want %d", mock.DeleteCallCount(), tt.wantDelete)
+			}
+			var reason string
+			if cond := apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.ClusterSecretStoresBootstrapped); cond != nil {
+				reason = cond.Reason
+			}
+			if reason != tt.wantReason {
+				t.Errorf("%s condition reason %q, want %q", operatorv1alpha1.ClusterSecretStoresBootstrapped, reason, tt.wantReason)
+			}
+			if r.bootstrapPending != (tt.wantReason == operatorv1alpha1.ReasonInProgress) {
+				t.Errorf("bootstrapPending = %v, want reason %q", r.bootstrapPending, tt.wantReason)
+			}
+		})
+	}
+}
+
+func testBitwardenClusterSecretStoreConfig() *operatorv1alpha1.BitwardenSecretManagerProvider {
+	return &operatorv1alpha1.BitwardenSecretManagerProvider{
+		Mode: operatorv1alpha1.Enabled,
+		ClusterSecretStore: &operatorv1alpha1.BitwardenClusterSecretStoreConfig{
+			Name:           "bitwarden-secretsmanager",
+			OrganizationID: "test-org",
+			ProjectID:      "test-project",
+			CredentialsSecretRef: operatorv1alpha1.BitwardenCredentialsSecretReference{
+				Name:      "bitwarden-access-token",
+				Namespace: "bitwarden",
+				Key:       "token",
+			},
+		},
+	}
+}
+
+func TestCreateOrApplyBitwardenClusterSecretStore(t *testing.T) {
+	tests := []struct {
+		name              string
+		bitwarden         *operatorv1alpha1.BitwardenSecretManagerProvider
+		bootstrap         *operatorv1alpha1.BootstrapConfig
+		caCert            []byte
+		wantCASecret      string
+		wantApply         int
+		wantErr           string
+		wantProvider      map[string]interface{}
+		skipProviderCheck bool
+	}{
+		{
+			name:         "clustersecretstore created with cert-manager CA",
+			bitwarden:    testBitwardenClusterSecretStoreConfig(),
+			caCert:       []byte("test-ca"),
+			wantCASecret: "bitwarden-tls-certs",
+			wantApply:    1,
+			wantProvider: map[string]interface{}{
+				"auth": map[string]interface{}{
+					"secretRef": map[string]interface{}{
+						"credentials": map[string]interface{}{
+							"key":       "token",
+							"name":      "bitwarden-access-token",
+							"namespace": "bitwarden",
+						},
+					},
+				},
+				"bitwardenServerSDKURL": "https://bitwarden-sdk-server.external-secrets.svc.cluster.local:9998",
+				"caBundle":              "dGVzdC1jYQ==",
+				"organizationID":        "test-org",
+				"projectID":             "test-project",
+			},
+		},
+		{
+			name: "clustersecretstore created with user provided CA",
+			bitwarden: func() *operatorv1alpha1.BitwardenSecretManagerProvider {
+				b := testBitwardenClusterSecretStoreConfig()
+				b.SecretRef = &operatorv1alpha1.SecretReference{Name: "bitwarden-user-tls"}
+				return b
+			}(),
+			caCert:            []byte("test-ca"),
+			wantCASecret:      "bitwarden-user-tls",
+			wantApply:         1,
+			skipProviderCheck: true,
+		},
+		{
+			name:         "CA certificate not yet available",
+			bitwarden:    testBitwardenClusterSecretStoreConfig(),
+			wantCASecret: "bitwarden-tls-certs",
+			wantErr:      "waiting for bitwarden-sdk-server CA certificate to create bitwarden clustersecretstore: ca.crt not found in external-secrets/bitwarden-tls-certs secret",
+		},
+		{
+			name: "plugin disabled",
+			bitwarden: func() *operatorv1alpha1.BitwardenSecretManagerProvider {
+				b := testBitwardenClusterSecretStoreConfig()
+				b.Mode = operatorv1alpha1.Disabled
+				return b
+			}(),
+			skipProviderCheck: true,
+		},
+		{
+			name:      "name conflicts with bootstrap clustersecretstore",
+			bitwarden: testBitwardenClusterSecretStoreConfig(),
+			bootstrap: &operatorv1alpha1.BootstrapConfig{
+				ClusterSecretStores: []operatorv1alpha1.BootstrapClusterSecretStore{
+					{Name: "bitwarden-secretsmanager", Spec: runtime.RawExtension{Raw: []byte(`{}`)}},
+				},
+			},
+			caCert:       []byte("test-ca"),
+			wantCASecret: "bitwarden-tls-certs",
+			wantErr:      "bitwarden clustersecretstore name must not be same as a bootstrap clustersecretstore name: clustersecretstore bitwarden-secretsmanager is configured more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			mock := &fakes.FakeCtrlClient{}
+			r.CtrlClient = mock
+			r.UncachedClient = mock
+			var fetchedCASecret string
+			mock.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+				if secret, ok := obj.(*corev1.Secret); ok {
+					fetchedCASecret = ns.Name
+					if tt.caCert == nil {
+						return false, nil
+					}
+					secret.Data = map[string][]byte{"ca.crt": tt.caCert}
+					return true, nil
+				}
+				return webhookAvailable(true, nil)(ctx, ns, obj)
+			})
+
+			esc := commontest.TestExternalSecretsConfig()
+			esc.Spec.Plugins.BitwardenSecretManagerProvider = tt.bitwarden
+			esc.Spec.Bootstrap = tt.bootstrap
+
+			err := r.createOrApplyBootstrapClusterSecretStores(esc, testResourceMetadata(esc))
+			if (tt.wantErr != "" || err != nil) && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Expected error: %v, got: %v", tt.wantErr, err)
+			}
+			if fetchedCASecret != tt.wantCASecret {
+				t.Errorf("CA fetched from secret %q, want %q", fetchedCASecret, tt.wantCASecret)
+			}
+			if mock.ApplyCallCount() != tt.wantApply {
+				t.Fatalf("Apply called %d times, want %d", mock.ApplyCallCount(), tt.wantApply)
+			}
+			if tt.wantApply == 0 || tt.skipProviderCheck {
+				return
+			}
+			_, obj, _ := mock.ApplyArgsForCall(0)
+			provider, _, _ := unstructured.NestedMap(obj.(*unstructured.Unstructured).Object, "spec", "provider", "bitwardensecretsmanager")
+			if !reflect.DeepEqual(provider, tt.wantProvider) {
+				t.Errorf("provider = %v, want %v", provider, tt.wantProvider)
+			}
+			if obj.GetLabels()[bootstrapResourceLabelKey] != "true" {
+				t.Errorf("expected bootstrap label on clustersecretstore, got %v", obj.GetLabels())
+			}
+		})
+	}
+}
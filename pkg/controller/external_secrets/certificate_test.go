@@ -169,7 +169,7 @@ func TestCreateOrApplyCertificates(t *testing.T) {
 					}
 					return false, nil
 				})
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if obj.GetName() == serviceExternalSecretWebhookName {
 						return commontest.ErrTestClient
 					}
@@ -216,6 +216,10 @@ func TestCreateOrApplyCertificates(t *testing.T) {
 				})
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					if ns.Name == serviceExternalSecretWebhookName {
+						esc := testExternalSecretsConfigForCertificate()
+						esc.Spec.ControllerConfig.CertProvider.CertManager.IssuerRef.Name = testIssuerName
+						desiredCert, _ := r.getCertificateObject(esc, testResourceMetadata(esc), webhookCertificateAssetName)
+						testApplied(desiredCert).DeepCopyInto(obj.(*certmanagerv1.Certificate))
 						return true, nil
 					}
 					if ns.Name == testIssuerName {
@@ -223,11 +227,8 @@ func TestCreateOrApplyCertificates(t *testing.T) {
 					}
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
-					t.Errorf("Create was called unexpectedly for %s", obj.GetName())
-					return nil
-				})
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
+					t.Errorf("Apply was called unexpectedly for %s", obj.GetName())
 					return nil
 				})
 			},
@@ -249,7 +250,7 @@ func TestCreateOrApplyCertificates(t *testing.T) {
 					}
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if obj.GetName() == serviceExternalSecretWebhookName {
 						return commontest.ErrTestClient
 					}
@@ -282,7 +283,7 @@ func TestCreateOrApplyCertificates(t *testing.T) {
 					}
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if obj.GetName() == serviceExternalSecretWebhookName {
 						return nil
 					}
@@ -311,7 +312,7 @@ func TestCreateOrApplyCertificates(t *testing.T) {
 						esc := testExternalSecretsConfigForCertificate()
 						esc.Spec.ControllerConfig.CertProvider.CertManager.IssuerRef.Name = testIssuerName
 						desiredCert, _ := r.getCertificateObject(esc, testResourceMetadata(esc), webhookCertificateAssetName)
-						desiredCert.DeepCopyInto(obj.(*certmanagerv1.Certificate))
+						testApplied(desiredCert).DeepCopyInto(obj.(*certmanagerv1.Certificate))
 						return true, nil
 					}
 					if ns.Name == testIssuerName {
@@ -334,12 +335,8 @@ func TestCreateOrApplyCertificates(t *testing.T) {
 					}
 					return fmt.Errorf("object not found for %s/%s", ns.Namespace, ns.Name)
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
-					t.Errorf("Create was called for %s when SecretRef exists and assertion should return early", obj.GetName())
-					return nil
-				})
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
-					t.Errorf("UpdateWithRetry was called unexpectedly for %s", obj.GetName())
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
+					t.Errorf("Apply was called unexpectedly for %s", obj.GetName())
 					return nil
 				})
 			},
@@ -364,7 +361,7 @@ func TestCreateOrApplyCertificates(t *testing.T) {
 						esc := testExternalSecretsConfigForCertificate()
 						esc.Spec.ControllerConfig.CertProvider.CertManager.IssuerRef.Name = testIssuerName
 						desiredCert, _ := r.getCertificateObject(esc, testResourceMetadata(esc), webhookCertificateAssetName)
-						desiredCert.DeepCopyInto(obj.(*certmanagerv1.Certificate))
+						testApplied(desiredCert).DeepCopyInto(obj.(*certmanagerv1.Certificate))
 						return true, nil
 					}
 					if ns.Name == testIssuerName {
@@ -386,7 +383,7 @@ func TestCreateOrApplyCertificates(t *testing.T) {
 					}
 					return fmt.Errorf("object not found")
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					t.Errorf("Create was called when SecretRef assertion should have failed and returned early")
 					return nil
 				})
@@ -419,7 +416,7 @@ func TestCreateOrApplyCertificates(t *testing.T) {
 					}
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					cert, ok := obj.(*certmanagerv1.Certificate)
 					if !ok {
 						return fmt.Errorf("expected *certmanagerv1.Certificate, got %T", obj)
@@ -454,7 +451,7 @@ func TestCreateOrApplyCertificates(t *testing.T) {
 					}
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if cert, ok := obj.(*certmanagerv1.Certificate); ok {
 						// Verify annotations are applied
 						if cert.Annotations == nil {
@@ -495,7 +492,7 @@ func TestCreateOrApplyCertificates(t *testing.T) {
 					}
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if cert, ok := obj.(*certmanagerv1.Certificate); ok {
 						// Verify all annotations from spec are present as managed annotations
 						if cert.Annotations["allowed-cert-annotation"] != "value" {
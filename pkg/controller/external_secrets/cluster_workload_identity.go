@@ -0,0 +1,211 @@
+package external_secrets
+
+import (
+	"fmt"
+	"maps"
+	"os"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+)
+
+// isClusterWorkloadIdentityEnabled returns whether deriving the workload identity from the cluster configuration
+// is enabled in ExternalSecretsConfig CR Spec.
+func isClusterWorkloadIdentityEnabled(esc *operatorv1alpha1.ExternalSecretsConfig) bool {
+	return esc.Spec.ControllerConfig.ClusterWorkloadIdentity != nil &&
+		common.EvalMode(esc.Spec.ControllerConfig.ClusterWorkloadIdentity.Mode)
+}
+
+// reconcileClusterWorkloadIdentity derives the workload identity configuration of the core controller from the
+// cluster configuration when enabled, and stores it for use while building the operand resources.
+func (r *Reconciler) reconcileClusterWorkloadIdentity(esc *operatorv1alpha1.ExternalSecretsConfig) error {
+	r.clusterWorkloadIdentity = nil
+	if !isClusterWorkloadIdentityEnabled(esc) {
+		return nil
+	}
+
+	wi, err := r.getClusterWorkloadIdentityConfig()
+	if err != nil {
+		return err
+	}
+	r.log.V(1).Info("derived workload identity configuration from cluster configuration", "mode", getCloudIdentityMode(wi))
+	r.clusterWorkloadIdentity = wi
+
+	return nil
+}
+
+// getClusterWorkloadIdentityConfig builds the workload identity configuration from the ServiceAccount issuer configured
+// in the cluster Authentication resource, the platform type in the cluster Infrastructure resource and the cloud
+// credentials made available by OLM through the operator environment variables.
+func (r *Reconciler) getClusterWorkloadIdentityConfig() (*operatorv1alpha1.WorkloadIdentityConfig, error) {
+	authentication, err := r.getClusterConfigObject(authenticationGVK)
+	if err != nil {
+		return nil, err
+	}
+	issuer, _, err := unstructured.NestedString(authentication.Object, "spec", "serviceAccountIssuer")
+	if err != nil {
+		return nil, common.NewIrrecoverableError(err, "failed to read serviceAccountIssuer from authentications.config.openshift.io/%s", clusterConfigObjectName)
+	}
+	if issuer == "" {
+		return nil, common.NewIrrecoverableError(fmt.Errorf("serviceAccountIssuer is not configured"), "cluster is not configured for short-term credentials, spec.controllerConfig.clusterWorkloadIdentity cannot be enabled")
+	}
+
+	infrastructure, err := r.getClusterConfigObject(infrastructureGVK)
+	if err != nil {
+		return nil, err
+	}
+	platform, _, err := unstructured.NestedString(infrastructure.Object, "status", "platformStatus", "type")
+	if err != nil {
+		return nil, common.NewIrrecoverableError(err, "failed to read platform type from infrastructures.config.openshift.io/%s", clusterConfigObjectName)
+	}
+
+	wi := &operatorv1alpha1.WorkloadIdentityConfig{
+		ServiceAccountToken: &operatorv1alpha1.ProjectedServiceAccountToken{
+			ExpirationSeconds: defaultWorkloadIdentityTokenExpirationSeconds,
+			MountPath:         defaultWorkloadIdentityTokenMountPath,
+		},
+	}
+	switch platform {
+	case "AWS":
+		roleARN, err := getRequiredEnvVars(platform, olmRoleARNEnvVar)
+		if err != nil {
+			return nil, err
+		}
+		region, _, _ := unstructured.NestedString(infrastructure.Object, "status", "platformStatus", "aws", "region")
+		wi.AWS = &operatorv1alpha1.AWSWorkloadIdentity{
+			RoleARN: roleARN[olmRoleARNEnvVar],
+			Region:  region,
+		}
+		wi.ServiceAccountToken.Audience = awsWorkloadIdentityAudience
+	case "Azure":
+		ids, err := getRequiredEnvVars(platform, olmClientIDEnvVar, olmTenantIDEnvVar)
+		if err != nil {
+			return nil, err
+		}
+		wi.Azure = &operatorv1alpha1.AzureWorkloadIdentity{
+			ClientID: ids[olmClientIDEnvVar],
+			TenantID: ids[olmTenantIDEnvVar],
+		}
+		wi.ServiceAccountToken.Audience = azureWorkloadIdentityAudience
+	case "GCP":
+		ids, err := getRequiredEnvVars(platform, olmProjectNumberEnvVar, olmPoolIDEnvVar, olmProviderIDEnvVar, olmServiceAccountEmailEnvVar)
+		if err != nil {
+			return nil, err
+		}
+		provider := fmt.Sprintf(gcpWorkloadIdentityProviderFmt, ids[olmProjectNumberEnvVar], ids[olmPoolIDEnvVar], ids[olmProviderIDEnvVar])
+		wi.GCP = &operatorv1alpha1.GCPWorkloadIdentity{
+			ServiceAccountEmail:      ids[olmServiceAccountEmailEnvVar],
+			WorkloadIdentityProvider: provider,
+		}
+		wi.ServiceAccountToken.Audience = provider
+	default:
+		return nil, common.NewIrrecoverableError(fmt.Errorf("platform %q is not supported", platform), "failed to derive workload identity configuration from cluster configuration")
+	}
+
+	return wi, nil
+}
+
+// getClusterConfigObject fetches the cluster scoped singleton config.openshift.io resource of the given kind.
+func (r *Reconciler) getClusterConfigObject(gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(gvk)
+	key := types.NamespacedName{Name: clusterConfigObjectName}
+	if err := r.UncachedClient.Get(r.ctx, key, object); err != nil {
+		return nil, common.FromClientError(err, "failed to fetch %s/%s", gvk.Kind, clusterConfigObjectName)
+	}
+	return object, nil
+}
+
+// getRequiredEnvVars returns the values of the environment variables, and an irrecoverable error when any of them is
+// not set, since the environment variables are set by OLM only when the operator is installed.
+func getRequiredEnvVars(platform string, names ...string) (map[string]string, error) {
+	values := make(map[string]string, len(names))
+	for _, name := range names {
+		value := os.Getenv(name)
+		if value == "" {
+			return nil, common.NewIrrecoverableError(fmt.Errorf("%s environment variable not set", name), "cloud credentials for %s token based authentication are not provided to the operator", platform)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// mergeWorkloadIdentityConfig merges the workload identity configuration derived from the cluster configuration with
+// the configuration provided by the user, with the user provided values taking precedence. When the user configured a
+// different cloud provider than derived, the user provided configuration is used as is.
+func mergeWorkloadIdentityConfig(derived, user *operatorv1alpha1.WorkloadIdentityConfig) *operatorv1alpha1.WorkloadIdentityConfig {
+	if derived == nil {
+		return user
+	}
+	if user == nil {
+		return derived.DeepCopy()
+	}
+	if (user.AWS != nil && derived.AWS == nil) || (user.Azure != nil && derived.Azure == nil) || (user.GCP != nil && derived.GCP == nil) {
+		return user
+	}
+
+	merged := derived.DeepCopy()
+	switch {
+	case user.AWS != nil:
+		if user.AWS.RoleARN != "" {
+			merged.AWS.RoleARN = user.AWS.RoleARN
+		}
+		if user.AWS.Region != "" {
+			merged.AWS.Region = user.AWS.Region
+		}
+	case user.Azure != nil:
+		if user.Azure.ClientID != "" {
+			merged.Azure.ClientID = user.Azure.ClientID
+		}
+		if user.Azure.TenantID != "" {
+			merged.Azure.TenantID = user.Azure.TenantID
+		}
+	case user.GCP != nil:
+		if user.GCP.ServiceAccountEmail != "" {
+			merged.GCP.ServiceAccountEmail = user.GCP.ServiceAccountEmail
+		}
+		if user.GCP.WorkloadIdentityProvider != "" {
+			merged.GCP.WorkloadIdentityProvider = user.GCP.WorkloadIdentityProvider
+		}
+	}
+	if user.ServiceAccountToken != nil {
+		merged.ServiceAccountToken = user.ServiceAccountToken.DeepCopy()
+	}
+	if len(user.ServiceAccountAnnotations) > 0 {
+		merged.ServiceAccountAnnotations = maps.Clone(user.ServiceAccountAnnotations)
+	}
+	if len(user.ServiceAccountLabels) > 0 {
+		merged.ServiceAccountLabels = maps.Clone(user.ServiceAccountLabels)
+	}
+
+	return merged
+}
+
+// getCloudIdentityMode returns the cloud identity mode for the workload identity configuration.
+func getCloudIdentityMode(wi *operatorv1alpha1.WorkloadIdentityConfig) operatorv1alpha1.CloudIdentityMode {
+	switch {
+	case wi == nil:
+		return operatorv1alpha1.CloudIdentityModeNone
+	case wi.AWS != nil:
+		return operatorv1alpha1.CloudIdentityModeAWS
+	case wi.Azure != nil:
+		return operatorv1alpha1.CloudIdentityModeAzure
+	case wi.GCP != nil:
+		return operatorv1alpha1.CloudIdentityModeGCP
+	}
+	return operatorv1alpha1.CloudIdentityModeNone
+}
+
+// updateCloudIdentityModeInStatus updates the cloud identity mode active for the core controller in the status.
+func (r *Reconciler) updateCloudIdentityModeInStatus(esc *operatorv1alpha1.ExternalSecretsConfig) error {
+	mode := getCloudIdentityMode(r.getWorkloadIdentityConfig(esc, operatorv1alpha1.CoreController))
+	if esc.Status.CloudIdentityMode != mode {
+		esc.Status.CloudIdentityMode = mode
+		return r.updateStatus(r.ctx, esc)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package external_secrets
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+// clusterConfigGetter returns a Get implementation populating the cluster Authentication and Infrastructure objects.
+func clusterConfigGetter(issuer string, platformStatus map[string]interface{}) func(context.Context, types.NamespacedName, client.Object) error {
+	return func(ctx context.Context, ns types.NamespacedName, obj client.Object) error {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return commontest.ErrTestClient
+		}
+		switch u.GroupVersionKind() {
+		case authenticationGVK:
+			u.Object["spec"] = map[string]interface{}{"serviceAccountIssuer": issuer}
+		case infrastructureGVK:
+			u.Object["status"] = map[string]interface{}{"platformStatus": platformStatus}
+		}
+		return nil
+	}
+}
+
+func TestReconcileClusterWorkloadIdentity(t *testing.T) {
+	tests := []struct {
+		name     string
+		preReq   func(*fakes.FakeCtrlClient)
+		env      map[string]string
+		disabled bool
+		want     *operatorv1alpha1.WorkloadIdentityConfig
+		wantErr  string
+	}{
+		{
+			name:     "cluster workload identity not enabled",
+			disabled: true,
+		},
+		{
+			name: "aws sts cluster",
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.GetCalls(clusterConfigGetter("https://oidc.example.com", map[string]interface{}{
+					"type": "AWS",
+					"aws":  map[string]interface{}{"region": "us-east-2"},
+				}))
+			},
+			env: map[string]string{olmRoleARNEnvVar: testRoleARN},
+			want: &operatorv1alpha1.WorkloadIdentityConfig{
+				AWS: &operatorv1alpha1.AWSWorkloadIdentity{RoleARN: testRoleARN, Region: "us-east-2"},
+				ServiceAccountToken: &operatorv1alpha1.ProjectedServiceAccountToken{
+					Audience:          awsWorkloadIdentityAudience,
+					ExpirationSeconds: defaultWorkloadIdentityTokenExpirationSeconds,
+					MountPath:         defaultWorkloadIdentityTokenMountPath,
+				},
+			},
+		},
+		{
+			name: "azure workload identity cluster",
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.GetCalls(clusterConfigGetter("https://oidc.example.com", map[string]interface{}{"type": "Azure"}))
+			},
+			env: map[string]string{olmClientIDEnvVar: testAzureClientID, olmTenantIDEnvVar: testAzureTenantID},
+			want: &operatorv1alpha1.WorkloadIdentityConfig{
+				Azure: &operatorv1alpha1.AzureWorkloadIdentity{ClientID: testAzureClientID, TenantID: testAzureTenantID},
+				ServiceAccountToken: &operatorv1alpha1.ProjectedServiceAccountToken{
+					Audience:          azureWorkloadIdentityAudience,
+					ExpirationSeconds: defaultWorkloadIdentityTokenExpirationSeconds,
+					MountPath:         defaultWorkloadIdentityTokenMountPath,
+				},
+			},
+		},
+		{
+			name: "gcp workload identity cluster",
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.GetCalls(clusterConfigGetter("https://oidc.example.com", map[string]interface{}{"type": "GCP"}))
+			},
+			env: map[string]string{
+				olmProjectNumberEnvVar:       "123456",
+				olmPoolIDEnvVar:              "pool",
+				olmProviderIDEnvVar:          "provider",
+				olmServiceAccountEmailEnvVar: testGCPServiceAccount,
+			},
+			want: &operatorv1alpha1.WorkloadIdentityConfig{
+				GCP: &operatorv1alpha1.GCPWorkloadIdentity{
+					ServiceAccountEmail:      testGCPServiceAccount,
+					WorkloadIdentityProvider: testGCPIdentityProvider,
+				},
+				ServiceAccountToken: &operatorv1alpha1.ProjectedServiceAccountToken{
+					Audience:          testGCPIdentityProvider,
+					ExpirationSeconds: defaultWorkloadIdentityTokenExpirationSeconds,
+					MountPath:         defaultWorkloadIdentityTokenMountPath,
+				},
+			},
+		},
+		{
+			name: "cluster without serviceaccount issuer",
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.GetCalls(clusterConfigGetter("", map[string]interface{}{"type": "AWS"}))
+			},
+			env:     map[string]string{olmRoleARNEnvVar: testRoleARN},
+			wantErr: "cluster is not configured for short-term credentials, spec.controllerConfig.clusterWorkloadIdentity cannot be enabled: serviceAccountIssuer is not configured",
+		},
+		{
+			name: "cloud credentials not provided by OLM",
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.GetCalls(clusterConfigGetter("https://oidc.example.com", map[string]interface{}{"type": "Azure"}))
+			},
+			env:     map[string]string{olmClientIDEnvVar: testAzureClientID},
+			wantErr: "cloud credentials for Azure token based authentication are not provided to the operator: TENANTID environment variable not set",
+		},
+		{
+			name: "unsupported platform",
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.GetCalls(clusterConfigGetter("https://oidc.example.com", map[string]interface{}{"type": "BareMetal"}))
+			},
+			wantErr: `failed to derive workload identity configuration from cluster configuration: platform "BareMetal" is not supported`,
+		},
+		{
+			name: "fetching cluster authentication fails",
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.GetReturns(commontest.ErrTestClient)
+			},
+			wantErr: "failed to fetch Authentication/cluster: test client error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{olmRoleARNEnvVar, olmClientIDEnvVar, olmTenantIDEnvVar, olmPoolIDEnvVar,
+				olmProviderIDEnvVar, olmServiceAccountEmailEnvVar, olmProjectNumberEnvVar} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			r := testReconciler(t)
+			mock := &fakes.FakeCtrlClient{}
+			r.UncachedClient = mock
+			if tt.preReq != nil {
+				tt.preReq(mock)
+			}
+
+			esc := commontest.TestExternalSecretsConfig()
+			if !tt.disabled {
+				esc.Spec.ControllerConfig.ClusterWorkloadIdentity = &operatorv1alpha1.ClusterWorkloadIdentityConfig{
+					Mode: operatorv1alpha1.Enabled,
+				}
+			}
+
+			err := r.reconcileClusterWorkloadIdentity(esc)
+			if (tt.wantErr != "" || err != nil) && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("Expected error: %v, got: %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(r.clusterWorkloadIdentity, tt.want) {
+				t.Errorf("clusterWorkloadIdentity = %+v, want %+v", r.clusterWorkloadIdentity, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeWorkloadIdentityConfig(t *testing.T) {
+	derived := &operatorv1alpha1.WorkloadIdentityConfig{
+		AWS: &operatorv1alpha1.AWSWorkloadIdentity{RoleARN: testRoleARN, Region: "us-east-2"},
+		ServiceAccountToken: &operatorv1alpha1.ProjectedServiceAccountToken{
+			Audience:          awsWorkloadIdentityAudience,
+			ExpirationSeconds: defaultWorkloadIdentityTokenExpirationSeconds,
+			MountPath:         defaultWorkloadIdentityTokenMountPath,
+		},
+	}
+	userRoleARN := "arn:aws:iam::123456789012:role/custom"
+
+	tests := []struct {
+		name    string
+		derived *operatorv1alpha1.WorkloadIdentityConfig
+		user    *operatorv1alpha1.WorkloadIdentityConfig
+		want    *operatorv1alpha1.WorkloadIdentityConfig
+	}{
+		{
+			name: "nothing configured",
+		},
+		{
+			name: "only user configured",
+			user: &operatorv1alpha1.WorkloadIdentityConfig{Azure: &operatorv1alpha1.AzureWorkloadIdentity{ClientID: testAzureClientID}},
+			want: &operatorv1alpha1.WorkloadIdentityConfig{Azure: &operatorv1alpha1.AzureWorkloadIdentity{ClientID: testAzureClientID}},
+		},
+		{
+			name:    "only derived configured",
+			derived: derived,
+			want:    derived,
+		},
+		{
+			name:    "user values take precedence over derived",
+			derived: derived,
+			user: &operatorv1alpha1.WorkloadIdentityConfig{
+				AWS:                       &operatorv1alpha1.AWSWorkloadIdentity{RoleARN: userRoleARN},
+				ServiceAccountAnnotations: map[string]string{"custom/annotation": "value"},
+			},
+			want: &operatorv1alpha1.WorkloadIdentityConfig{
+				AWS:                       &operatorv1alpha1.AWSWorkloadIdentity{RoleARN: userRoleARN, Region: "us-east-2"},
+				ServiceAccountAnnotations: map[string]string{"custom/annotation": "value"},
+				ServiceAccountToken:       derived.ServiceAccountToken,
+			},
+		},
+		{
+			name:    "user configured different cloud provider",
+			derived: derived,
+			user: &operatorv1alpha1.WorkloadIdentityConfig{
+				GCP: &operatorv1alpha1.GCPWorkloadIdentity{ServiceAccountEmail: testGCPServiceAccount},
+			},
+			want: &operatorv1alpha1.WorkloadIdentityConfig{
+				GCP: &operatorv1alpha1.GCPWorkloadIdentity{ServiceAccountEmail: testGCPServiceAccount},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeWorkloadIdentityConfig(tt.derived, tt.user)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeWorkloadIdentityConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
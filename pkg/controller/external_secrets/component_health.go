@@ -0,0 +1,180 @@
+package external_secrets
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+)
+
+// component is an operand component, whose availability is reported with a condition.
+type component struct {
+	// conditionType is the type of the condition reporting the availability of the component.
+	conditionType string
+	// deploymentName is the name of the deployment of the component.
+	deploymentName string
+	// serviceName is the name of the service, which must have ready endpoints for the component to be
+	// available. Empty when the component does not serve any requests.
+	serviceName string
+	// enabled is whether the component is deployed for the current configuration.
+	enabled bool
+}
+
+// getComponents returns the operand components, with the ones not deployed for the current configuration
+// marked as disabled.
+func getComponents(esc *operatorv1alpha1.ExternalSecretsConfig) []component {
+	return []component{
+		{
+			conditionType:  operatorv1alpha1.CoreControllerAvailable,
+			deploymentName: controllerDeploymentName,
+			enabled:        true,
+		},
+		{
+			conditionType:  operatorv1alpha1.WebhookAvailable,
+			deploymentName: webhookDeploymentName,
+			serviceName:    webhookServiceName,
+			enabled:        true,
+		},
+		{
+			conditionType:  operatorv1alpha1.CertControllerAvailable,
+			deploymentName: certControllerDeploymentName,
+			enabled:        !isCertManagerConfigEnabled(esc),
+		},
+		{
+			conditionType:  operatorv1alpha1.BitwardenSDKServerAvailable,
+			deploymentName: bitwardenDeploymentName,
+			serviceName:    bitwardenServiceName,
+			enabled:        isBitwardenConfigEnabled(esc),
+		},
+	}
+}
+
+// checkComponentsAvailable updates the availability conditions of the operand components and the Progressing
+// condition, and records the enabled components not yet available in unavailableComponents. The conditions are
+// written with the final status update of the reconciliation, an error is returned only when the resources could
+// not be fetched.
+func (r *Reconciler) checkComponentsAvailable(esc *operatorv1alpha1.ExternalSecretsConfig) error {
+	var unavailable, progressing []string
+
+	for _, c := range getComponents(esc) {
+		if !c.enabled {
+			apimeta.RemoveStatusCondition(&esc.Status.Conditions, c.conditionType)
+			continue
+		}
+
+		cond, rolledOut, err := r.getComponentCondition(esc, c)
+		if err != nil {
+			return err
+		}
+		if cond.Status != metav1.ConditionTrue {
+			unavailable = append(unavailable, c.deploymentName)
+		}
+		if !rolledOut {
+			progressing = append(progressing, c.deploymentName)
+		}
+		apimeta.SetStatusCondition(&esc.Status.Conditions, cond)
+	}
+
+	progressingCond := metav1.Condition{
+		Type:               operatorv1alpha1.Progressing,
+		Status:             metav1.ConditionFalse,
+		Reason:             operatorv1alpha1.ReasonCompleted,
+		Message:            "rollout of all the deployments is complete",
+		ObservedGeneration: esc.GetGeneration(),
+	}
+	if len(progressing) > 0 {
+		progressingCond.Status = metav1.ConditionTrue
+		progressingCond.Reason = operatorv1alpha1.ReasonInProgress
+		progressingCond.Message = fmt.Sprintf("rollout in progress for deployments %s", strings.Join(progressing, ", "))
+	}
+	apimeta.SetStatusCondition(&esc.Status.Conditions, progressingCond)
+
+	r.unavailableComponents = unavailable
+	return nil
+}
+
+// unavailableComponentsMessage returns the message reporting the components recorded as not yet available.
+func (r *Reconciler) unavailableComponentsMessage() string {
+	return fmt.Sprintf("external-secrets components are not yet available: deployments %s are not available", strings.Join(r.unavailableComponents, ", "))
+}
+
+// getComponentCondition returns the availability condition of the component derived from the deployment status
+// and the endpoints of the service, along with whether the rollout of the deployment is complete.
+func (r *Reconciler) getComponentCondition(esc *operatorv1alpha1.ExternalSecretsConfig, c component) (metav1.Condition, bool, error) {
+	cond := metav1.Condition{
+		Type:               c.conditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             operatorv1alpha1.ReasonInProgress,
+		ObservedGeneration: esc.GetGeneration(),
+	}
+
+	key := types.NamespacedName{Name: c.deploymentName, Namespace: getNamespace(esc)}
+	deployment := &appsv1.Deployment{}
+	exist, err := r.Exists(r.ctx, key, deployment)
+	if err != nil {
+		return cond, false, common.FromClientError(err, "failed to fetch %s deployment", key)
+	}
+	if !exist {
+		cond.Message = fmt.Sprintf("deployment %s not found", key)
+		return cond, false, nil
+	}
+
+	rolledOut := isDeploymentRolledOut(deployment)
+	if !isDeploymentAvailable(deployment) || deployment.Status.AvailableReplicas == 0 {
+		cond.Message = fmt.Sprintf("deployment %s has %d of %d replicas available", key,
+			deployment.Status.AvailableReplicas, ptr.Deref(deployment.Spec.Replicas, 1))
+		return cond, rolledOut, nil
+	}
+
+	if c.serviceName != "" {
+		ready, err := r.hasReadyEndpoints(getNamespace(esc), c.serviceName)
+		if err != nil {
+			return cond, rolledOut, err
+		}
+		if !ready {
+			cond.Message = fmt.Sprintf("service %s/%s has no ready endpoints", getNamespace(esc), c.serviceName)
+			return cond, rolledOut, nil
+		}
+	}
+
+	cond.Status = metav1.ConditionTrue
+	cond.Reason = operatorv1alpha1.ReasonReady
+	cond.Message = fmt.Sprintf("deployment %s is available", key)
+	return cond, rolledOut, nil
+}
+
+// hasReadyEndpoints returns whether any of the EndpointSlices of the service has a ready endpoint.
+func (r *Reconciler) hasReadyEndpoints(namespace, serviceName string) (bool, error) {
+	list := &discoveryv1.EndpointSliceList{}
+	if err := r.List(r.ctx, list, client.InNamespace(namespace), client.MatchingLabels{discoveryv1.LabelServiceName: serviceName}); err != nil {
+		return false, common.FromClientError(err, "failed to list endpointslices of %s/%s service", namespace, serviceName)
+	}
+	for _, slice := range list.Items {
+		for _, endpoint := range slice.Endpoints {
+			// nil ready condition must be interpreted as ready.
+			if ptr.Deref(endpoint.Conditions.Ready, true) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// isDeploymentRolledOut returns whether the latest spec of the deployment is observed and all the replicas
+// are updated and available, with no replicas of the older revisions remaining.
+func isDeploymentRolledOut(deployment *appsv1.Deployment) bool {
+	replicas := ptr.Deref(deployment.Spec.Replicas, 1)
+	return deployment.Status.ObservedGeneration >= deployment.GetGeneration() &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.Replicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas
+}
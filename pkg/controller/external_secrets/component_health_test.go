@@ -0,0 +1,199 @@
+package external_secrets
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+// testDeploymentStatus updates the deployment with a status of the given number of available replicas out
+// of the one desired replica.
+func testDeploymentStatus(deployment *appsv1.Deployment, availableReplicas int32) {
+	deployment.Spec.Replicas = ptr.To(int32(1))
+	deployment.Status = appsv1.DeploymentStatus{
+		Replicas:          1,
+		UpdatedReplicas:   1,
+		ReadyReplicas:     availableReplicas,
+		AvailableReplicas: availableReplicas,
+	}
+	status := corev1.ConditionFalse
+	if availableReplicas > 0 {
+		status = corev1.ConditionTrue
+	}
+	deployment.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: status}}
+}
+
+func TestCheckComponentsAvailable(t *testing.T) {
+	tests := []struct {
+		name string
+		esc  func(*operatorv1alpha1.ExternalSecretsConfig)
+		// deployments is the number of available replicas of the existing deployments.
+		deployments map[string]int32
+		// rollingOut is the name of the deployment with the rollout in progress.
+		rollingOut      string
+		readyEndpoints  bool
+		wantConditions  map[string]metav1.ConditionStatus
+		wantUnavailable []string
+	}{
+		{
+			name: "all components available",
+			deployments: map[string]int32{
+				controllerDeploymentName:     1,
+				webhookDeploymentName:        1,
+				certControllerDeploymentName: 1,
+			},
+			readyEndpoints: true,
+			wantConditions: map[string]metav1.ConditionStatus{
+				operatorv1alpha1.CoreControllerAvailable: metav1.ConditionTrue,
+				operatorv1alpha1.WebhookAvailable:        metav1.ConditionTrue,
+				operatorv1alpha1.CertControllerAvailable: metav1.ConditionTrue,
+				operatorv1alpha1.Progressing:             metav1.ConditionFalse,
+			},
+		},
+		{
+			name: "webhook service without ready endpoints",
+			deployments: map[string]int32{
+				controllerDeploymentName:     1,
+				webhookDeploymentName:        1,
+				certControllerDeploymentName: 1,
+			},
+			wantConditions: map[string]metav1.ConditionStatus{
+				operatorv1alpha1.CoreControllerAvailable: metav1.ConditionTrue,
+				operatorv1alpha1.WebhookAvailable:        metav1.ConditionFalse,
+				operatorv1alpha1.CertControllerAvailable: metav1.ConditionTrue,
+				operatorv1alpha1.Progressing:             metav1.ConditionFalse,
+			},
+			wantUnavailable: []string{webhookDeploymentName},
+		},
+		{
+			name: "deployments not created yet",
+			wantConditions: map[string]metav1.ConditionStatus{
+				operatorv1alpha1.CoreControllerAvailable: metav1.ConditionFalse,
+				operatorv1alpha1.WebhookAvailable:        metav1.ConditionFalse,
+				operatorv1alpha1.CertControllerAvailable: metav1.ConditionFalse,
+				operatorv1alpha1.Progressing:             metav1.ConditionTrue,
+			},
+			wantUnavailable: []string{controllerDeploymentName, webhookDeploymentName, certControllerDeploymentName},
+		},
+		{
+			name: "core controller rollout in progress",
+			deployments: map[string]int32{
+				controllerDeploymentName:     1,
+				webhookDeploymentName:        1,
+				certControllerDeploymentName: 1,
+			},
+			rollingOut:     controllerDeploymentName,
+			readyEndpoints: true,
+			wantConditions: map[string]metav1.ConditionStatus{
+				operatorv1alpha1.CoreControllerAvailable: metav1.ConditionTrue,
+				operatorv1alpha1.WebhookAvailable:        metav1.ConditionTrue,
+				operatorv1alpha1.CertControllerAvailable: metav1.ConditionTrue,
+				operatorv1alpha1.Progressing:             metav1.ConditionTrue,
+			},
+		},
+		{
+			name: "bitwarden-sdk-server not available and cert-controller not deployed",
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ControllerConfig.CertProvider = &operatorv1alpha1.CertProvidersConfig{
+					CertManager: &operatorv1alpha1.CertManagerConfig{
+						Mode: operatorv1alpha1.Enabled,
+					},
+				}
+				esc.Spec.Plugins.BitwardenSecretManagerProvider = &operatorv1alpha1.BitwardenSecretManagerProvider{
+					Mode: operatorv1alpha1.Enabled,
+				}
+				esc.Status.Conditions = []metav1.Condition{
+					{Type: operatorv1alpha1.CertControllerAvailable, Status: metav1.ConditionTrue, Reason: operatorv1alpha1.ReasonReady},
+				}
+			},
+			deployments: map[string]int32{
+				controllerDeploymentName: 1,
+				webhookDeploymentName:    1,
+				bitwardenDeploymentName:  0,
+			},
+			readyEndpoints: true,
+			wantConditions: map[string]metav1.ConditionStatus{
+				operatorv1alpha1.CoreControllerAvailable:     metav1.ConditionTrue,
+				operatorv1alpha1.WebhookAvailable:            metav1.ConditionTrue,
+				operatorv1alpha1.BitwardenSDKServerAvailable: metav1.ConditionFalse,
+				operatorv1alpha1.Progressing:                 metav1.ConditionTrue,
+			},
+			wantUnavailable: []string{bitwardenDeploymentName},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			mock := &fakes.FakeCtrlClient{}
+			r.CtrlClient = mock
+			mock.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+				deployment, ok := obj.(*appsv1.Deployment)
+				if !ok {
+					return false, nil
+				}
+				available, exist := tt.deployments[ns.Name]
+				if !exist {
+					return false, nil
+				}
+				testDeploymentStatus(deployment, available)
+				if ns.Name == tt.rollingOut {
+					deployment.Status.Replicas = 2
+				}
+				return true, nil
+			})
+			mock.ListCalls(func(ctx context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				if l, ok := list.(*discoveryv1.EndpointSliceList); ok && tt.readyEndpoints {
+					l.Items = []discoveryv1.EndpointSlice{
+						{Endpoints: []discoveryv1.Endpoint{{Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)}}}},
+					}
+				}
+				return nil
+			})
+
+			esc := commontest.TestExternalSecretsConfig()
+			if tt.esc != nil {
+				tt.esc(esc)
+			}
+
+			if err := r.checkComponentsAvailable(esc); err != nil {
+				t.Fatalf("checkComponentsAvailable() unexpected error: %v", err)
+			}
+			if !slices.Equal(r.unavailableComponents, tt.wantUnavailable) {
+				t.Errorf("unavailableComponents = %v, want %v", r.unavailableComponents, tt.wantUnavailable)
+			}
+
+			for _, c := range getComponents(esc) {
+				if _, ok := tt.wantConditions[c.conditionType]; !ok && apimeta.FindStatusCondition(esc.Status.Conditions, c.conditionType) != nil {
+					t.Errorf("unexpected condition %s for disabled component", c.conditionType)
+				}
+			}
+			for condType, status := range tt.wantConditions {
+				cond := apimeta.FindStatusCondition(esc.Status.Conditions, condType)
+				if cond == nil {
+					t.Errorf("expected condition %s, not found", condType)
+					continue
+				}
+				if cond.Status != status {
+					t.Errorf("condition %s status = %s, want %s: %s", condType, cond.Status, status, cond.Message)
+				}
+			}
+			if mock.StatusUpdateCallCount() != 0 {
+				t.Errorf("StatusUpdate called %d times, want the conditions written with the final status update", mock.StatusUpdateCallCount())
+			}
+		})
+	}
+}
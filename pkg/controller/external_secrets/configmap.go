@@ -55,7 +55,7 @@ func (r *Reconciler) ensureTrustedCABundleConfigMap(esc *operatorv1alpha1.Extern
 
 	if !exist {
 		// Create the ConfigMap
-		if err := r.Create(r.ctx, desiredConfigMap); err != nil {
+		if err := r.Apply(r.ctx, desiredConfigMap); err != nil {
 			return common.FromClientError(err, "failed to create %s trusted CA bundle ConfigMap resource", configMapName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "trusted CA bundle ConfigMap resource %s created", configMapName)
@@ -63,15 +63,11 @@ func (r *Reconciler) ensureTrustedCABundleConfigMap(esc *operatorv1alpha1.Extern
 	}
 
 	// ConfigMap exists, ensure it has the correct labels and annotations.
-	// Do not update the data of the ConfigMap since it is managed by CNO.
-	// MergeFetchedAnnotations preserves external annotations (e.g., CNO's openshift.io/owning-component).
-	if exist && common.ObjectMetadataModified(desiredConfigMap, existingConfigMap, &resourceMetadata) {
+	// The data of the ConfigMap is managed by CNO, and is not applied.
+	if exist && r.hasObjectDrifted(desiredConfigMap, existingConfigMap) {
 		r.log.V(1).Info("trusted CA bundle ConfigMap has been modified, updating to desired state", "name", configMapName)
-		// Preserve data from existing ConfigMap (managed by CNO)
-		desiredConfigMap.Data = existingConfigMap.Data
-		desiredConfigMap.BinaryData = existingConfigMap.BinaryData
 		common.RemoveObsoleteAnnotations(desiredConfigMap, resourceMetadata)
-		if err := r.UpdateWithRetry(r.ctx, desiredConfigMap); err != nil {
+		if err := r.Apply(r.ctx, desiredConfigMap); err != nil {
 			return common.FromClientError(err, "failed to update %s trusted CA bundle ConfigMap resource", configMapName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "trusted CA bundle ConfigMap resource %s reconciled back to desired state", configMapName)
@@ -3,9 +3,13 @@ package external_secrets
 import (
 	"fmt"
 	"os"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	certmanagerapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+
 	"github.com/openshift/external-secrets-operator/pkg/controller/common"
 )
 
@@ -23,12 +27,160 @@ const (
 	// successful reconciliation by the controller.
 	controllerProcessedAnnotation = "operator.openshift.io/external-secrets-processed"
 
+	// unmanagedResourceAnnotation is the annotation set by the users on a resource created by the controller, for
+	// the controller to not correct the drift of the resource from the desired state.
+	unmanagedResourceAnnotation = "operator.openshift.io/external-secrets-unmanaged"
+
 	// certificateCRDGroupVersion is the group and version of the Certificate CRD provided by cert-manager project.
 	certificateCRDGroupVersion = "cert-manager.io/v1"
 
 	// certificateCRDName is the name of the Certificate CRD provided by cert-manager project.
 	certificateCRDName = "certificates"
 
+	// credentialsRequestCRDGroupVersion is the group and version of the CredentialsRequest CRD provided by the
+	// Cloud Credential Operator.
+	credentialsRequestCRDGroupVersion = "cloudcredential.openshift.io/v1"
+
+	// credentialsRequestCRDName is the name of the CredentialsRequest CRD provided by the Cloud Credential Operator.
+	credentialsRequestCRDName = "credentialsrequests"
+
+	// monitoringCRDGroupVersion is the group and version of the ServiceMonitor and PrometheusRule CRDs provided by
+	// the Prometheus Operator.
+	monitoringCRDGroupVersion = "monitoring.coreos.com/v1"
+
+	// serviceMonitorCRDName is the name of the ServiceMonitor CRD provided by the Prometheus Operator.
+	serviceMonitorCRDName = "servicemonitors"
+
+	// prometheusRuleCRDName is the name of the PrometheusRule CRD provided by the Prometheus Operator.
+	prometheusRuleCRDName = "prometheusrules"
+
+	// prometheusRuleName is the name of the PrometheusRule created with the alerts for the external-secrets operand.
+	prometheusRuleName = externalsecretsCommonName
+
+	// operatorPrometheusRuleName is the name of the PrometheusRule created in the operator namespace with the alerts
+	// based on the operator metrics.
+	operatorPrometheusRuleName = "external-secrets-operator"
+
+	// componentMetricsPort is the port the metrics of the external-secrets controller, webhook and cert-controller
+	// are served on.
+	componentMetricsPort = 8080
+
+	// platformMonitoringNamespace is the namespace of the OpenShift platform monitoring stack.
+	platformMonitoringNamespace = "openshift-monitoring"
+
+	// defaultScrapeInterval is the interval at which the metrics of the external-secrets components are scraped,
+	// when not configured.
+	defaultScrapeInterval = "30s"
+
+	// defaultWebhookLatencyThresholdMilliseconds is the webhook latency above which the alert fires, when not configured.
+	defaultWebhookLatencyThresholdMilliseconds = 1000
+
+	// defaultCertificateExpiryThresholdDays is the remaining validity of the webhook serving certificate below which
+	// the alert fires, when not configured.
+	defaultCertificateExpiryThresholdDays = 7
+
+	// podSecurityEnforceLabel, podSecurityAuditLabel and podSecurityWarnLabel are the namespace labels for the
+	// Pod Security Admission levels.
+	podSecurityEnforceLabel = "pod-security.kubernetes.io/enforce"
+	podSecurityAuditLabel   = "pod-security.kubernetes.io/audit"
+	podSecurityWarnLabel    = "pod-security.kubernetes.io/warn"
+
+	// podSecurityLabelSyncLabel is the namespace label for disabling the synchronization of the Pod Security
+	// Admission labels by OpenShift.
+	podSecurityLabelSyncLabel = "security.openshift.io/scc.podSecurityLabelSync"
+
+	// clusterMonitoringLabel is the namespace label for including the namespace in the OpenShift cluster monitoring.
+	clusterMonitoringLabel = "openshift.io/cluster-monitoring"
+
+	// cloudCredentialOperatorNamespace is the namespace where the CredentialsRequest resources are created for the
+	// Cloud Credential Operator to process.
+	cloudCredentialOperatorNamespace = "openshift-cloud-credential-operator"
+
+	// bootstrapResourceLabelKey is the label added to the external-secrets resources created by the operator from
+	// the bootstrap configuration, for identifying the ones to be removed when no longer configured.
+	bootstrapResourceLabelKey = "externalsecretsconfig.operator.openshift.io/bootstrap"
+
+	// webhookDeploymentName is the name of the external-secrets webhook deployment.
+	webhookDeploymentName = externalsecretsCommonName + "-webhook"
+
+	// controllerDeploymentName is the name of the external-secrets core controller deployment.
+	controllerDeploymentName = externalsecretsCommonName
+
+	// certControllerDeploymentName is the name of the external-secrets cert-controller deployment.
+	certControllerDeploymentName = externalsecretsCommonName + "-cert-controller"
+
+	// bitwardenDeploymentName is the name of the bitwarden-sdk-server deployment.
+	bitwardenDeploymentName = "bitwarden-sdk-server"
+
+	// webhookServiceName is the name of the external-secrets webhook service.
+	webhookServiceName = externalsecretsCommonName + "-webhook"
+
+	// controllerMetricsServiceName is the name of the external-secrets core controller metrics service.
+	controllerMetricsServiceName = externalsecretsCommonName + "-metrics"
+
+	// bitwardenServiceName is the name of the bitwarden-sdk-server service.
+	bitwardenServiceName = "bitwarden-sdk-server"
+
+	// webhookTLSSecretName is the name of the secret with the webhook serving certificate created by the
+	// cert-controller, when cert-manager is not configured.
+	webhookTLSSecretName = externalsecretsCommonName + "-webhook"
+
+	// webhookServiceHostFmt is the format of the webhook service host name used for verifying the TLS handshake,
+	// with the operand namespace to be substituted. The host name is as configured in the webhook certificate.
+	webhookServiceHostFmt = "external-secrets-webhook.%s.svc"
+
+	// webhookServicePort is the port of the webhook service serving the admission requests.
+	webhookServicePort = "443"
+
+	// webhookCertExpiryThreshold is the remaining validity of the webhook serving certificate below which the
+	// webhook is reported unhealthy, as the certificate was expected to be renewed by then.
+	webhookCertExpiryThreshold = 7 * 24 * time.Hour
+
+	// webhookHealthCheckInterval is the interval at which the webhook health is verified.
+	webhookHealthCheckInterval = 5 * time.Minute
+
+	// planRefreshInterval is the interval at which the plan is recomputed when the managementState is Plan, for
+	// reporting the changes made to the resources not watched by the controller.
+	planRefreshInterval = 5 * time.Minute
+
+	// upgradeCheckInterval is the interval at which the external-secrets resources are scanned for the deprecated
+	// API versions and fields blocking the upgrade of the operator.
+	upgradeCheckInterval = 30 * time.Minute
+
+	// maxUpgradeBlockers is the maximum number of the resources blocking the upgrade listed in the status.
+	maxUpgradeBlockers = 50
+
+	// storageMigrationQPS is the rate limit of the updates made in the storage version migration of the
+	// external-secrets CRDs.
+	storageMigrationQPS = 20
+
+	// storageMigrationBatchSize is the maximum number of the objects rewritten in a reconciliation in the storage
+	// version migration of the external-secrets CRDs, which is the burst of the rate limiter of the updates. A batch
+	// is limited to the updates allowed by the rate limiter, for the reconciliation not to wait for the limiter.
+	storageMigrationBatchSize = 20
+
+	// storageMigrationRequeueInterval is the interval at which the next batch of the objects is migrated, when the
+	// storage version migration is in progress, for the rate limiter to allow a full batch.
+	storageMigrationRequeueInterval = time.Second * storageMigrationBatchSize / storageMigrationQPS
+
+	// bitwardenTLSSecretName is the name of the secret created by cert-manager with the bitwarden-sdk-server
+	// TLS key pair, when secretRef is not configured in the bitwarden plugin config.
+	bitwardenTLSSecretName = "bitwarden-tls-certs"
+
+	// bitwardenCACertKey is the key name of the CA certificate in the bitwarden-sdk-server TLS secret.
+	bitwardenCACertKey = "ca.crt"
+
+	// bitwardenServiceHostFmt is the format of the bitwarden-sdk-server service host name, with the operand namespace
+	// to be substituted. The host name is as configured in the DNS names of the bitwarden-sdk-server certificate.
+	bitwardenServiceHostFmt = "bitwarden-sdk-server.%s.svc.cluster.local"
+
+	// bitwardenSDKServerURLFmt is the format of the bitwarden-sdk-server service URL, with the operand namespace
+	// to be substituted.
+	bitwardenSDKServerURLFmt = "https://" + bitwardenServiceHostFmt + ":9998"
+
+	// credentialsRequestNamePrefix is the prefix added to the name of the CredentialsRequest resources created.
+	credentialsRequestNamePrefix = externalsecretsCommonName + "-"
+
 	// externalsecretsImageVersionEnvVarName is the environment variable key name
 	// containing the image version of the external-secrets operand as value.
 	externalsecretsImageVersionEnvVarName = "OPERAND_EXTERNAL_SECRETS_IMAGE_VERSION"
@@ -45,6 +197,17 @@ const (
 	// containing the image version of the bitwarden-sdk-server as value.
 	bitwardenImageVersionEnvVarName = "BITWARDEN_SDK_SERVER_IMAGE_VERSION"
 
+	// versionLabelKey is the label key with the version of the external-secrets component as value.
+	versionLabelKey = "app.kubernetes.io/version"
+
+	// operatorConditionNameEnvVarName is the environment variable key name containing the name of the
+	// OperatorCondition created by OLM for the operator as value.
+	operatorConditionNameEnvVarName = "OPERATOR_CONDITION_NAME"
+
+	// operatorNamespaceEnvVarName is the environment variable key name containing the namespace of the operator
+	// as value.
+	operatorNamespaceEnvVarName = "OPERATOR_NAMESPACE"
+
 	// externalsecretsDefaultNamespace is the namespace where the `external-secrets` operand required resources
 	// will be created, when ExternalSecretsConfig.Spec.Namespace is not set.
 	externalsecretsDefaultNamespace = "external-secrets"
@@ -81,11 +244,104 @@ const (
 	webhookContainerName        = "webhook"
 	certControllerContainerName = "cert-controller"
 	bitwardenContainerName      = "bitwarden-sdk-server"
+
+	// workloadIdentityManagedAnnotationsKey is the annotation key used to track the workload identity annotation
+	// keys set on a ServiceAccount. The value is a base64-encoded JSON array of annotation keys.
+	workloadIdentityManagedAnnotationsKey = "externalsecretsconfig.operator.openshift.io/workload-identity-annotations"
+
+	// Workload identity annotations read by the cloud provider specific identity webhooks and token exchange.
+	awsRoleARNAnnotation        = "eks.amazonaws.com/role-arn"
+	azureClientIDAnnotation     = "azure.workload.identity/client-id"
+	azureTenantIDAnnotation     = "azure.workload.identity/tenant-id"
+	gcpServiceAccountAnnotation = "iam.gke.io/gcp-service-account"
+
+	// azureWorkloadIdentityUseLabel is the pod label for requesting the Azure workload identity webhook to inject
+	// the projected token and the environment variables.
+	azureWorkloadIdentityUseLabel = "azure.workload.identity/use"
+
+	// Workload identity environment variable names read by the cloud provider SDKs.
+	awsRoleARNEnvVar                = "AWS_ROLE_ARN"
+	awsWebIdentityTokenFileEnvVar   = "AWS_WEB_IDENTITY_TOKEN_FILE"
+	awsRegionEnvVar                 = "AWS_REGION"
+	azureClientIDEnvVar             = "AZURE_CLIENT_ID"
+	azureTenantIDEnvVar             = "AZURE_TENANT_ID"
+	azureFederatedTokenFileEnvVar   = "AZURE_FEDERATED_TOKEN_FILE"
+	gcpApplicationCredentialsEnvVar = "GOOGLE_APPLICATION_CREDENTIALS"
+
+	// workloadIdentityTokenVolumeName is the name of the projected volume holding the ServiceAccount token.
+	workloadIdentityTokenVolumeName = "bound-sa-token"
+
+	// workloadIdentityTokenFileName is the name of the file holding the projected ServiceAccount token.
+	workloadIdentityTokenFileName = "token"
+
+	// defaultWorkloadIdentityTokenMountPath is the directory where the projected ServiceAccount token is mounted,
+	// when not configured.
+	defaultWorkloadIdentityTokenMountPath = "/var/run/secrets/openshift/serviceaccount"
+
+	// defaultWorkloadIdentityTokenExpirationSeconds is the validity duration of the projected ServiceAccount token,
+	// when not configured.
+	defaultWorkloadIdentityTokenExpirationSeconds int64 = 3600
+
+	// gcpCredentialConfigFileName is the name of the file holding the Google Cloud external account credential configuration.
+	gcpCredentialConfigFileName = "credential-configuration.json"
+
+	// clusterConfigObjectName is the name of the cluster scoped singleton config.openshift.io resources.
+	clusterConfigObjectName = "cluster"
+
+	// Environment variables set by OLM on the operator, when installed on a cluster with short-term credentials
+	// and the cloud credentials are provided for token based authentication.
+	olmRoleARNEnvVar             = "ROLEARN"
+	olmClientIDEnvVar            = "CLIENTID"
+	olmTenantIDEnvVar            = "TENANTID"
+	olmPoolIDEnvVar              = "POOL_ID"
+	olmProviderIDEnvVar          = "PROVIDER_ID"
+	olmServiceAccountEmailEnvVar = "SERVICE_ACCOUNT_EMAIL"
+	olmProjectNumberEnvVar       = "PROJECT_NUMBER"
+
+	// Audiences of the projected ServiceAccount token expected by the cloud provider token exchange services.
+	awsWorkloadIdentityAudience   = "sts.amazonaws.com"
+	azureWorkloadIdentityAudience = "api://AzureADTokenExchange"
+
+	// gcpWorkloadIdentityProviderFmt is the format of the full resource name of the Google Cloud workload identity pool provider.
+	gcpWorkloadIdentityProviderFmt = "//iam.googleapis.com/projects/%s/locations/global/workloadIdentityPools/%s/providers/%s"
 )
 
 var (
 	// certificateCRDGKV is the group.version/kind of the Certificate CRD.
 	certificateCRDGKV = fmt.Sprintf("certificate.%s/%s", certmanagerv1.SchemeGroupVersion.Group, certmanagerv1.SchemeGroupVersion.Version)
+
+	// credentialsRequestCRDGKV is the group.version/kind of the CredentialsRequest CRD.
+	credentialsRequestCRDGKV = "credentialsrequest." + credentialsRequestCRDGroupVersion
+
+	// credentialsRequestGVK is the group/version/kind of the CredentialsRequest resource.
+	credentialsRequestGVK = schema.GroupVersionKind{Group: "cloudcredential.openshift.io", Version: "v1", Kind: "CredentialsRequest"}
+
+	// serviceMonitorCRDGKV is the group.version/kind of the ServiceMonitor CRD.
+	serviceMonitorCRDGKV = "servicemonitor." + monitoringCRDGroupVersion
+
+	// prometheusRuleCRDGKV is the group.version/kind of the PrometheusRule CRD.
+	prometheusRuleCRDGKV = "prometheusrule." + monitoringCRDGroupVersion
+
+	// serviceMonitorGVK is the group/version/kind of the ServiceMonitor resource.
+	serviceMonitorGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}
+
+	// prometheusRuleGVK is the group/version/kind of the PrometheusRule resource.
+	prometheusRuleGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"}
+
+	// secretStoreGVK is the group/version/kind of the SecretStore resource.
+	secretStoreGVK = schema.GroupVersionKind{Group: "external-secrets.io", Version: "v1", Kind: "SecretStore"}
+
+	// clusterSecretStoreGVK is the group/version/kind of the ClusterSecretStore resource.
+	clusterSecretStoreGVK = schema.GroupVersionKind{Group: "external-secrets.io", Version: "v1", Kind: "ClusterSecretStore"}
+
+	// operatorConditionGVK is the group/version/kind of the OLM OperatorCondition resource.
+	operatorConditionGVK = schema.GroupVersionKind{Group: "operators.coreos.com", Version: "v2", Kind: "OperatorCondition"}
+
+	// authenticationGVK is the group/version/kind of the cluster Authentication config resource.
+	authenticationGVK = schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: "Authentication"}
+
+	// infrastructureGVK is the group/version/kind of the cluster Infrastructure config resource.
+	infrastructureGVK = schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: "Infrastructure"}
 )
 
 var (
@@ -93,7 +349,7 @@ var (
 	// created for external-secrets deployment.
 	controllerDefaultResourceLabels = map[string]string{
 		"app":                          externalsecretsCommonName,
-		"app.kubernetes.io/version":    os.Getenv(externalsecretsImageVersionEnvVarName),
+		versionLabelKey:                os.Getenv(externalsecretsImageVersionEnvVarName),
 		"app.kubernetes.io/managed-by": common.ExternalSecretsOperatorCommonName,
 		"app.kubernetes.io/part-of":    common.ExternalSecretsOperatorCommonName,
 	}
@@ -20,15 +20,22 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
+	"time"
+
+	"golang.org/x/time/rate"
 
 	webhook "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
@@ -40,7 +47,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -52,6 +61,7 @@ import (
 	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
 	operatorclient "github.com/openshift/external-secrets-operator/pkg/controller/client"
 	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+	"github.com/openshift/external-secrets-operator/pkg/metrics"
 )
 
 var (
@@ -91,6 +101,63 @@ type Reconciler struct {
 	log                   logr.Logger
 	esm                   *operatorv1alpha1.ExternalSecretsManager
 	optionalResourcesList map[string]struct{}
+
+	// reconcileState is the state of the request being reconciled, replaced for every request.
+	reconcileState
+
+	// upgradeCheckTimes are the times the external-secrets resources were last scanned for the upgrade blockers,
+	// keyed by the UID of the ExternalSecretsConfig.
+	upgradeCheckTimes map[types.UID]time.Time
+
+	// storageMigration is the progress of the storage version migration of the external-secrets CRDs, carried
+	// over the reconciliations migrating the objects in batches.
+	storageMigration *storageMigration
+
+	// storageMigrationLimiter is the rate limiter of the updates made in the storage version migration.
+	storageMigrationLimiter *rate.Limiter
+
+	// webhookVerificationWaitStart is the time the rollout started waiting for the webhook verification to pass,
+	// carried over the reconciliations retrying the verification, and reset when the deployments are reconciled.
+	webhookVerificationWaitStart time.Time
+}
+
+// reconcileState is the state built while reconciling a single request, which is not carried over to the next
+// reconciliation. The reconcilers rendering or planning the resources have their own state.
+type reconcileState struct {
+	// rendering is whether the reconciler renders the resources without a cluster, when the rollout of the
+	// deployments is not gated on the health of the components.
+	rendering bool
+
+	// configReconciled is whether the configuration being reconciled was reconciled successfully earlier,
+	// the updates of the managed resources are then recorded as the drift corrections.
+	configReconciled bool
+
+	// clusterWorkloadIdentity is the workload identity configuration of the core controller
+	// derived from the cluster configuration, when enabled.
+	clusterWorkloadIdentity *operatorv1alpha1.WorkloadIdentityConfig
+
+	// unmanagedResources are the resources annotated with unmanagedResourceAnnotation found in the reconciliation,
+	// which are not reconciled to the desired state.
+	unmanagedResources []string
+
+	// overrideStatuses are the results of the overrides applied in the reconciliation.
+	overrideStatuses []operatorv1alpha1.ResourceOverrideStatus
+
+	// storageMigrationPending is whether the storage version migration is not complete in the reconciliation, the
+	// request is then requeued for migrating the next batch of the objects.
+	storageMigrationPending bool
+
+	// unavailableComponents are the deployments of the enabled components not available in the reconciliation, the
+	// request is then requeued for checking the availability again.
+	unavailableComponents []string
+
+	// bootstrapPending is whether the bootstrap clustersecretstores are waiting for the webhook to be available, the
+	// request is then requeued for creating them.
+	bootstrapPending bool
+
+	// stalledRollouts are the deployments whose rollout did not complete within the progress deadline, reported
+	// with the Degraded condition while the other resources are reconciled.
+	stalledRollouts []string
 }
 
 // +kubebuilder:rbac:groups=operator.openshift.io,resources=externalsecretsconfigs,verbs=get;list;watch;create;update;patch
@@ -100,18 +167,25 @@ type Reconciler struct {
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch
 
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings;clusterroles;clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=events;secrets;services;serviceaccounts,verbs=get;list;watch;create;update;delete;patch
-// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update
-// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates;clusterissuers;issuers,verbs=get;list;watch;create;update
-// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates;clusterissuers;issuers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch
 
 // +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=serviceaccounts/token,verbs=create
+// +kubebuilder:rbac:groups="",resources=pods,verbs=create
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,verbs=update;patch
 // +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=authentications;infrastructures,verbs=get
+// +kubebuilder:rbac:groups=operators.coreos.com,resources=operatorconditions,verbs=get;update;patch
+// +kubebuilder:rbac:groups=cloudcredential.openshift.io,resources=credentialsrequests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;prometheusrules,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=external-secrets.io,resources=clusterexternalsecrets;clustersecretstores;clusterpushsecrets;externalsecrets;secretstores;pushsecrets,verbs=get;list;watch;create;update;patch;delete;deletecollection
 // +kubebuilder:rbac:groups=external-secrets.io,resources=clusterexternalsecrets/finalizers;clustersecretstores/finalizers;externalsecrets/finalizers;pushsecrets/finalizers;secretstores/finalizers;clusterpushsecrets/finalizers,verbs=get;update;patch
 // +kubebuilder:rbac:groups=external-secrets.io,resources=clusterexternalsecrets/status;clustersecretstores/status;externalsecrets/status;pushsecrets/status;secretstores/status;clusterpushsecrets/status,verbs=get;update;patch
@@ -136,13 +210,24 @@ func New(ctx context.Context, mgr ctrl.Manager) (*Reconciler, error) {
 	}
 	r.log.V(1).Info("Cert-manager check complete", "installed", certManagerInstalled)
 
+	// Check if Cloud Credential Operator is installed for requesting the cloud credentials
+	if err := checkCredentialsRequestsInstalled(mgr, r); err != nil {
+		return nil, err
+	}
+
+	// Check if Prometheus Operator is installed for creating the monitoring resources
+	if err := checkMonitoringInstalled(mgr, r); err != nil {
+		return nil, err
+	}
+
 	// Use the manager's client - it reads from the manager's cache
 	// which is configured with label selectors via NewCacheBuilder()
 	c, err := NewClient(mgr, r)
 	if err != nil {
 		return nil, err
 	}
-	r.CtrlClient = c
+	isConfigReconciled := func() bool { return r.configReconciled }
+	r.CtrlClient = newMetricsClient(c, r.Scheme, isConfigReconciled)
 
 	// create an uncached client for the objects not managed by
 	// the controller.
@@ -150,7 +235,7 @@ func New(ctx context.Context, mgr ctrl.Manager) (*Reconciler, error) {
 	if err != nil {
 		return nil, err
 	}
-	r.UncachedClient = uc
+	r.UncachedClient = newMetricsClient(uc, r.Scheme, isConfigReconciled)
 
 	return r, nil
 }
@@ -168,7 +253,13 @@ func NewClient(m manager.Manager, r *Reconciler) (operatorclient.CtrlClient, err
 // NewUncachedClient is for creating an uncached client, and all the objects are read and written directly
 // through API server.
 func NewUncachedClient(m manager.Manager) (operatorclient.CtrlClient, error) {
-	c, err := client.New(m.GetConfig(), client.Options{Scheme: m.GetScheme()})
+	// warnings returned by the API server are logged, and are also collected for the requests made with a context
+	// created by withAPIWarnings.
+	config := rest.CopyConfig(m.GetConfig())
+	config.WarningHandlerWithContext = &apiWarningHandler{
+		WarningHandlerWithContext: log.NewKubeAPIWarningLogger(log.KubeAPIWarningLoggerOptions{}),
+	}
+	c, err := client.New(config, client.Options{Scheme: m.GetScheme()})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create uncached client: %w", err)
 	}
@@ -219,6 +310,21 @@ func buildCacheObjectList(includeCertManager bool) map[client.Object]cache.ByObj
 	objectList[&operatorv1alpha1.ExternalSecretsConfig{}] = cache.ByObject{}
 	objectList[&operatorv1alpha1.ExternalSecretsManager{}] = cache.ByObject{}
 
+	// Bootstrap ClusterSecretStore objects - CRD is installed along with the operator
+	objectList[bootstrapClusterSecretStoreObject()] = cache.ByObject{
+		Label: managedResourceLabelReqSelector,
+	}
+
+	// EndpointSlice objects - labels of the services are copied to the endpointslices
+	objectList[&discoveryv1.EndpointSlice{}] = cache.ByObject{
+		Label: managedResourceLabelReqSelector,
+	}
+
+	// HorizontalPodAutoscaler objects created for the components configured with autoscaling
+	objectList[&autoscalingv2.HorizontalPodAutoscaler{}] = cache.ByObject{
+		Label: managedResourceLabelReqSelector,
+	}
+
 	// Certificate objects - only include if cert-manager CRD exists
 	if includeCertManager {
 		objectList[&certmanagerv1.Certificate{}] = cache.ByObject{
@@ -229,6 +335,13 @@ func buildCacheObjectList(includeCertManager bool) map[client.Object]cache.ByObj
 	return objectList
 }
 
+// bootstrapClusterSecretStoreObject returns an empty ClusterSecretStore object for configuring the cache and the watch.
+func bootstrapClusterSecretStoreObject() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(clusterSecretStoreGVK)
+	return obj
+}
+
 // checkAndRegisterCertificates checks if cert-manager CRD exists and registers Certificate informer if present.
 // Returns true if Certificate CRD is installed.
 func checkAndRegisterCertificates(ctx context.Context, mgr ctrl.Manager, r *Reconciler) (bool, error) {
@@ -252,6 +365,42 @@ func checkAndRegisterCertificates(ctx context.Context, mgr ctrl.Manager, r *Reco
 	return exist, nil
 }
 
+// checkCredentialsRequestsInstalled checks if CredentialsRequest CRD provided by the Cloud Credential Operator exists.
+// CredentialsRequest objects are created in the Cloud Credential Operator namespace and are not watched, the minted
+// Secrets are polled for availability instead.
+func checkCredentialsRequestsInstalled(mgr ctrl.Manager, r *Reconciler) error {
+	exist, err := isCRDInstalled(mgr.GetConfig(), credentialsRequestCRDName, credentialsRequestCRDGroupVersion)
+	if err != nil {
+		return fmt.Errorf("failed to check %s/%s CRD is installed: %w", credentialsRequestCRDGroupVersion, credentialsRequestCRDName, err)
+	}
+	if exist {
+		r.optionalResourcesList[credentialsRequestCRDGKV] = struct{}{}
+	}
+	r.log.V(1).Info("Cloud Credential Operator check complete", "installed", exist)
+
+	return nil
+}
+
+// checkMonitoringInstalled checks if ServiceMonitor and PrometheusRule CRDs provided by the Prometheus Operator exist.
+// The monitoring resources are not watched, and are reconciled with the periodic reconciliation.
+func checkMonitoringInstalled(mgr ctrl.Manager, r *Reconciler) error {
+	for crdName, gkv := range map[string]string{
+		serviceMonitorCRDName: serviceMonitorCRDGKV,
+		prometheusRuleCRDName: prometheusRuleCRDGKV,
+	} {
+		exist, err := isCRDInstalled(mgr.GetConfig(), crdName, monitoringCRDGroupVersion)
+		if err != nil {
+			return fmt.Errorf("failed to check %s/%s CRD is installed: %w", monitoringCRDGroupVersion, crdName, err)
+		}
+		if exist {
+			r.optionalResourcesList[gkv] = struct{}{}
+		}
+		r.log.V(1).Info("Prometheus Operator check complete", "crd", crdName, "installed", exist)
+	}
+
+	return nil
+}
+
 // SetupWithManager is for creating a controller instance with predicates and event filters.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	mapFunc := func(ctx context.Context, obj client.Object) []reconcile.Request {
@@ -281,6 +430,16 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	withIgnoreStatusUpdatePredicates := builder.WithPredicates(predicate.GenerationChangedPredicate{}, managedResources)
 	managedResourcePredicate := builder.WithPredicates(managedResources)
 
+	// predicate function to reconcile on the deployment status changes, for updating the components availability.
+	deploymentStatusChanged := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldDeployment, okOld := e.ObjectOld.(*appsv1.Deployment)
+			newDeployment, okNew := e.ObjectNew.(*appsv1.Deployment)
+			return okOld && okNew && !reflect.DeepEqual(oldDeployment.Status, newDeployment.Status)
+		},
+	}
+	withDeploymentStatusPredicates := builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, deploymentStatusChanged), managedResources)
+
 	mgrBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&operatorv1alpha1.ExternalSecretsConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Named(ControllerName)
@@ -288,7 +447,7 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	for _, res := range controllerManagedResources {
 		switch res {
 		case &appsv1.Deployment{}:
-			mgrBuilder.Watches(res, handler.EnqueueRequestsFromMapFunc(mapFunc), withIgnoreStatusUpdatePredicates)
+			mgrBuilder.Watches(res, handler.EnqueueRequestsFromMapFunc(mapFunc), withDeploymentStatusPredicates)
 		case &corev1.Secret{}:
 			mgrBuilder.WatchesMetadata(res, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(predicate.LabelChangedPredicate{}))
 		default: // Trusted CA ConfigMap depends on this case
@@ -299,6 +458,15 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Watch ExternalSecretsManager
 	mgrBuilder.Watches(&operatorv1alpha1.ExternalSecretsManager{}, handler.EnqueueRequestsFromMapFunc(mapFunc), withIgnoreStatusUpdatePredicates)
 
+	// Watch EndpointSlices of the services for updating the components availability
+	mgrBuilder.Watches(&discoveryv1.EndpointSlice{}, handler.EnqueueRequestsFromMapFunc(mapFunc), managedResourcePredicate)
+
+	// Watch HorizontalPodAutoscalers, ignoring the status updated by the autoscaler on every sync
+	mgrBuilder.Watches(&autoscalingv2.HorizontalPodAutoscaler{}, handler.EnqueueRequestsFromMapFunc(mapFunc), withIgnoreStatusUpdatePredicates)
+
+	// Watch bootstrap ClusterSecretStores
+	mgrBuilder.Watches(bootstrapClusterSecretStoreObject(), handler.EnqueueRequestsFromMapFunc(mapFunc), withIgnoreStatusUpdatePredicates)
+
 	// Conditionally watch Certificate if cert-manager is installed
 	// Note: Certificate is already declared in buildCacheObjectList(), this just sets up the watch
 	if _, ok := r.optionalResourcesList[certificateCRDGKV]; ok {
@@ -390,6 +558,19 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 }
 
 func (r *Reconciler) processReconcileRequest(esc *operatorv1alpha1.ExternalSecretsConfig, req types.NamespacedName) (ctrl.Result, error) {
+	r.reconcileState = reconcileState{configReconciled: isConfigReconciled(esc)}
+	switch esc.Spec.ManagementState {
+	case operatorv1alpha1.ManagementStatePlan:
+		return r.processPlanRequest(esc, req)
+	case operatorv1alpha1.ManagementStateUnmanaged:
+		return r.processUnmanagedRequest(esc, req)
+	case operatorv1alpha1.ManagementStateRemoved:
+		return r.processRemovedRequest(esc, req)
+	}
+	// plan is reported only in the Plan management state.
+	planCleared := esc.Status.Plan != nil
+	esc.Status.Plan = nil
+
 	createRecon := false
 	if !containsProcessedAnnotation(esc) && reflect.DeepEqual(esc.Status, operatorv1alpha1.ExternalSecretsConfigStatus{}) {
 		r.log.V(1).Info("starting reconciliation of newly created externalsecretsconfigs.operator.openshift.io", "namespace", esc.GetNamespace(), "name", esc.GetName())
@@ -398,10 +579,17 @@ func (r *Reconciler) processReconcileRequest(esc *operatorv1alpha1.ExternalSecre
 
 	var errUpdate error = nil
 	observedGeneration := esc.GetGeneration()
+	// conditions updated by the checks in the reconciliation are written with the final status update.
+	conditions := slices.Clone(esc.Status.Conditions)
 	err := r.reconcileExternalSecretsDeployment(esc, createRecon)
 	if err != nil {
 		r.log.Error(err, "failed to reconcile external-secrets deployment", "request", req)
 		isFatal := common.IsIrrecoverableError(err)
+		if isFatal {
+			metrics.ReconcileErrors.WithLabelValues(ControllerName, metrics.ErrorTypeIrrecoverable).Inc()
+		} else {
+			metrics.ReconcileErrors.WithLabelValues(ControllerName, metrics.ErrorTypeRetryable).Inc()
+		}
 
 		degradedCond := metav1.Condition{
 			Type:               operatorv1alpha1.Degraded,
@@ -431,8 +619,11 @@ func (r *Reconciler) processReconcileRequest(esc *operatorv1alpha1.ExternalSecre
 		// Set both conditions atomically before updating status
 		degradedChanged := apimeta.SetStatusCondition(&esc.Status.Conditions, degradedCond)
 		readyChanged := apimeta.SetStatusCondition(&esc.Status.Conditions, readyCond)
+		// resources not reached in the failed reconciliation may still be unmanaged, the condition is only updated
+		// with the unmanaged resources found and is not removed.
+		unmanagedChanged := len(r.unmanagedResources) > 0 && r.setUnmanagedResourcesCondition(esc)
 
-		if degradedChanged || readyChanged {
+		if degradedChanged || readyChanged || unmanagedChanged {
 			r.log.V(2).Info("updating externalsecretsconfig conditions on error",
 				"namespace", esc.GetNamespace(),
 				"name", esc.GetName(),
@@ -468,12 +659,34 @@ func (r *Reconciler) processReconcileRequest(esc *operatorv1alpha1.ExternalSecre
 		Message:            "reconciliation successful",
 		ObservedGeneration: observedGeneration,
 	}
+	if len(r.unavailableComponents) > 0 {
+		readyCond.Status = metav1.ConditionFalse
+		readyCond.Reason = operatorv1alpha1.ReasonInProgress
+		readyCond.Message = r.unavailableComponentsMessage()
+	}
+	if r.bootstrapPending {
+		readyCond.Status = metav1.ConditionFalse
+		readyCond.Reason = operatorv1alpha1.ReasonInProgress
+		readyCond.Message = apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.ClusterSecretStoresBootstrapped).Message
+	}
+	if len(r.stalledRollouts) > 0 {
+		degradedCond.Status = metav1.ConditionTrue
+		degradedCond.Reason = operatorv1alpha1.ReasonFailed
+		degradedCond.Message = r.stalledRolloutsMessage()
+
+		readyCond.Status = metav1.ConditionFalse
+		readyCond.Reason = operatorv1alpha1.ReasonFailed
+		readyCond.Message = r.stalledRolloutsMessage()
+	}
 
 	// Set both conditions atomically before updating status on success
 	degradedChanged := apimeta.SetStatusCondition(&esc.Status.Conditions, degradedCond)
 	readyChanged := apimeta.SetStatusCondition(&esc.Status.Conditions, readyCond)
+	unmanagedChanged := r.setUnmanagedResourcesCondition(esc)
+	overridesChanged := r.setOverridesStatus(esc)
+	conditionsChanged := !reflect.DeepEqual(conditions, esc.Status.Conditions)
 
-	if degradedChanged || readyChanged {
+	if degradedChanged || readyChanged || unmanagedChanged || overridesChanged || conditionsChanged || planCleared {
 		r.log.V(2).Info("updating externalsecretsconfig conditions on successful reconciliation",
 			"namespace", esc.GetNamespace(),
 			"name", esc.GetName(),
@@ -481,8 +694,39 @@ func (r *Reconciler) processReconcileRequest(esc *operatorv1alpha1.ExternalSecre
 			"readyChanged", readyChanged)
 		errUpdate = r.updateCondition(esc, nil)
 	}
+	if errUpdate != nil {
+		return ctrl.Result{}, errUpdate
+	}
+
+	if len(r.unavailableComponents) > 0 || len(r.stalledRollouts) > 0 || r.bootstrapPending {
+		return ctrl.Result{RequeueAfter: common.DefaultRequeueTime}, nil
+	}
+	if r.storageMigrationPending {
+		return ctrl.Result{RequeueAfter: storageMigrationRequeueInterval}, nil
+	}
+	// requeue for verifying the webhook health periodically.
+	return ctrl.Result{RequeueAfter: webhookHealthCheckInterval}, nil
+}
+
+// processPlanRequest computes the changes the controller would make to the resources created for the external-secrets
+// deployment, and reports them in the status without making them.
+func (r *Reconciler) processPlanRequest(esc *operatorv1alpha1.ExternalSecretsConfig, req types.NamespacedName) (ctrl.Result, error) {
+	if err := r.planExternalSecretsDeployment(esc); err != nil {
+		r.log.Error(err, "failed to plan external-secrets deployment changes", "request", req)
+		if common.IsIrrecoverableError(err) {
+			r.eventRecorder.Eventf(esc, corev1.EventTypeWarning, "PlanFailed", "failed to plan external-secrets deployment changes: %v", err)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: planRefreshInterval}, nil
+}
 
-	return ctrl.Result{}, errUpdate
+// isConfigReconciled returns whether the current generation of the externalsecretsconfigs.operator.openshift.io
+// was reconciled successfully earlier.
+func isConfigReconciled(esc *operatorv1alpha1.ExternalSecretsConfig) bool {
+	readyCond := apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.Ready)
+	return readyCond != nil && readyCond.Status == metav1.ConditionTrue && readyCond.ObservedGeneration == esc.GetGeneration()
 }
 
 // cleanUp handles deletion of externalsecretsconfigs.operator.openshift.io gracefully.
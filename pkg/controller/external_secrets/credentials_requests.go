@@ -0,0 +1,195 @@
+package external_secrets
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+)
+
+// createOrApplyCredentialsRequests creates or updates the CredentialsRequest resources for the cloud credentials
+// configured in ExternalSecretsConfig, and removes the ones created earlier which are no longer configured.
+func (r *Reconciler) createOrApplyCredentialsRequests(esc *operatorv1alpha1.ExternalSecretsConfig, resourceMetadata common.ResourceMetadata) error {
+	if !r.isCredentialsRequestsInstalled() {
+		return nil
+	}
+
+	desiredNames := make([]string, 0, len(esc.Spec.ControllerConfig.CredentialsRequests))
+	for _, request := range esc.Spec.ControllerConfig.CredentialsRequests {
+		desired := getCredentialsRequestObject(esc, request, resourceMetadata)
+		desiredNames = append(desiredNames, desired.GetName())
+
+		credentialsRequestName := fmt.Sprintf("%s/%s", desired.GetNamespace(), desired.GetName())
+		r.log.V(4).Info("reconciling credentialsrequest resource", "name", credentialsRequestName)
+
+		fetched := &unstructured.Unstructured{}
+		fetched.SetGroupVersionKind(credentialsRequestGVK)
+		exist, err := r.UncachedClient.Exists(r.ctx, client.ObjectKeyFromObject(desired), fetched)
+		if err != nil {
+			return common.FromClientError(err, "failed to check %s credentialsrequest resource already exists", credentialsRequestName)
+		}
+
+		switch {
+		case exist && r.hasObjectDrifted(desired, fetched):
+			r.log.V(1).Info("credentialsrequest has been modified, updating to desired state", "name", credentialsRequestName)
+			common.RemoveObsoleteAnnotations(desired, resourceMetadata)
+			if err := r.UncachedClient.Apply(r.ctx, desired); err != nil {
+				return common.FromClientError(err, "failed to update %s credentialsrequest resource", credentialsRequestName)
+			}
+			r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "credentialsrequest resource %s updated", credentialsRequestName)
+		case !exist:
+			if err := r.UncachedClient.Apply(r.ctx, desired); err != nil {
+				return common.FromClientError(err, "failed to create %s credentialsrequest resource", credentialsRequestName)
+			}
+			r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "credentialsrequest resource %s created", credentialsRequestName)
+		default:
+			r.log.V(4).Info("credentialsrequest resource already exists and is in expected state", "name", credentialsRequestName)
+		}
+	}
+
+	return r.deleteStaleCredentialsRequests(esc, desiredNames)
+}
+
+// deleteStaleCredentialsRequests removes the CredentialsRequest resources created by the controller which are not
+// configured anymore.
+func (r *Reconciler) deleteStaleCredentialsRequests(esc *operatorv1alpha1.ExternalSecretsConfig, desiredNames []string) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(credentialsRequestGVK.GroupVersion().WithKind(credentialsRequestGVK.Kind + "List"))
+	if err := r.UncachedClient.List(r.ctx, list,
+		client.InNamespace(cloudCredentialOperatorNamespace),
+		client.MatchingLabels{requestEnqueueLabelKey: requestEnqueueLabelValue},
+	); err != nil {
+		return common.FromClientError(err, "failed to list credentialsrequest resources in %s namespace", cloudCredentialOperatorNamespace)
+	}
+
+	for i := range list.Items {
+		item := &list.Items[i]
+		if slices.Contains(desiredNames, item.GetName()) || !strings.HasPrefix(item.GetName(), credentialsRequestNamePrefix) {
+			continue
+		}
+		credentialsRequestName := fmt.Sprintf("%s/%s", item.GetNamespace(), item.GetName())
+		if err := r.UncachedClient.Delete(r.ctx, item); err != nil {
+			return common.FromClientError(err, "failed to delete %s credentialsrequest resource", credentialsRequestName)
+		}
+		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "credentialsrequest resource %s deleted", credentialsRequestName)
+	}
+
+	return nil
+}
+
+// checkCloudCredentialsAvailable updates the CloudCredentialsAvailable condition with the availability of the Secrets
+// minted by the Cloud Credential Operator, and returns a retry required error when any of them is not yet available.
+func (r *Reconciler) checkCloudCredentialsAvailable(esc *operatorv1alpha1.ExternalSecretsConfig) error {
+	if len(esc.Spec.ControllerConfig.CredentialsRequests) == 0 {
+		if apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.CloudCredentialsAvailable) == nil {
+			return nil
+		}
+		apimeta.RemoveStatusCondition(&esc.Status.Conditions, operatorv1alpha1.CloudCredentialsAvailable)
+		return r.updateStatus(r.ctx, esc)
+	}
+
+	var pending []string
+	for _, request := range esc.Spec.ControllerConfig.CredentialsRequests {
+		key := types.NamespacedName{Name: request.Name, Namespace: getNamespace(esc)}
+		exist, err := r.UncachedClient.Exists(r.ctx, key, &corev1.Secret{})
+		if err != nil {
+			return common.FromClientError(err, "failed to check %s cloud credentials secret is available", key)
+		}
+		if !exist {
+			pending = append(pending, key.String())
+		}
+	}
+
+	cond := metav1.Condition{
+		Type:               operatorv1alpha1.CloudCredentialsAvailable,
+		Status:             metav1.ConditionTrue,
+		Reason:             operatorv1alpha1.ReasonReady,
+		Message:            "cloud credentials requested from Cloud Credential Operator are available",
+		ObservedGeneration: esc.GetGeneration(),
+	}
+	if len(pending) > 0 {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = operatorv1alpha1.ReasonInProgress
+		cond.Message = fmt.Sprintf("waiting for Cloud Credential Operator to mint the credentials into secrets %s", strings.Join(pending, ", "))
+	}
+	if apimeta.SetStatusCondition(&esc.Status.Conditions, cond) {
+		if err := r.updateStatus(r.ctx, esc); err != nil {
+			return common.FromClientError(err, "failed to update %s/%s status with cloud credentials availability", esc.GetNamespace(), esc.GetName())
+		}
+	}
+
+	if len(pending) > 0 {
+		return common.NewRetryRequiredError(fmt.Errorf("secrets %s not found", strings.Join(pending, ", ")), "cloud credentials are not yet available")
+	}
+	return nil
+}
+
+// getCredentialsRequestObject returns the CredentialsRequest resource for requesting the cloud credentials from the
+// Cloud Credential Operator, to be written into a Secret in the operand namespace for the core controller.
+func getCredentialsRequestObject(esc *operatorv1alpha1.ExternalSecretsConfig, request operatorv1alpha1.CloudCredentialsRequest, resourceMetadata common.ResourceMetadata) *unstructured.Unstructured {
+	providerSpec := map[string]interface{}{
+		"apiVersion": credentialsRequestCRDGroupVersion,
+	}
+	switch {
+	case request.AWS != nil:
+		statements := make([]interface{}, 0, len(request.AWS.StatementEntries))
+		for _, s := range request.AWS.StatementEntries {
+			statements = append(statements, map[string]interface{}{
+				"effect":   s.Effect,
+				"action":   toInterfaceSlice(s.Action),
+				"resource": s.Resource,
+			})
+		}
+		providerSpec["kind"] = "AWSProviderSpec"
+		providerSpec["statementEntries"] = statements
+	case request.Azure != nil:
+		roleBindings := make([]interface{}, 0, len(request.Azure.RoleBindings))
+		for _, b := range request.Azure.RoleBindings {
+			roleBindings = append(roleBindings, map[string]interface{}{
+				"role": b.Role,
+			})
+		}
+		providerSpec["kind"] = "AzureProviderSpec"
+		providerSpec["roleBindings"] = roleBindings
+	case request.GCP != nil:
+		providerSpec["kind"] = "GCPProviderSpec"
+		providerSpec["predefinedRoles"] = toInterfaceSlice(request.GCP.PredefinedRoles)
+		providerSpec["skipServiceCheck"] = true
+	}
+
+	credentialsRequest := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"secretRef": map[string]interface{}{
+					"name":      request.Name,
+					"namespace": getNamespace(esc),
+				},
+				"serviceAccountNames": []interface{}{externalsecretsCommonName},
+				"providerSpec":        providerSpec,
+			},
+		},
+	}
+	credentialsRequest.SetGroupVersionKind(credentialsRequestGVK)
+	credentialsRequest.SetName(credentialsRequestNamePrefix + request.Name)
+	credentialsRequest.SetNamespace(cloudCredentialOperatorNamespace)
+	common.ApplyResourceMetadata(credentialsRequest, resourceMetadata)
+
+	return credentialsRequest
+}
+
+func toInterfaceSlice(values []string) []interface{} {
+	s := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		s = append(s, v)
+	}
+	return s
+}
@@ -0,0 +1,298 @@
+package external_secrets
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+func testCloudCredentialsRequests() []operatorv1alpha1.CloudCredentialsRequest {
+	return []operatorv1alpha1.CloudCredentialsRequest{
+		{
+			Name: "aws-credentials",
+			AWS: &operatorv1alpha1.AWSCredentialsRequest{
+				StatementEntries: []operatorv1alpha1.AWSStatementEntry{
+					{
+						Effect:   "Allow",
+						Action:   []string{"secretsmanager:GetSecretValue", "secretsmanager:ListSecrets"},
+						Resource: "*",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetCredentialsRequestObject(t *testing.T) {
+	tests := []struct {
+		name             string
+		request          operatorv1alpha1.CloudCredentialsRequest
+		wantProviderSpec map[string]interface{}
+	}{
+		{
+			name:    "aws statement entries",
+			request: testCloudCredentialsRequests()[0],
+			wantProviderSpec: map[string]interface{}{
+				"apiVersion": "cloudcredential.openshift.io/v1",
+				"kind":       "AWSProviderSpec",
+				"statementEntries": []interface{}{
+					map[string]interface{}{
+						"effect":   "Allow",
+						"action":   []interface{}{"secretsmanager:GetSecretValue", "secretsmanager:ListSecrets"},
+						"resource": "*",
+					},
+				},
+			},
+		},
+		{
+			name: "azure role bindings",
+			request: operatorv1alpha1.CloudCredentialsRequest{
+				Name: "azure-credentials",
+				Azure: &operatorv1alpha1.AzureCredentialsRequest{
+					RoleBindings: []operatorv1alpha1.AzureRoleBinding{{Role: "Key Vault Secrets User"}},
+				},
+			},
+			wantProviderSpec: map[string]interface{}{
+				"apiVersion":   "cloudcredential.openshift.io/v1",
+				"kind":         "AzureProviderSpec",
+				"roleBindings": []interface{}{map[string]interface{}{"role": "Key Vault Secrets User"}},
+			},
+		},
+		{
+			name: "gcp predefined roles",
+			request: operatorv1alpha1.CloudCredentialsRequest{
+				Name: "gcp-credentials",
+				GCP: &operatorv1alpha1.GCPCredentialsRequest{
+					PredefinedRoles: []string{"roles/secretmanager.secretAccessor"},
+				},
+			},
+			wantProviderSpec: map[string]interface{}{
+				"apiVersion":       "cloudcredential.openshift.io/v1",
+				"kind":             "GCPProviderSpec",
+				"predefinedRoles":  []interface{}{"roles/secretmanager.secretAccessor"},
+				"skipServiceCheck": true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			esc := commontest.TestExternalSecretsConfig()
+			obj := getCredentialsRequestObject(esc, tt.request, testResourceMetadata(esc))
+
+			if obj.GetName() != "external-secrets-"+tt.request.Name || obj.GetNamespace() != cloudCredentialOperatorNamespace {
+				t.Errorf("unexpected credentialsrequest %s/%s", obj.GetNamespace(), obj.GetName())
+			}
+			if obj.GetLabels()[requestEnqueueLabelKey] != requestEnqueueLabelValue {
+				t.Errorf("expected managed label on credentialsrequest, got %v", obj.GetLabels())
+			}
+			secretRef, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "secretRef")
+			if !reflect.DeepEqual(secretRef, map[string]string{"name": tt.request.Name, "namespace": commontest.TestExternalSecretsNamespace}) {
+				t.Errorf("unexpected secretRef %v", secretRef)
+			}
+			providerSpec, _, _ := unstructured.NestedMap(obj.Object, "spec", "providerSpec")
+			if !reflect.DeepEqual(providerSpec, tt.wantProviderSpec) {
+				t.Errorf("providerSpec = %v, want %v", providerSpec, tt.wantProviderSpec)
+			}
+		})
+	}
+}
+
+func TestCreateOrApplyCredentialsRequests(t *testing.T) {
+	tests := []struct {
+		name         string
+		crdInstalled bool
+		requests     []operatorv1alpha1.CloudCredentialsRequest
+		preReq       func(*fakes.FakeCtrlClient)
+		wantApply    int
+		wantDelete   int
+		wantErr      string
+	}{
+		{
+			name:     "cloud credential operator not installed",
+			requests: testCloudCredentialsRequests(),
+		},
+		{
+			name:         "credentialsrequest created",
+			crdInstalled: true,
+			requests:     testCloudCredentialsRequests(),
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(doesNotExist())
+			},
+			wantApply: 1,
+		},
+		{
+			name:         "credentialsrequest modified is updated",
+			crdInstalled: true,
+			requests:     testCloudCredentialsRequests(),
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+					u := obj.(*unstructured.Unstructured)
+					u.Object["spec"] = map[string]interface{}{"secretRef": map[string]interface{}{"name": "old"}}
+					return true, nil
+				})
+			},
+			wantApply: 1,
+		},
+		{
+			name:         "credentialsrequest in desired state",
+			crdInstalled: true,
+			requests:     testCloudCredentialsRequests(),
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+					esc := commontest.TestExternalSecretsConfig()
+					desired := getCredentialsRequestObject(esc, testCloudCredentialsRequests()[0], testResourceMetadata(esc))
+					testApplied(desired).DeepCopyInto(obj.(*unstructured.Unstructured))
+					return true, nil
+				})
+			},
+		},
+		{
+			name:         "credentialsrequest no longer configured is deleted",
+			crdInstalled: true,
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ListCalls(func(ctx context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					stale := unstructured.Unstructured{}
+					stale.SetGroupVersionKind(credentialsRequestGVK)
+					stale.SetName("external-secrets-stale")
+					stale.SetNamespace(cloudCredentialOperatorNamespace)
+					list.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{stale}
+					return nil
+				})
+			},
+			wantDelete: 1,
+		},
+		{
+			name:         "credentialsrequest creation fails",
+			crdInstalled: true,
+			requests:     testCloudCredentialsRequests(),
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(doesNotExist())
+				m.ApplyReturns(commontest.ErrTestClient)
+			},
+			wantApply: 1,
+			wantErr:   "failed to create openshift-cloud-credential-operator/external-secrets-aws-credentials credentialsrequest resource: test client error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			mock := &fakes.FakeCtrlClient{}
+			r.UncachedClient = mock
+			if tt.crdInstalled {
+				r.optionalResourcesList[credentialsRequestCRDGKV] = struct{}{}
+			}
+			if tt.preReq != nil {
+				tt.preReq(mock)
+			}
+
+			esc := commontest.TestExternalSecretsConfig()
+			esc.Spec.ControllerConfig.CredentialsRequests = tt.requests
+
+			err := r.createOrApplyCredentialsRequests(esc, testResourceMetadata(esc))
+			if (tt.wantErr != "" || err != nil) && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Expected error: %v, got: %v", tt.wantErr, err)
+			}
+			if mock.ApplyCallCount() != tt.wantApply {
+				t.Errorf("Apply called %d times, want %d", mock.ApplyCallCount(), tt.wantApply)
+			}
+			if mock.DeleteCallCount() != tt.wantDelete {
+				t.Errorf("Delete called %d times, want %d", mock.DeleteCallCount(), tt.wantDelete)
+			}
+		})
+	}
+}
+
+func TestCheckCloudCredentialsAvailable(t *testing.T) {
+	tests := []struct {
+		name          string
+		requests      []operatorv1alpha1.CloudCredentialsRequest
+		conditions    []metav1.Condition
+		secretExists  bool
+		wantCondition *metav1.Condition
+		wantErr       string
+	}{
+		{
+			name: "no credentials requested",
+		},
+		{
+			name: "condition removed when credentials no longer requested",
+			conditions: []metav1.Condition{
+				{Type: operatorv1alpha1.CloudCredentialsAvailable, Status: metav1.ConditionTrue, Reason: operatorv1alpha1.ReasonReady},
+			},
+		},
+		{
+			name:         "minted secret available",
+			requests:     testCloudCredentialsRequests(),
+			secretExists: true,
+			wantCondition: &metav1.Condition{
+				Type:   operatorv1alpha1.CloudCredentialsAvailable,
+				Status: metav1.ConditionTrue,
+				Reason: operatorv1alpha1.ReasonReady,
+			},
+		},
+		{
+			name:     "minted secret pending",
+			requests: testCloudCredentialsRequests(),
+			wantCondition: &metav1.Condition{
+				Type:   operatorv1alpha1.CloudCredentialsAvailable,
+				Status: metav1.ConditionFalse,
+				Reason: operatorv1alpha1.ReasonInProgress,
+			},
+			wantErr: "cloud credentials are not yet available: secrets external-secrets/aws-credentials not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			mock := &fakes.FakeCtrlClient{}
+			r.CtrlClient = mock
+			r.UncachedClient = mock
+			mock.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+				if _, ok := obj.(*corev1.Secret); ok {
+					return tt.secretExists, nil
+				}
+				return false, nil
+			})
+
+			esc := commontest.TestExternalSecretsConfig()
+			esc.Spec.ControllerConfig.CredentialsRequests = tt.requests
+			esc.Status.Conditions = tt.conditions
+
+			err := r.checkCloudCredentialsAvailable(esc)
+			if (tt.wantErr != "" || err != nil) && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Expected error: %v, got: %v", tt.wantErr, err)
+			}
+
+			cond := apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.CloudCredentialsAvailable)
+			switch {
+			case tt.wantCondition == nil && cond != nil:
+				t.Errorf("unexpected condition %v", cond)
+			case tt.wantCondition != nil && cond == nil:
+				t.Errorf("expected condition %v, not found", tt.wantCondition)
+			case tt.wantCondition != nil && (cond.Status != tt.wantCondition.Status || cond.Reason != tt.wantCondition.Reason):
+				t.Errorf("condition = %v, want %v", cond, tt.wantCondition)
+			}
+			wantStatusUpdates := 0
+			if tt.wantCondition != nil || len(tt.conditions) > 0 {
+				wantStatusUpdates = 1
+			}
+			if mock.StatusUpdateCallCount() != wantStatusUpdates {
+				t.Errorf("StatusUpdate called %d times, want %d", mock.StatusUpdateCallCount(), wantStatusUpdates)
+			}
+		})
+	}
+}
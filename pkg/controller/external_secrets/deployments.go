@@ -1,9 +1,11 @@
 package external_secrets
 
 import (
+	"errors"
 	"fmt"
 	"maps"
 	"os"
+	"time"
 	"unsafe"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -22,6 +24,43 @@ import (
 
 // createOrApplyDeployments ensures required Deployment resources exist and are correctly configured.
 func (r *Reconciler) createOrApplyDeployments(esc *operatorv1alpha1.ExternalSecretsConfig, resourceMetadata common.ResourceMetadata, externalSecretsConfigCreateRecon bool) error {
+	enabled := make(map[string]bool)
+	for _, assetName := range getDeploymentAssetNames(esc) {
+		enabled[assetName] = true
+	}
+	// deployments are rolled out in stages, a new image is rolled out only when the previous stages are healthy.
+	var previous []rolloutStageDeployment
+	for _, stage := range rolloutStages {
+		var current []rolloutStageDeployment
+		for _, d := range stage {
+			if !enabled[d.assetName] {
+				continue
+			}
+			imageUpdated, err := r.createOrApplyDeploymentFromAsset(esc, d.assetName, resourceMetadata, externalSecretsConfigCreateRecon, previous)
+			if err != nil {
+				return err
+			}
+			d.imageUpdated = imageUpdated
+			current = append(current, d)
+		}
+		previous = append(previous, current...)
+	}
+	// no deployment is waiting for the webhook verification, when the deployments are reconciled.
+	r.webhookVerificationWaitStart = time.Time{}
+
+	if err := r.updateImageInStatus(esc); err != nil {
+		return common.FromClientError(err, "failed to update %s/%s status with image info", esc.GetNamespace(), esc.GetName())
+	}
+
+	if err := r.updateCloudIdentityModeInStatus(esc); err != nil {
+		return common.FromClientError(err, "failed to update %s/%s status with cloud identity mode", esc.GetNamespace(), esc.GetName())
+	}
+
+	return nil
+}
+
+// getDeploymentAssetNames returns the Deployment assets of the external-secrets components enabled.
+func getDeploymentAssetNames(esc *operatorv1alpha1.ExternalSecretsConfig) []string {
 	// Define all Deployment assets to apply based on conditions.
 	deployments := []struct {
 		assetName string
@@ -45,58 +84,78 @@ func (r *Reconciler) createOrApplyDeployments(esc *operatorv1alpha1.ExternalSecr
 		},
 	}
 
-	// Apply deployments based on the specified conditions.
+	assetNames := make([]string, 0, len(deployments))
 	for _, d := range deployments {
-		if !d.condition {
-			continue
-		}
-		if err := r.createOrApplyDeploymentFromAsset(esc, d.assetName, resourceMetadata, externalSecretsConfigCreateRecon); err != nil {
-			return err
+		if d.condition {
+			assetNames = append(assetNames, d.assetName)
 		}
 	}
-
-	if err := r.updateImageInStatus(esc); err != nil {
-		return common.FromClientError(err, "failed to update %s/%s status with image info", esc.GetNamespace(), esc.GetName())
-	}
-
-	return nil
+	return assetNames
 }
 
+// createOrApplyDeploymentFromAsset creates or updates the deployment of the asset, and returns whether the deployment
+// was updated with a new image. The update with a new image waits for the deployments of the previous stages of the
+// rollout to be healthy.
 func (r *Reconciler) createOrApplyDeploymentFromAsset(esc *operatorv1alpha1.ExternalSecretsConfig, assetName string, resourceMetadata common.ResourceMetadata,
-	externalSecretsConfigCreateRecon bool,
-) error {
+	externalSecretsConfigCreateRecon bool, previous []rolloutStageDeployment,
+) (bool, error) {
 	deployment, err := r.getDeploymentObject(assetName, esc, resourceMetadata)
 	if err != nil {
-		return err
+		return false, err
+	}
+	if err := r.applyOverride(esc, deployment); err != nil {
+		return false, err
 	}
 
 	deploymentName := fmt.Sprintf("%s/%s", deployment.GetNamespace(), deployment.GetName())
 	fetched := &appsv1.Deployment{}
 	exist, err := r.Exists(r.ctx, client.ObjectKeyFromObject(deployment), fetched)
 	if err != nil {
-		return common.FromClientError(err, "failed to check %s deployment resource already exists", deploymentName)
+		return false, common.FromClientError(err, "failed to check %s deployment resource already exists", deploymentName)
 	}
 	if exist && externalSecretsConfigCreateRecon {
 		r.eventRecorder.Eventf(esc, corev1.EventTypeWarning, "ResourceAlreadyExists", "%s deployment resource already exists", deploymentName)
 	}
+	if exist {
+		retainAutoscaledReplicas(esc, assetName, deployment, fetched)
+	}
+	autoscaledFields := getAutoscaledFields(esc, assetName)
+	imageUpdated := false
 	switch {
-	case exist && common.HasObjectChanged(deployment, fetched, &resourceMetadata):
+	case exist && r.hasObjectDrifted(deployment, fetched, autoscaledFields...):
+		imageUpdated = isImageUpdated(deployment, fetched)
+		if imageUpdated && !r.rendering {
+			err := r.waitForRolloutStages(esc, previous, deploymentName)
+			if errors.Is(err, errRolloutStalled) {
+				r.log.V(1).Info("deployment not updated, rollout of a previous stage is stalled", "name", deploymentName)
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+		}
 		r.log.V(1).Info("deployment has been modified, updating to desired state", "name", deploymentName)
 		common.RemoveObsoleteAnnotations(deployment, resourceMetadata)
-		if err := r.UpdateWithRetry(r.ctx, deployment); err != nil {
-			return common.FromClientError(err, "failed to update %s deployment resource", deploymentName)
+		if err := r.Apply(r.ctx, deployment, autoscaledFields); err != nil {
+			return false, common.FromClientError(err, "failed to update %s deployment resource", deploymentName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "deployment resource %s updated", deploymentName)
 	case !exist:
-		if err := r.Create(r.ctx, deployment); err != nil {
-			return common.FromClientError(err, "failed to create %s deployment resource", deploymentName)
+		if err := r.Apply(r.ctx, deployment, autoscaledFields); err != nil {
+			return false, common.FromClientError(err, "failed to create %s deployment resource", deploymentName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "deployment resource %s created", deploymentName)
 	default:
 		r.log.V(4).Info("deployment resource already exists and is in expected state", "name", deploymentName)
+		// a stalled rollout is reported with the Degraded condition, and does not stop the reconciliation of the
+		// other resources.
+		if !r.rendering && getProgressDeadlineExceeded(fetched) != nil {
+			r.log.V(1).Info("deployment rollout did not complete within the progress deadline", "name", deploymentName)
+			r.stalledRollouts = append(r.stalledRollouts, deploymentName)
+		}
 	}
 
-	return nil
+	return imageUpdated, nil
 }
 
 func (r *Reconciler) getDeploymentObject(assetName string, esc *operatorv1alpha1.ExternalSecretsConfig, resourceMetadata common.ResourceMetadata) (*appsv1.Deployment, error) {
@@ -106,13 +165,19 @@ func (r *Reconciler) getDeploymentObject(assetName string, esc *operatorv1alpha1
 	updatePodTemplateLabels(deployment, resourceMetadata.Labels)
 	updatePodTemplateAnnotations(deployment, resourceMetadata.Annotations)
 
-	image := os.Getenv(externalsecretsImageEnvVarName)
-	if image == "" {
-		return nil, common.NewIrrecoverableError(fmt.Errorf("%s environment variable with externalsecrets image not set", externalsecretsImageEnvVarName), "failed to update image in %s deployment object", deployment.GetName())
+	componentName, _, err := getComponentNameFromAsset(assetName)
+	if err != nil {
+		return nil, err
 	}
-	bitwardenImage := os.Getenv(bitwardenImageEnvVarName)
-	if bitwardenImage == "" {
-		return nil, common.NewIrrecoverableError(fmt.Errorf("%s environment variable with bitwarden-sdk-server image not set", bitwardenImageEnvVarName), "failed to update image in %s deployment object", deployment.GetName())
+	image, overridden, err := getComponentImage(esc, componentName)
+	if err != nil {
+		return nil, common.NewIrrecoverableError(err, "failed to update image in %s deployment object", deployment.GetName())
+	}
+	if assetName == bitwardenDeploymentAssetName {
+		deployment.Labels[versionLabelKey] = os.Getenv(bitwardenImageVersionEnvVarName)
+	}
+	if overridden {
+		removeVersionLabel(deployment)
 	}
 	logLevel := getLogLevel(esc, r.esm)
 
@@ -130,8 +195,7 @@ func (r *Reconciler) getDeploymentObject(assetName string, esc *operatorv1alpha1
 	case certControllerDeploymentAssetName:
 		updateCertControllerContainerSpec(deployment, image, logLevel)
 	case bitwardenDeploymentAssetName:
-		deployment.Labels["app.kubernetes.io/version"] = os.Getenv(bitwardenImageVersionEnvVarName)
-		updateBitwardenServerContainerSpec(deployment, bitwardenImage)
+		updateBitwardenServerContainerSpec(deployment, image)
 		updateBitwardenVolumeConfig(deployment, esc)
 	}
 
@@ -150,6 +214,9 @@ func (r *Reconciler) getDeploymentObject(assetName string, esc *operatorv1alpha1
 	if err := r.updateProxyConfiguration(deployment, esc); err != nil {
 		return nil, fmt.Errorf("failed to update proxy configuration: %w", err)
 	}
+	if err := r.updateWorkloadIdentityConfig(deployment, esc, assetName); err != nil {
+		return nil, fmt.Errorf("failed to update workload identity configuration: %w", err)
+	}
 	if err := r.applyUserDeploymentConfigs(deployment, esc, assetName); err != nil {
 		return nil, fmt.Errorf("failed to apply user deployment configuration: %w", err)
 	}
@@ -216,8 +283,8 @@ func (r *Reconciler) updateResourceRequirement(deployment *appsv1.Deployment, es
 	}
 
 	// Validate the resource requirements
-	if err := validateResourceRequirements(rscReqs, field.NewPath("spec")); err != nil {
-		return fmt.Errorf("invalid resource requirements: %w", err)
+	if errs := validateResourceRequirements(rscReqs, field.NewPath("spec")); len(errs) > 0 {
+		return fmt.Errorf("invalid resource requirements: %w", errs.ToAggregate())
 	}
 
 	// Apply the resource requirements to all containers in the pod template
@@ -229,10 +296,10 @@ func (r *Reconciler) updateResourceRequirement(deployment *appsv1.Deployment, es
 }
 
 // validateResourceRequirements validates the resource request/limit configuration.
-func validateResourceRequirements(requirements corev1.ResourceRequirements, fldPath *field.Path) error {
+func validateResourceRequirements(requirements corev1.ResourceRequirements, fldPath *field.Path) field.ErrorList {
 	// convert corev1.ResourceRequirements to core.ResourceRequirements, required for validation.
 	convRequirements := *(*core.ResourceRequirements)(unsafe.Pointer(&requirements))
-	return corevalidation.ValidateContainerResourceRequirements(&convRequirements, nil, fldPath.Child("resources"), corevalidation.PodValidationOptions{}).ToAggregate()
+	return corevalidation.ValidateContainerResourceRequirements(&convRequirements, nil, fldPath.Child("resources"), corevalidation.PodValidationOptions{})
 }
 
 // updateNodeSelector sets and validates node selector constraints.
@@ -249,8 +316,8 @@ func (r *Reconciler) updateNodeSelector(deployment *appsv1.Deployment, esc *oper
 		return nil
 	}
 
-	if err := validateNodeSelectorConfig(nodeSelector, field.NewPath("spec")); err != nil {
-		return err
+	if errs := validateNodeSelectorConfig(nodeSelector, field.NewPath("spec")); len(errs) > 0 {
+		return errs.ToAggregate()
 	}
 
 	deployment.Spec.Template.Spec.NodeSelector = nodeSelector
@@ -271,8 +338,8 @@ func (r *Reconciler) updateAffinityRules(deployment *appsv1.Deployment, esc *ope
 		return nil
 	}
 
-	if err := validateAffinityRules(affinity, field.NewPath("spec", "affinity")); err != nil {
-		return err
+	if errs := validateAffinityRules(affinity, field.NewPath("spec", "affinity")); len(errs) > 0 {
+		return errs.ToAggregate()
 	}
 
 	deployment.Spec.Template.Spec.Affinity = affinity
@@ -293,8 +360,8 @@ func (r *Reconciler) updatePodTolerations(deployment *appsv1.Deployment, esc *op
 		return nil
 	}
 
-	if err := validateTolerationsConfig(tolerations, field.NewPath("spec", "tolerations")); err != nil {
-		return err
+	if errs := validateTolerationsConfig(tolerations, field.NewPath("spec", "tolerations")); len(errs) > 0 {
+		return errs.ToAggregate()
 	}
 
 	deployment.Spec.Template.Spec.Tolerations = tolerations
@@ -302,33 +369,22 @@ func (r *Reconciler) updatePodTolerations(deployment *appsv1.Deployment, esc *op
 }
 
 // validateNodeSelectorConfig validates the NodeSelector configuration.
-func validateNodeSelectorConfig(nodeSelector map[string]string, fldPath *field.Path) error {
-	return metav1validation.ValidateLabels(nodeSelector, fldPath.Child("nodeSelector")).ToAggregate()
+func validateNodeSelectorConfig(nodeSelector map[string]string, fldPath *field.Path) field.ErrorList {
+	return metav1validation.ValidateLabels(nodeSelector, fldPath.Child("nodeSelector"))
 }
 
 // validateAffinityRules validates the Affinity configuration.
-func validateAffinityRules(affinity *corev1.Affinity, fldPath *field.Path) error {
+func validateAffinityRules(affinity *corev1.Affinity, fldPath *field.Path) field.ErrorList {
 	// convert corev1.Affinity to core.Affinity, required for validation.
 	convAffinity := (*core.Affinity)(unsafe.Pointer(affinity))
-	return common.ValidateAffinity(convAffinity, corevalidation.PodValidationOptions{}, fldPath.Child("affinity")).ToAggregate()
+	return common.ValidateAffinity(convAffinity, corevalidation.PodValidationOptions{}, fldPath.Child("affinity"))
 }
 
 // validateTolerationsConfig validates the toleration configuration.
-func validateTolerationsConfig(tolerations []corev1.Toleration, fldPath *field.Path) error {
+func validateTolerationsConfig(tolerations []corev1.Toleration, fldPath *field.Path) field.ErrorList {
 	// convert corev1.Tolerations to core.Tolerations, required for validation.
 	convTolerations := *(*[]core.Toleration)(unsafe.Pointer(&tolerations))
-	return corevalidation.ValidateTolerations(convTolerations, fldPath.Child("tolerations")).ToAggregate()
-}
-
-func (r *Reconciler) updateImageInStatus(esc *operatorv1alpha1.ExternalSecretsConfig) error {
-	externalSecretsImage := os.Getenv(externalsecretsImageEnvVarName)
-	bitwardenImage := os.Getenv(bitwardenImageEnvVarName)
-	if esc.Status.ExternalSecretsImage != externalSecretsImage || esc.Status.BitwardenSDKServerImage != bitwardenImage {
-		esc.Status.ExternalSecretsImage = externalSecretsImage
-		esc.Status.BitwardenSDKServerImage = bitwardenImage
-		return r.updateStatus(r.ctx, esc)
-	}
-	return nil
+	return corevalidation.ValidateTolerations(convTolerations, fldPath.Child("tolerations"))
 }
 
 // argument list for external-secrets deployment resource.
@@ -692,20 +748,34 @@ func (r *Reconciler) applyUserDeploymentConfigs(deployment *appsv1.Deployment, e
 
 	for _, i := range esc.Spec.ControllerConfig.ComponentConfigs {
 		if i.ComponentName == componentName {
-			// Apply RevisionHistoryLimit if set
-			if i.DeploymentConfigs != nil && i.DeploymentConfigs.RevisionHistoryLimit != nil {
-				deployment.Spec.RevisionHistoryLimit = i.DeploymentConfigs.RevisionHistoryLimit
+			var container *corev1.Container
+			for j := range deployment.Spec.Template.Spec.Containers {
+				if deployment.Spec.Template.Spec.Containers[j].Name == containerName {
+					container = &deployment.Spec.Template.Spec.Containers[j]
+					break
+				}
 			}
 
-			// Apply OverrideEnv only to the target component container.
-			if len(i.OverrideEnv) > 0 {
-				for j := range deployment.Spec.Template.Spec.Containers {
-					if deployment.Spec.Template.Spec.Containers[j].Name == containerName {
-						mergeEnvVars(&deployment.Spec.Template.Spec.Containers[j], i.OverrideEnv)
-						break
+			if i.DeploymentConfigs != nil {
+				updateDeploymentRollout(deployment, i.DeploymentConfigs)
+				if container != nil {
+					if err := updateContainerProbes(container, i.DeploymentConfigs); err != nil {
+						return common.NewIrrecoverableError(err, "failed to update probes of %s deployment", deployment.GetName())
 					}
 				}
 			}
+
+			if i.ImagePullPolicy != "" && container != nil {
+				container.ImagePullPolicy = i.ImagePullPolicy
+			}
+			if len(i.ImagePullSecrets) > 0 {
+				deployment.Spec.Template.Spec.ImagePullSecrets = i.ImagePullSecrets
+			}
+
+			// Apply OverrideEnv only to the target component container.
+			if len(i.OverrideEnv) > 0 && container != nil {
+				mergeEnvVars(container, i.OverrideEnv)
+			}
 			break
 		}
 	}
@@ -713,6 +783,62 @@ func (r *Reconciler) applyUserDeploymentConfigs(deployment *appsv1.Deployment, e
 	return nil
 }
 
+// updateDeploymentRollout updates the revision history limit and the rollout configuration of the deployment with
+// the user specified configuration.
+func updateDeploymentRollout(deployment *appsv1.Deployment, config *operatorv1alpha1.DeploymentConfig) {
+	if config.RevisionHistoryLimit != nil {
+		deployment.Spec.RevisionHistoryLimit = config.RevisionHistoryLimit
+	}
+	if config.Strategy != nil {
+		config.Strategy.DeepCopyInto(&deployment.Spec.Strategy)
+	}
+	if config.MinReadySeconds != nil {
+		deployment.Spec.MinReadySeconds = *config.MinReadySeconds
+	}
+	if config.ProgressDeadlineSeconds != nil {
+		deployment.Spec.ProgressDeadlineSeconds = ptr.To(*config.ProgressDeadlineSeconds)
+	}
+}
+
+// updateContainerProbes updates the timing of the probes of the container with the user specified configuration.
+// The liveness and the startup probes missing in the container are added with the handler of the readiness probe.
+func updateContainerProbes(container *corev1.Container, config *operatorv1alpha1.DeploymentConfig) error {
+	probes := []struct {
+		name   string
+		config *operatorv1alpha1.ProbeConfig
+		probe  **corev1.Probe
+	}{
+		{name: "liveness", config: config.LivenessProbe, probe: &container.LivenessProbe},
+		{name: "readiness", config: config.ReadinessProbe, probe: &container.ReadinessProbe},
+		{name: "startup", config: config.StartupProbe, probe: &container.StartupProbe},
+	}
+	for _, p := range probes {
+		if p.config == nil {
+			continue
+		}
+		if *p.probe == nil {
+			if container.ReadinessProbe == nil {
+				return fmt.Errorf("%s probe cannot be configured for %s container without a health endpoint", p.name, container.Name)
+			}
+			*p.probe = &corev1.Probe{ProbeHandler: *container.ReadinessProbe.ProbeHandler.DeepCopy()}
+		}
+		probe := *p.probe
+		if p.config.InitialDelaySeconds != nil {
+			probe.InitialDelaySeconds = *p.config.InitialDelaySeconds
+		}
+		if p.config.PeriodSeconds != nil {
+			probe.PeriodSeconds = *p.config.PeriodSeconds
+		}
+		if p.config.TimeoutSeconds != nil {
+			probe.TimeoutSeconds = *p.config.TimeoutSeconds
+		}
+		if p.config.FailureThreshold != nil {
+			probe.FailureThreshold = *p.config.FailureThreshold
+		}
+	}
+	return nil
+}
+
 // mergeEnvVars merges user-defined environment variables into a container, User-defined values take precedence over existing values.
 func mergeEnvVars(container *corev1.Container, overrideEnv []corev1.EnvVar) {
 	if container.Env == nil {
@@ -11,12 +11,15 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/openshift/external-secrets-operator/api/v1alpha1"
 	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
 	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+	"github.com/openshift/external-secrets-operator/pkg/operator/assets"
 )
 
 const (
@@ -34,7 +37,7 @@ func doesNotExist() func(context.Context, types.NamespacedName, client.Object) (
 // Helper function to set up mock for deployment creation
 func setupDeploymentCreate(m *fakes.FakeCtrlClient, capturedDeployment **appsv1.Deployment, deploymentName string) {
 	m.ExistsCalls(doesNotExist())
-	m.CreateCalls(func(ctx context.Context, obj client.Object, _ ...client.CreateOption) error {
+	m.ApplyCalls(func(ctx context.Context, obj client.Object, _ ...client.ApplyOption) error {
 		switch o := obj.(type) {
 		case *appsv1.Deployment:
 			if o.Name == deploymentName {
@@ -73,7 +76,6 @@ func componentConfigWithRevisionLimit(name v1alpha1.ComponentName, limit *int32)
 // Helper to create ESC update function with component configs
 func escWithComponentConfigs(configs ...v1alpha1.ComponentConfig) func(*v1alpha1.ExternalSecretsConfig) {
 	return func(esc *v1alpha1.ExternalSecretsConfig) {
-		esc.Status.ExternalSecretsImage = commontest.TestExternalSecretsImageName
 		esc.Spec.ControllerConfig.ComponentConfigs = configs
 	}
 }
@@ -97,16 +99,13 @@ func TestCreateOrApplyDeployments(t *testing.T) {
 					}
 					return true, nil
 				})
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, _ ...client.ApplyOption) error {
 					if o, ok := obj.(*appsv1.Deployment); ok {
 						*capturedDeployment = o.DeepCopy()
 					}
 					return nil
 				})
 			},
-			updateExternalSecretsConfig: func(i *v1alpha1.ExternalSecretsConfig) {
-				i.Status.ExternalSecretsImage = commontest.TestExternalSecretsImageName
-			},
 			validateDeployment: func(t *testing.T, deployment *appsv1.Deployment) {
 				// Validate basic deployment structure
 				if deployment == nil {
@@ -138,7 +137,7 @@ func TestCreateOrApplyDeployments(t *testing.T) {
 				})
 			},
 			skipEnvVar: true,
-			wantErr:    `failed to update image in external-secrets deployment object: RELATED_IMAGE_EXTERNAL_SECRETS environment variable with externalsecrets image not set`,
+			wantErr:    `failed to update image in external-secrets-webhook deployment object: RELATED_IMAGE_EXTERNAL_SECRETS environment variable with externalsecrets image not set`,
 		},
 		{
 			name: "deployment reconciliation fails while checking if exists",
@@ -150,7 +149,7 @@ func TestCreateOrApplyDeployments(t *testing.T) {
 					return true, nil
 				})
 			},
-			wantErr: `failed to check external-secrets/external-secrets deployment resource already exists: test client error`,
+			wantErr: `failed to check external-secrets/external-secrets-webhook deployment resource already exists: test client error`,
 		},
 		{
 			name: "deployment reconciliation failed while restoring to desired state",
@@ -163,14 +162,14 @@ func TestCreateOrApplyDeployments(t *testing.T) {
 					}
 					return true, nil
 				})
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, _ ...client.ApplyOption) error {
 					if _, ok := obj.(*appsv1.Deployment); ok {
 						return commontest.ErrTestClient
 					}
 					return nil
 				})
 			},
-			wantErr: `failed to update external-secrets/external-secrets deployment resource: test client error`,
+			wantErr: `failed to update external-secrets/external-secrets-webhook deployment resource: test client error`,
 		},
 		{
 			name: "deployment reconciliation with user custom config successful",
@@ -182,7 +181,7 @@ func TestCreateOrApplyDeployments(t *testing.T) {
 					}
 					return true, nil
 				})
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, _ ...client.ApplyOption) error {
 					if o, ok := obj.(*appsv1.Deployment); ok {
 						*capturedDeployment = o.DeepCopy()
 					}
@@ -370,7 +369,7 @@ func TestCreateOrApplyDeployments(t *testing.T) {
 					}
 					return true, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if o, ok := obj.(*appsv1.Deployment); ok {
 						*capturedDeployment = o.DeepCopy()
 						deployment := testDeployment(controllerDeploymentAssetName)
@@ -536,7 +535,7 @@ func TestCreateOrApplyDeployments(t *testing.T) {
 					}
 					return true, nil
 				})
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, _ ...client.ApplyOption) error {
 					if o, ok := obj.(*appsv1.Deployment); ok {
 						*capturedDeployment = o.DeepCopy()
 					}
@@ -626,7 +625,6 @@ func TestCreateOrApplyDeployments(t *testing.T) {
 				setupDeploymentCreate(m, d, "external-secrets")
 			},
 			updateExternalSecretsConfig: func(esc *v1alpha1.ExternalSecretsConfig) {
-				esc.Status.ExternalSecretsImage = commontest.TestExternalSecretsImageName
 				esc.Spec.ControllerConfig.ComponentConfigs = []v1alpha1.ComponentConfig{{ComponentName: v1alpha1.CoreController, DeploymentConfigs: nil}}
 			},
 			validateDeployment: validateRevisionHistory(10),
@@ -635,7 +633,7 @@ func TestCreateOrApplyDeployments(t *testing.T) {
 			name: "multiple components with mixed revisionHistoryLimit configurations",
 			preReq: func(r *Reconciler, m *fakes.FakeCtrlClient, d **appsv1.Deployment) {
 				m.ExistsCalls(doesNotExist())
-				m.CreateCalls(func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+				m.ApplyCalls(func(_ context.Context, obj client.Object, _ ...client.ApplyOption) error {
 					if dep, ok := obj.(*appsv1.Deployment); ok && dep.Name == "external-secrets-webhook" {
 						*d = dep.DeepCopy()
 					}
@@ -682,8 +680,9 @@ func TestCreateOrApplyDeployments(t *testing.T) {
 				t.Errorf("createOrApplyDeployments() err: %v, wantErr: %v", err, tt.wantErr)
 			}
 
-			if tt.wantErr == "" && externalsecrets.Status.ExternalSecretsImage != commontest.TestExternalSecretsImageName {
-				t.Errorf("createOrApplyDeployments() got image in status: %v, want: %v", externalsecrets.Status.ExternalSecretsImage, commontest.TestExternalSecretsImageName)
+			if tt.wantErr == "" && (len(externalsecrets.Status.ComponentImages) == 0 ||
+				externalsecrets.Status.ComponentImages[0].Image != commontest.TestExternalSecretsImageName) {
+				t.Errorf("createOrApplyDeployments() got images in status: %v, want: %v", externalsecrets.Status.ComponentImages, commontest.TestExternalSecretsImageName)
 			}
 
 			// Validate deployment changes if validation function is provided
@@ -1756,3 +1755,164 @@ func TestApplyUserDeploymentConfigsWithOverrideEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyUserDeploymentConfigsRolloutAndProbes(t *testing.T) {
+	tests := []struct {
+		name             string
+		assetName        string
+		deploymentConfig *v1alpha1.DeploymentConfig
+		componentName    v1alpha1.ComponentName
+		wantErr          bool
+		verify           func(*testing.T, *appsv1.Deployment)
+	}{
+		{
+			name:          "rollout configuration rendered",
+			assetName:     webhookDeploymentAssetName,
+			componentName: v1alpha1.Webhook,
+			deploymentConfig: &v1alpha1.DeploymentConfig{
+				Strategy:                &appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+				MinReadySeconds:         ptr.To(int32(10)),
+				ProgressDeadlineSeconds: ptr.To(int32(300)),
+			},
+			verify: func(t *testing.T, d *appsv1.Deployment) {
+				if d.Spec.Strategy.Type != appsv1.RecreateDeploymentStrategyType {
+					t.Errorf("strategy type = %q, want %q", d.Spec.Strategy.Type, appsv1.RecreateDeploymentStrategyType)
+				}
+				if d.Spec.MinReadySeconds != 10 {
+					t.Errorf("minReadySeconds = %d, want 10", d.Spec.MinReadySeconds)
+				}
+				if d.Spec.ProgressDeadlineSeconds == nil || *d.Spec.ProgressDeadlineSeconds != 300 {
+					t.Errorf("progressDeadlineSeconds = %v, want 300", d.Spec.ProgressDeadlineSeconds)
+				}
+			},
+		},
+		{
+			name:          "startup probe added with the readiness endpoint",
+			assetName:     webhookDeploymentAssetName,
+			componentName: v1alpha1.Webhook,
+			deploymentConfig: &v1alpha1.DeploymentConfig{
+				ReadinessProbe: &v1alpha1.ProbeConfig{PeriodSeconds: ptr.To(int32(20))},
+				StartupProbe:   &v1alpha1.ProbeConfig{PeriodSeconds: ptr.To(int32(10)), FailureThreshold: ptr.To(int32(30))},
+			},
+			verify: func(t *testing.T, d *appsv1.Deployment) {
+				container := d.Spec.Template.Spec.Containers[0]
+				if container.ReadinessProbe.PeriodSeconds != 20 {
+					t.Errorf("readiness probe periodSeconds = %d, want 20", container.ReadinessProbe.PeriodSeconds)
+				}
+				startup := container.StartupProbe
+				if startup == nil || startup.HTTPGet == nil || startup.HTTPGet.Path != container.ReadinessProbe.HTTPGet.Path {
+					t.Fatalf("startup probe = %+v, want the readiness probe endpoint", startup)
+				}
+				if startup.PeriodSeconds != 10 || startup.FailureThreshold != 30 {
+					t.Errorf("startup probe periodSeconds = %d, failureThreshold = %d, want 10 and 30", startup.PeriodSeconds, startup.FailureThreshold)
+				}
+				if container.LivenessProbe != nil {
+					t.Errorf("liveness probe = %+v, want not added", container.LivenessProbe)
+				}
+			},
+		},
+		{
+			name:          "probe of component without health endpoint rejected",
+			assetName:     controllerDeploymentAssetName,
+			componentName: v1alpha1.CoreController,
+			deploymentConfig: &v1alpha1.DeploymentConfig{
+				LivenessProbe: &v1alpha1.ProbeConfig{PeriodSeconds: ptr.To(int32(20))},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			deployment := common.DecodeDeploymentObjBytes(assets.MustAsset(tt.assetName))
+			esc := &v1alpha1.ExternalSecretsConfig{
+				Spec: v1alpha1.ExternalSecretsConfigSpec{
+					ControllerConfig: v1alpha1.ControllerConfig{
+						ComponentConfigs: []v1alpha1.ComponentConfig{
+							{ComponentName: tt.componentName, DeploymentConfigs: tt.deploymentConfig},
+						},
+					},
+				},
+			}
+
+			err := r.applyUserDeploymentConfigs(deployment, esc, tt.assetName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyUserDeploymentConfigs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.verify != nil {
+				tt.verify(t, deployment)
+			}
+		})
+	}
+}
+
+func TestDeploymentRolloutAndProbesDrift(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*appsv1.Deployment)
+		want   bool
+	}{
+		{
+			name: "strategy and probe fields defaulted by the API server are not a drift",
+			modify: func(d *appsv1.Deployment) {
+				d.Spec.Strategy.RollingUpdate.MaxUnavailable = ptr.To(intstr.FromString("25%"))
+				d.Spec.Template.Spec.Containers[0].ReadinessProbe.SuccessThreshold = 1
+				d.Spec.Template.Spec.Containers[0].ReadinessProbe.TimeoutSeconds = 1
+			},
+		},
+		{
+			name: "strategy modified",
+			modify: func(d *appsv1.Deployment) {
+				d.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+			},
+			want: true,
+		},
+		{
+			name: "probe timing modified",
+			modify: func(d *appsv1.Deployment) {
+				d.Spec.Template.Spec.Containers[0].ReadinessProbe.PeriodSeconds = 10
+			},
+			want: true,
+		},
+		{
+			name: "probe removed",
+			modify: func(d *appsv1.Deployment) {
+				d.Spec.Template.Spec.Containers[0].StartupProbe = nil
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			desired := common.DecodeDeploymentObjBytes(assets.MustAsset(webhookDeploymentAssetName))
+			esc := &v1alpha1.ExternalSecretsConfig{
+				Spec: v1alpha1.ExternalSecretsConfigSpec{
+					ControllerConfig: v1alpha1.ControllerConfig{
+						ComponentConfigs: []v1alpha1.ComponentConfig{
+							{ComponentName: v1alpha1.Webhook, DeploymentConfigs: &v1alpha1.DeploymentConfig{
+								Strategy: &appsv1.DeploymentStrategy{
+									Type:          appsv1.RollingUpdateDeploymentStrategyType,
+									RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: ptr.To(intstr.FromInt32(1))},
+								},
+								ReadinessProbe: &v1alpha1.ProbeConfig{PeriodSeconds: ptr.To(int32(20))},
+								StartupProbe:   &v1alpha1.ProbeConfig{FailureThreshold: ptr.To(int32(30))},
+							}},
+						},
+					},
+				},
+			}
+			if err := r.applyUserDeploymentConfigs(desired, esc, webhookDeploymentAssetName); err != nil {
+				t.Fatalf("applyUserDeploymentConfigs() unexpected error: %v", err)
+			}
+
+			fetched := testApplied(desired.DeepCopy())
+			tt.modify(fetched)
+			if got := r.hasObjectDrifted(desired, fetched); got != tt.want {
+				t.Errorf("hasObjectDrifted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
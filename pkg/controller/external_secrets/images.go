@@ -0,0 +1,88 @@
+package external_secrets
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+)
+
+// getComponentImage returns the image of the component, which is the image overridden in the component
+// configuration, or the image released with the operator and set in the environment variable of the operator.
+// Returns whether the image is overridden.
+func getComponentImage(esc *operatorv1alpha1.ExternalSecretsConfig, componentName operatorv1alpha1.ComponentName) (string, bool, error) {
+	for _, config := range esc.Spec.ControllerConfig.ComponentConfigs {
+		if config.ComponentName == componentName && config.Image != "" {
+			return config.Image, true, nil
+		}
+	}
+
+	envVarName, imageName := externalsecretsImageEnvVarName, "externalsecrets"
+	if componentName == operatorv1alpha1.BitwardenSDKServer {
+		envVarName, imageName = bitwardenImageEnvVarName, "bitwarden-sdk-server"
+	}
+	image := os.Getenv(envVarName)
+	if image == "" {
+		return "", false, fmt.Errorf("%s environment variable with %s image not set", envVarName, imageName)
+	}
+	return image, false, nil
+}
+
+// removeVersionLabel removes the app.kubernetes.io/version label from the deployment and its pod template, as the
+// version of an overridden image is not known to the operator.
+func removeVersionLabel(deployment *appsv1.Deployment) {
+	delete(deployment.Labels, versionLabelKey)
+	delete(deployment.Spec.Template.Labels, versionLabelKey)
+}
+
+// updateImageInStatus sets status.componentImages with the images of the external-secrets components deployed, and
+// the UnsupportedImage condition, which is removed when no image is overridden. The status is updated when changed.
+func (r *Reconciler) updateImageInStatus(esc *operatorv1alpha1.ExternalSecretsConfig) error {
+	var images []operatorv1alpha1.ComponentImageStatus
+	var overridden []string
+	for _, assetName := range getDeploymentAssetNames(esc) {
+		componentName, _, err := getComponentNameFromAsset(assetName)
+		if err != nil {
+			return err
+		}
+		image, isOverridden, err := getComponentImage(esc, componentName)
+		if err != nil {
+			return common.NewIrrecoverableError(err, "failed to get image of %s component", componentName)
+		}
+		if isOverridden {
+			overridden = append(overridden, string(componentName))
+		}
+		images = append(images, operatorv1alpha1.ComponentImageStatus{
+			ComponentName: componentName,
+			Image:         image,
+			Overridden:    isOverridden,
+		})
+	}
+	changed := !reflect.DeepEqual(esc.Status.ComponentImages, images)
+	esc.Status.ComponentImages = images
+
+	if len(overridden) == 0 {
+		changed = apimeta.RemoveStatusCondition(&esc.Status.Conditions, operatorv1alpha1.UnsupportedImage) || changed
+	} else {
+		changed = apimeta.SetStatusCondition(&esc.Status.Conditions, metav1.Condition{
+			Type:   operatorv1alpha1.UnsupportedImage,
+			Status: metav1.ConditionTrue,
+			Reason: operatorv1alpha1.ReasonOverridden,
+			Message: fmt.Sprintf("images of the components %s are overridden, which is not a supported configuration",
+				strings.Join(overridden, ", ")),
+			ObservedGeneration: esc.GetGeneration(),
+		}) || changed
+	}
+
+	if !changed {
+		return nil
+	}
+	return r.updateStatus(r.ctx, esc)
+}
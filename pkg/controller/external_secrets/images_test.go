@@ -0,0 +1,110 @@
+package external_secrets
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+const testImageDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestGetDeploymentObjectImageOverride(t *testing.T) {
+	overrideImage := "registry.example.com/mirror/external-secrets@" + testImageDigest
+	r := testReconciler(t)
+	esc := commontest.TestExternalSecretsConfig()
+	esc.Spec.ControllerConfig.ComponentConfigs = []operatorv1alpha1.ComponentConfig{
+		{
+			ComponentName:    operatorv1alpha1.Webhook,
+			Image:            overrideImage,
+			ImagePullPolicy:  corev1.PullAlways,
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "mirror-pull-secret"}},
+		},
+	}
+	// the image released with the operator is not required for the component with the image overridden.
+	t.Setenv(externalsecretsImageEnvVarName, "")
+	t.Setenv(bitwardenImageEnvVarName, "")
+
+	deployment, err := r.getDeploymentObject(webhookDeploymentAssetName, esc, testResourceMetadata(esc))
+	if err != nil {
+		t.Fatalf("getDeploymentObject() unexpected error: %v", err)
+	}
+	container := deployment.Spec.Template.Spec.Containers[0]
+	if container.Image != overrideImage || container.ImagePullPolicy != corev1.PullAlways {
+		t.Errorf("container image = %s with pull policy %s, want %s with %s", container.Image, container.ImagePullPolicy, overrideImage, corev1.PullAlways)
+	}
+	if secrets := deployment.Spec.Template.Spec.ImagePullSecrets; len(secrets) != 1 || secrets[0].Name != "mirror-pull-secret" {
+		t.Errorf("imagePullSecrets = %v, want mirror-pull-secret", secrets)
+	}
+	if version, ok := deployment.Labels[versionLabelKey]; ok {
+		t.Errorf("deployment label %s = %s, want no version for the overridden image", versionLabelKey, version)
+	}
+	if version, ok := deployment.Spec.Template.Labels[versionLabelKey]; ok {
+		t.Errorf("pod template label %s = %s, want no version for the overridden image", versionLabelKey, version)
+	}
+
+	if _, err := r.getDeploymentObject(controllerDeploymentAssetName, esc, testResourceMetadata(esc)); err == nil ||
+		!strings.Contains(err.Error(), "RELATED_IMAGE_EXTERNAL_SECRETS environment variable with externalsecrets image not set") {
+		t.Errorf("getDeploymentObject() err: %v, want the image environment variable not set", err)
+	}
+}
+
+func TestUpdateImageInStatus(t *testing.T) {
+	overrideImage := "registry.example.com/mirror/bitwarden-sdk-server@" + testImageDigest
+	r := testReconciler(t)
+	mock := &fakes.FakeCtrlClient{}
+	r.CtrlClient = mock
+	t.Setenv(externalsecretsImageEnvVarName, commontest.TestExternalSecretsImageName)
+	t.Setenv(bitwardenImageEnvVarName, commontest.TestBitwardenImageName)
+
+	esc := commontest.TestExternalSecretsConfig()
+	esc.Spec.Plugins.BitwardenSecretManagerProvider = &operatorv1alpha1.BitwardenSecretManagerProvider{
+		Mode: operatorv1alpha1.Enabled,
+	}
+	esc.Spec.ControllerConfig.ComponentConfigs = []operatorv1alpha1.ComponentConfig{
+		{ComponentName: operatorv1alpha1.BitwardenSDKServer, Image: overrideImage},
+	}
+
+	if err := r.updateImageInStatus(esc); err != nil {
+		t.Fatalf("updateImageInStatus() unexpected error: %v", err)
+	}
+	want := map[operatorv1alpha1.ComponentName]string{
+		operatorv1alpha1.CoreController:     commontest.TestExternalSecretsImageName,
+		operatorv1alpha1.Webhook:            commontest.TestExternalSecretsImageName,
+		operatorv1alpha1.CertController:     commontest.TestExternalSecretsImageName,
+		operatorv1alpha1.BitwardenSDKServer: overrideImage,
+	}
+	if len(esc.Status.ComponentImages) != len(want) {
+		t.Fatalf("status.componentImages = %+v, want %v", esc.Status.ComponentImages, want)
+	}
+	for _, image := range esc.Status.ComponentImages {
+		if image.Image != want[image.ComponentName] || image.Overridden != (image.ComponentName == operatorv1alpha1.BitwardenSDKServer) {
+			t.Errorf("status.componentImages entry %+v, want image %s", image, want[image.ComponentName])
+		}
+	}
+	cond := apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.UnsupportedImage)
+	if cond == nil || cond.Reason != operatorv1alpha1.ReasonOverridden || !strings.Contains(cond.Message, string(operatorv1alpha1.BitwardenSDKServer)) {
+		t.Errorf("%s condition = %+v, want the bitwarden-sdk-server image overridden", operatorv1alpha1.UnsupportedImage, cond)
+	}
+
+	updates := mock.StatusUpdateCallCount()
+	if err := r.updateImageInStatus(esc); err != nil {
+		t.Fatalf("updateImageInStatus() unexpected error: %v", err)
+	}
+	if mock.StatusUpdateCallCount() != updates {
+		t.Errorf("updateImageInStatus() expected no status update for the same images")
+	}
+
+	esc.Spec.ControllerConfig.ComponentConfigs = nil
+	if err := r.updateImageInStatus(esc); err != nil {
+		t.Fatalf("updateImageInStatus() unexpected error: %v", err)
+	}
+	if apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.UnsupportedImage) != nil {
+		t.Errorf("expected %s condition to be removed when no image is overridden", operatorv1alpha1.UnsupportedImage)
+	}
+}
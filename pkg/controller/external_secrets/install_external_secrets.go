@@ -13,6 +13,7 @@ import (
 
 	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
 	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+	"github.com/openshift/external-secrets-operator/pkg/metrics"
 )
 
 var (
@@ -30,9 +31,18 @@ var (
 // That order ensures we never advance tracking on the CR before obsolete annotations have been
 // removed from resources (e.g. spec a,b→c,d: we remove a,b from resources first, then patch CR).
 func (r *Reconciler) reconcileExternalSecretsDeployment(esc *operatorv1alpha1.ExternalSecretsConfig, recon bool) error {
+	timer := metrics.NewPhaseTimer()
+
 	if err := r.validateExternalSecretsConfig(esc); err != nil {
 		return common.NewIrrecoverableError(err, "%s/%s configuration validation failed", esc.GetObjectKind().GroupVersionKind().String(), esc.GetName())
 	}
+	timer.ObservePhase("validate")
+
+	if err := r.reconcileClusterWorkloadIdentity(esc); err != nil {
+		r.log.Error(err, "failed to derive workload identity configuration from cluster configuration")
+		return err
+	}
+	timer.ObservePhase("cluster_workload_identity")
 
 	resourceMetadata, err := r.getResourceMetadata(esc)
 	if err != nil {
@@ -44,55 +54,135 @@ func (r *Reconciler) reconcileExternalSecretsDeployment(esc *operatorv1alpha1.Ex
 		r.log.Error(err, "failed to create namespace")
 		return err
 	}
+	timer.ObservePhase("namespace")
 
 	if err := r.createOrApplyNetworkPolicies(esc, resourceMetadata, recon); err != nil {
 		r.log.Error(err, "failed to reconcile network policy resource")
 		return err
 	}
+	timer.ObservePhase("network_policies")
 
 	if err := r.createOrApplyServiceAccounts(esc, resourceMetadata, recon); err != nil {
 		r.log.Error(err, "failed to reconcile serviceaccount resource")
 		return err
 	}
+	timer.ObservePhase("service_accounts")
 
 	if err := r.createOrApplyCertificates(esc, resourceMetadata, recon); err != nil {
 		r.log.Error(err, "failed to reconcile certificates resource")
 		return err
 	}
+	timer.ObservePhase("certificates")
 
 	if err := r.createOrApplySecret(esc, resourceMetadata, recon); err != nil {
 		r.log.Error(err, "failed to reconcile secret resource")
 		return err
 	}
+	timer.ObservePhase("secrets")
 
 	if err := r.ensureTrustedCABundleConfigMap(esc, resourceMetadata); err != nil {
 		r.log.Error(err, "failed to ensure trusted CA bundle ConfigMap")
 		return err
 	}
+	timer.ObservePhase("trusted_ca_bundle")
+
+	if err := r.createOrApplyWorkloadIdentityConfigMaps(esc, resourceMetadata); err != nil {
+		r.log.Error(err, "failed to reconcile workload identity configmap resources")
+		return err
+	}
+	timer.ObservePhase("workload_identity_configmaps")
+
+	if err := r.createOrApplyCredentialsRequests(esc, resourceMetadata); err != nil {
+		r.log.Error(err, "failed to reconcile credentialsrequest resources")
+		return err
+	}
+	timer.ObservePhase("credentials_requests")
 
 	if err := r.createOrApplyRBACResource(esc, resourceMetadata, recon); err != nil {
 		r.log.Error(err, "failed to reconcile rbac resources")
 		return err
 	}
+	timer.ObservePhase("rbac")
 
 	if err := r.createOrApplyServices(esc, resourceMetadata, recon); err != nil {
 		r.log.Error(err, "failed to reconcile service resource")
 		return err
 	}
+	timer.ObservePhase("services")
+
+	if err := r.createOrApplyMonitoring(esc, resourceMetadata); err != nil {
+		r.log.Error(err, "failed to reconcile monitoring resources")
+		return err
+	}
+	timer.ObservePhase("monitoring")
+
+	if err := r.validatePodSecurity(esc, resourceMetadata); err != nil {
+		r.log.Error(err, "failed to verify pod security of deployment pods")
+		return err
+	}
+	timer.ObservePhase("pod_security")
 
 	if err := r.createOrApplyDeployments(esc, resourceMetadata, recon); err != nil {
 		r.log.Error(err, "failed to reconcile deployment resource")
 		return err
 	}
+	timer.ObservePhase("deployments")
+
+	if err := r.createOrApplyAutoscalers(esc, resourceMetadata, recon); err != nil {
+		r.log.Error(err, "failed to reconcile horizontalpodautoscaler resources")
+		return err
+	}
+	timer.ObservePhase("autoscalers")
 
 	if err := r.createOrApplyValidatingWebhookConfiguration(esc, resourceMetadata, recon); err != nil {
 		r.log.Error(err, "failed to reconcile validating webhook resource")
 		return err
 	}
+	timer.ObservePhase("validating_webhooks")
+
+	if err := r.createOrApplyBootstrapClusterSecretStores(esc, resourceMetadata); err != nil {
+		r.log.Error(err, "failed to reconcile bootstrap clustersecretstore resources")
+		return err
+	}
+	timer.ObservePhase("bootstrap_cluster_secret_stores")
 
 	if err := r.updateCRAnnotationsIfNeeded(esc, resourceMetadata); err != nil {
 		return err
 	}
+	timer.ObservePhase("annotations")
+
+	// cloud credentials are minted asynchronously by the Cloud Credential Operator, availability is checked
+	// after all the resources are reconciled for not delaying the operand deployment.
+	if err := r.checkCloudCredentialsAvailable(esc); err != nil {
+		return err
+	}
+	timer.ObservePhase("cloud_credentials")
+
+	// Ready condition is set only when all the enabled components are serving, the availability is checked before
+	// the optional checks for reporting the components status after every reconciliation.
+	if err := r.checkComponentsAvailable(esc); err != nil {
+		return err
+	}
+	timer.ObservePhase("components_availability")
+
+	// webhook health is only reported and does not fail the reconciliation.
+	if err := r.checkWebhookHealth(esc); err != nil {
+		return err
+	}
+	timer.ObservePhase("webhook_health")
+
+	// objects of the external-secrets CRDs are migrated to the storage version after the webhook is verified, as the
+	// objects are rewritten through the webhook.
+	if err := r.migrateStorageVersions(esc); err != nil {
+		return err
+	}
+	timer.ObservePhase("storage_version_migration")
+
+	// upgrade blockers are only reported and do not fail the reconciliation.
+	if err := r.checkUpgradeable(esc); err != nil {
+		return err
+	}
+	timer.ObservePhase("upgradeable")
 
 	r.log.V(4).Info("finished reconciliation of external-secrets", "namespace", esc.GetNamespace(), "name", esc.GetName())
 	return nil
@@ -152,6 +242,13 @@ func (r *Reconciler) createOrApplyNamespace(esc *operatorv1alpha1.ExternalSecret
 		},
 	}
 
+	// namespace labels configured are applied only on the namespace, in addition to the common resource labels.
+	resourceMetadata.Labels = maps.Clone(resourceMetadata.Labels)
+	if resourceMetadata.Labels == nil {
+		resourceMetadata.Labels = make(map[string]string)
+	}
+	maps.Copy(resourceMetadata.Labels, podSecurityNamespaceLabels(esc))
+
 	common.ApplyResourceMetadata(desired, resourceMetadata)
 
 	fetched := &corev1.Namespace{}
@@ -160,15 +257,19 @@ func (r *Reconciler) createOrApplyNamespace(esc *operatorv1alpha1.ExternalSecret
 		return fmt.Errorf("failed to check if namespace %s exists: %w", namespaceName, err)
 	}
 
+	// the namespace may be pre-created by the users with their own labels and annotations, which are preserved as
+	// only the fields applied by the operator are updated.
 	switch {
 	case !exists:
 		r.log.V(4).Info("Creating namespace", "name", namespaceName)
-		if err := r.Create(r.ctx, desired); err != nil {
+		if err := r.Apply(r.ctx, desired); err != nil {
 			return fmt.Errorf("failed to create namespace %s: %w", namespaceName, err)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "Namespace %s created", namespaceName)
-	case r.resourceMetadataNeedsUpdate(fetched, resourceMetadata):
-		if err := r.UpdateWithRetry(r.ctx, fetched); err != nil {
+	case r.hasObjectDrifted(desired, fetched):
+		r.log.V(1).Info("Namespace metadata changed, updating", "name", namespaceName)
+		common.RemoveObsoleteAnnotations(desired, resourceMetadata)
+		if err := r.Apply(r.ctx, desired); err != nil {
 			return fmt.Errorf("failed to update namespace %s: %w", namespaceName, err)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "Namespace %s updated", namespaceName)
@@ -179,63 +280,6 @@ func (r *Reconciler) createOrApplyNamespace(esc *operatorv1alpha1.ExternalSecret
 	return nil
 }
 
-func (r *Reconciler) resourceMetadataNeedsUpdate(existing *corev1.Namespace, resourceMetadata common.ResourceMetadata) bool {
-	needsUpdate := false
-	if namespaceLabelsNeedUpdate(existing, resourceMetadata.Labels) {
-		needsUpdate = true
-		r.log.V(1).Info("Namespace labels changed, updating", "name", existing.GetName())
-		// Merge existing labels with desired labels (desired labels take precedence)
-		if existing.Labels == nil {
-			existing.Labels = make(map[string]string)
-		}
-		maps.Copy(existing.Labels, resourceMetadata.Labels)
-	}
-
-	if namespaceAnnotationsNeedUpdate(existing, resourceMetadata.Annotations) || len(resourceMetadata.DeletedAnnotationKeys) != 0 {
-		needsUpdate = true
-		r.log.V(1).Info("Namespace annotations changed, updating", "name", existing.GetName())
-		if existing.Annotations == nil {
-			existing.Annotations = make(map[string]string)
-		}
-		common.UpdateResourceAnnotations(existing, resourceMetadata.Annotations)
-		common.RemoveObsoleteAnnotations(existing, resourceMetadata)
-	}
-
-	return needsUpdate
-}
-
-// namespaceLabelsNeedUpdate checks if any of the desired labels are missing or different
-// in the existing namespace. This is different from ObjectMetadataModified because namespaces
-// may be pre-created by users with their own labels that should be preserved.
-func namespaceLabelsNeedUpdate(existing *corev1.Namespace, desiredLabels map[string]string) bool {
-	if existing.Labels == nil && len(desiredLabels) > 0 {
-		return true
-	}
-	for k, v := range desiredLabels {
-		if existing.Labels[k] != v {
-			return true
-		}
-	}
-	return false
-}
-
-// namespaceAnnotationsNeedUpdate checks if any of the desired annotations are missing or different
-// in the existing namespace. This is different from ObjectMetadataModified because namespaces
-// may be pre-created by users with their own annotations that should be preserved.
-func namespaceAnnotationsNeedUpdate(existing *corev1.Namespace, desiredAnnots map[string]string) bool {
-	if existing.Annotations == nil && len(desiredAnnots) > 0 {
-		return true
-	}
-
-	for k, v := range desiredAnnots {
-		if existing.Annotations[k] != v {
-			return true
-		}
-	}
-
-	return false
-}
-
 // GetResourceMetadata builds the labels and annotations to apply to all operand resources,
 // and computes which annotation keys were previously managed but are no longer in spec
 // (DeletedAnnotationKeys) so downstream logic can remove them from resources.
@@ -35,8 +35,7 @@ func TestCreateOrApplyNamespace(t *testing.T) {
 		resourceMetadata common.ResourceMetadata
 		preReq           func(*Reconciler, *fakes.FakeCtrlClient)
 		wantErr          string
-		wantCreate       bool
-		wantUpdate       bool
+		wantApply        bool
 	}{
 		{
 			name:             "namespace created successfully when it does not exist",
@@ -45,7 +44,7 @@ func TestCreateOrApplyNamespace(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					ns, ok := obj.(*corev1.Namespace)
 					if !ok {
 						t.Errorf("expected Namespace object, got %T", obj)
@@ -62,7 +61,7 @@ func TestCreateOrApplyNamespace(t *testing.T) {
 					return nil
 				})
 			},
-			wantCreate: true,
+			wantApply: true,
 		},
 		{
 			name:             "namespace exists with same labels, no update needed",
@@ -76,13 +75,17 @@ func TestCreateOrApplyNamespace(t *testing.T) {
 								Labels: controllerDefaultResourceLabels,
 							},
 						}
-						existing.DeepCopyInto(namespace)
+						testApplied(existing).DeepCopyInto(namespace)
 						return true, nil
 					}
 					return false, nil
 				})
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
+					t.Errorf("Apply was called unexpectedly for %s", obj.GetName())
+					return nil
+				})
 			},
-			wantUpdate: false,
+			wantApply: false,
 		},
 		{
 			name:             "namespace exists with different labels, update triggered",
@@ -102,7 +105,7 @@ func TestCreateOrApplyNamespace(t *testing.T) {
 					}
 					return false, nil
 				})
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					ns, ok := obj.(*corev1.Namespace)
 					if !ok {
 						t.Errorf("expected Namespace object, got %T", obj)
@@ -116,10 +119,10 @@ func TestCreateOrApplyNamespace(t *testing.T) {
 					return nil
 				})
 			},
-			wantUpdate: true,
+			wantApply: true,
 		},
 		{
-			name: "namespace exists with different annotations, update triggered and obsolete annotations removed",
+			name: "namespace exists with different annotations, update triggered without the annotations of other field managers",
 			resourceMetadata: common.ResourceMetadata{
 				Labels:                controllerDefaultResourceLabels,
 				Annotations:           map[string]string{"example.com/team": "platform"},
@@ -143,7 +146,7 @@ func TestCreateOrApplyNamespace(t *testing.T) {
 					}
 					return false, nil
 				})
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					ns, ok := obj.(*corev1.Namespace)
 					if !ok {
 						t.Errorf("expected Namespace object, got %T", obj)
@@ -152,21 +155,21 @@ func TestCreateOrApplyNamespace(t *testing.T) {
 					if ns.Annotations["example.com/team"] != "platform" {
 						t.Errorf("expected annotation example.com/team=platform, got %q", ns.Annotations["example.com/team"])
 					}
-					// Obsolete key removed by RemoveObsoleteAnnotations
+					// Obsolete key is not applied, and is removed from the fields owned by the operator
 					if _, has := ns.Annotations["obsolete-key"]; has {
-						t.Error("expected obsolete-key to be removed from namespace annotations")
+						t.Error("expected obsolete-key to not be applied in namespace annotations")
 					}
-					// Other existing annotation preserved (UpdateResourceAnnotations merges)
-					if ns.Annotations["other"] != "preserved" {
-						t.Errorf("expected annotation other=preserved, got %q", ns.Annotations["other"])
+					// Other existing annotation is not applied, and is preserved by server-side apply
+					if _, has := ns.Annotations["other"]; has {
+						t.Error("expected annotation other to not be applied in namespace annotations")
 					}
 					return nil
 				})
 			},
-			wantUpdate: true,
+			wantApply: true,
 		},
 		{
-			name: "existing labels are preserved while adding new labels",
+			name: "existing labels are not applied while adding new labels",
 			resourceMetadata: common.ResourceMetadata{Labels: map[string]string{
 				"new-label": "new-value",
 			}},
@@ -186,14 +189,14 @@ func TestCreateOrApplyNamespace(t *testing.T) {
 					}
 					return false, nil
 				})
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					ns, ok := obj.(*corev1.Namespace)
 					if !ok {
 						t.Errorf("expected Namespace object, got %T", obj)
 					}
-					// Verify existing label is preserved
-					if ns.Labels["existing-label"] != "existing-value" {
-						t.Errorf("expected existing-label=existing-value, got %s", ns.Labels["existing-label"])
+					// Verify existing label is not applied, and is preserved by server-side apply
+					if _, has := ns.Labels["existing-label"]; has {
+						t.Error("expected existing-label to not be applied in namespace labels")
 					}
 					// Verify new label is added
 					if ns.Labels["new-label"] != "new-value" {
@@ -202,7 +205,7 @@ func TestCreateOrApplyNamespace(t *testing.T) {
 					return nil
 				})
 			},
-			wantUpdate: true,
+			wantApply: true,
 		},
 		{
 			name: "resource labels override existing labels with same key",
@@ -225,7 +228,7 @@ func TestCreateOrApplyNamespace(t *testing.T) {
 					}
 					return false, nil
 				})
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					ns, ok := obj.(*corev1.Namespace)
 					if !ok {
 						t.Errorf("expected Namespace object, got %T", obj)
@@ -237,7 +240,7 @@ func TestCreateOrApplyNamespace(t *testing.T) {
 					return nil
 				})
 			},
-			wantUpdate: true,
+			wantApply: true,
 		},
 		{
 			name:             "exists check fails",
@@ -256,7 +259,7 @@ func TestCreateOrApplyNamespace(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					return commontest.ErrTestClient
 				})
 			},
@@ -279,7 +282,7 @@ func TestCreateOrApplyNamespace(t *testing.T) {
 					}
 					return false, nil
 				})
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					return commontest.ErrTestClient
 				})
 			},
@@ -307,11 +310,8 @@ func TestCreateOrApplyNamespace(t *testing.T) {
 				t.Errorf("createOrApplyNamespace() unexpected error: %v", err)
 			}
 
-			if tt.wantCreate && mock.CreateCallCount() == 0 {
-				t.Error("expected Create to be called, but it wasn't")
-			}
-			if tt.wantUpdate && mock.UpdateWithRetryCallCount() == 0 {
-				t.Error("expected UpdateWithRetry to be called, but it wasn't")
+			if tt.wantApply && mock.ApplyCallCount() == 0 {
+				t.Error("expected Apply to be called, but it wasn't")
 			}
 		})
 	}
@@ -0,0 +1,206 @@
+package external_secrets
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+)
+
+// unstructuredList returns an empty unstructured list of the kind.
+func unstructuredList(gvk schema.GroupVersionKind) *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	return list
+}
+
+// processUnmanagedRequest reports the availability of the external-secrets components in the status, without
+// creating, modifying or deleting any of the resources created for the external-secrets deployment.
+func (r *Reconciler) processUnmanagedRequest(esc *operatorv1alpha1.ExternalSecretsConfig, req types.NamespacedName) (ctrl.Result, error) {
+	readyCond := metav1.Condition{
+		Type:               operatorv1alpha1.Ready,
+		Status:             metav1.ConditionTrue,
+		Reason:             operatorv1alpha1.ReasonUnmanaged,
+		Message:            "external-secrets resources are not managed, all the components are available",
+		ObservedGeneration: esc.GetGeneration(),
+	}
+	conditions := slices.Clone(esc.Status.Conditions)
+	if err := r.checkComponentsAvailable(esc); err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(r.unavailableComponents) > 0 {
+		readyCond.Status = metav1.ConditionFalse
+		readyCond.Message = fmt.Sprintf("external-secrets resources are not managed: %s", r.unavailableComponentsMessage())
+	}
+	degradedCond := metav1.Condition{
+		Type:               operatorv1alpha1.Degraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             operatorv1alpha1.ReasonUnmanaged,
+		ObservedGeneration: esc.GetGeneration(),
+	}
+
+	degradedChanged := apimeta.SetStatusCondition(&esc.Status.Conditions, degradedCond)
+	readyChanged := apimeta.SetStatusCondition(&esc.Status.Conditions, readyCond)
+	planCleared := esc.Status.Plan != nil
+	esc.Status.Plan = nil
+	conditionsChanged := !reflect.DeepEqual(conditions, esc.Status.Conditions)
+	if degradedChanged || readyChanged || conditionsChanged || planCleared {
+		r.log.V(2).Info("updating externalsecretsconfig conditions of unmanaged resources", "request", req)
+		if err := r.updateCondition(esc, nil); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// status is refreshed periodically, as the resources may be modified by the users without an event for the
+	// externalsecretsconfigs.operator.openshift.io.
+	return ctrl.Result{RequeueAfter: webhookHealthCheckInterval}, nil
+}
+
+// processRemovedRequest removes the resources created for the external-secrets deployment, and reports the removal
+// in the status. The externalsecretsconfigs.operator.openshift.io is retained, for the operand to be deployed again
+// when the management state is changed. The request is requeued until the removal of all the resources is confirmed.
+func (r *Reconciler) processRemovedRequest(esc *operatorv1alpha1.ExternalSecretsConfig, req types.NamespacedName) (ctrl.Result, error) {
+	removed, pending, err := r.removeExternalSecretsDeployment(esc)
+	if err != nil {
+		r.log.Error(err, "failed to remove external-secrets deployment", "request", req)
+		if common.IsIrrecoverableError(err) {
+			r.eventRecorder.Eventf(esc, corev1.EventTypeWarning, "RemoveFailed", "failed to remove external-secrets resources: %v", err)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if removed > 0 {
+		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Removed", "%d external-secrets resources removed", removed)
+	}
+
+	changed := false
+	for _, c := range getComponents(esc) {
+		changed = apimeta.RemoveStatusCondition(&esc.Status.Conditions, c.conditionType) || changed
+	}
+	for _, condType := range []string{operatorv1alpha1.Progressing, operatorv1alpha1.WebhookHealthy, operatorv1alpha1.CloudCredentialsAvailable} {
+		changed = apimeta.RemoveStatusCondition(&esc.Status.Conditions, condType) || changed
+	}
+	changed = apimeta.SetStatusCondition(&esc.Status.Conditions, metav1.Condition{
+		Type:               operatorv1alpha1.Degraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             operatorv1alpha1.ReasonRemoved,
+		ObservedGeneration: esc.GetGeneration(),
+	}) || changed
+	readyCond := metav1.Condition{
+		Type:               operatorv1alpha1.Ready,
+		Status:             metav1.ConditionFalse,
+		Reason:             operatorv1alpha1.ReasonRemoved,
+		Message:            "external-secrets resources are removed",
+		ObservedGeneration: esc.GetGeneration(),
+	}
+	if len(pending) > 0 {
+		readyCond.Reason = operatorv1alpha1.ReasonInProgress
+		readyCond.Message = fmt.Sprintf("removing external-secrets resources, pending deletion: %s", strings.Join(pending, ", "))
+	}
+	changed = apimeta.SetStatusCondition(&esc.Status.Conditions, readyCond) || changed
+	changed = r.setUnmanagedResourcesCondition(esc) || changed
+	if esc.Status.Plan != nil {
+		esc.Status.Plan = nil
+		changed = true
+	}
+	if changed {
+		r.log.V(2).Info("updating externalsecretsconfig conditions of removed resources", "request", req)
+		if err := r.updateCondition(esc, nil); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if len(pending) > 0 {
+		return ctrl.Result{RequeueAfter: common.DefaultRequeueTime}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// removeExternalSecretsDeployment deletes the resources created for the external-secrets deployment with the current
+// configuration, in the reverse order of creation: the bootstrap clustersecretstores first while the webhook is
+// still serving, and the deployments before the resources mounted or used by them. The namespace is not removed, as
+// it may be created by the users and contain other resources, and the resources annotated as unmanaged and the
+// clustersecretstores not created by the operator are retained.
+// It returns the number of the resources deleted, and the resources whose deletion is not yet complete.
+func (r *Reconciler) removeExternalSecretsDeployment(esc *operatorv1alpha1.ExternalSecretsConfig) (int, []string, error) {
+	objects, err := r.getOperandObjects(esc)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	removed := 0
+	var pending []string
+	for _, desired := range slices.Backward(objects) {
+		if desired.GetKind() == "Namespace" {
+			continue
+		}
+		fetched := &unstructured.Unstructured{}
+		fetched.SetGroupVersionKind(desired.GroupVersionKind())
+		exist, err := r.UncachedClient.Exists(r.ctx, client.ObjectKeyFromObject(desired), fetched)
+		if err != nil {
+			return removed, pending, common.FromClientError(err, "failed to fetch %s", r.resourceReference(desired))
+		}
+		switch {
+		case !exist:
+			continue
+		case isUnmanagedResource(fetched):
+			r.log.V(1).Info("skipping removal of unmanaged resource", "namespace", fetched.GetNamespace(), "name", fetched.GetName())
+			r.unmanagedResources = append(r.unmanagedResources, r.resourceReference(fetched))
+			continue
+		case desired.GroupVersionKind() == clusterSecretStoreGVK && !isBootstrapResource(fetched):
+			r.log.V(1).Info("skipping removal of clustersecretstore not created by the operator", "name", fetched.GetName())
+			continue
+		case fetched.GetDeletionTimestamp() != nil:
+			pending = append(pending, r.resourceReference(fetched))
+			continue
+		}
+		if err := r.UncachedClient.Delete(r.ctx, fetched); err != nil && !errors.IsNotFound(err) {
+			return removed, pending, common.FromClientError(err, "failed to delete %s", r.resourceReference(fetched))
+		}
+		r.log.V(1).Info("removed external-secrets resource", "kind", fetched.GetKind(), "namespace", fetched.GetNamespace(), "name", fetched.GetName())
+		removed++
+		pending = append(pending, r.resourceReference(fetched))
+	}
+	return removed, pending, nil
+}
+
+// getOperandObjects returns the resources created for the external-secrets deployment with the current
+// configuration, in the order of creation, rendered as the reconciliation would create them. The bitwarden
+// clustersecretstore, which is not rendered as it requires the CA certificate issued for bitwarden-sdk-server, is
+// identified by its name.
+func (r *Reconciler) getOperandObjects(esc *operatorv1alpha1.ExternalSecretsConfig) ([]*unstructured.Unstructured, error) {
+	if err := r.reconcileClusterWorkloadIdentity(esc); err != nil {
+		return nil, err
+	}
+	esm := r.esm
+	if esm == nil {
+		esm = &operatorv1alpha1.ExternalSecretsManager{}
+	}
+	c := &renderClient{scheme: r.Scheme, esc: esc, esm: esm}
+	p := newRenderReconciler(r.ctx, c, r.log, r.optionalResourcesList)
+	p.clusterWorkloadIdentity = r.clusterWorkloadIdentity
+	if err := p.renderResources(esc); err != nil {
+		return nil, err
+	}
+
+	objects := c.objects
+	if store := getBitwardenClusterSecretStoreConfig(esc); store != nil {
+		clusterSecretStore := &unstructured.Unstructured{}
+		clusterSecretStore.SetGroupVersionKind(clusterSecretStoreGVK)
+		clusterSecretStore.SetName(store.Name)
+		objects = append(objects, clusterSecretStore)
+	}
+	return objects, nil
+}
@@ -0,0 +1,205 @@
+package external_secrets
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+func TestProcessUnmanagedRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		available int32
+		wantReady metav1.ConditionStatus
+	}{
+		{
+			name:      "components available",
+			available: 1,
+			wantReady: metav1.ConditionTrue,
+		},
+		{
+			name:      "components not available",
+			wantReady: metav1.ConditionFalse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			mock := &fakes.FakeCtrlClient{}
+			r.CtrlClient = mock
+			r.UncachedClient = mock
+			esc := commontest.TestExternalSecretsConfig()
+			esc.Spec.ManagementState = operatorv1alpha1.ManagementStateUnmanaged
+
+			mock.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+				deployment, ok := obj.(*appsv1.Deployment)
+				if !ok {
+					return false, nil
+				}
+				testDeploymentStatus(deployment, tt.available)
+				return true, nil
+			})
+			mock.ListCalls(func(ctx context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				if l, ok := list.(*discoveryv1.EndpointSliceList); ok {
+					l.Items = []discoveryv1.EndpointSlice{
+						{Endpoints: []discoveryv1.Endpoint{{Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)}}}},
+					}
+				}
+				return nil
+			})
+
+			if _, err := r.processReconcileRequest(esc, types.NamespacedName{Name: esc.GetName()}); err != nil {
+				t.Fatalf("processReconcileRequest() unexpected error: %v", err)
+			}
+			if n := mock.ApplyCallCount() + mock.CreateCallCount() + mock.UpdateWithRetryCallCount() + mock.DeleteCallCount() + mock.PatchCallCount(); n != 0 {
+				t.Errorf("unmanaged resources modified %d times", n)
+			}
+			ready := apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.Ready)
+			if ready == nil || ready.Status != tt.wantReady || ready.Reason != operatorv1alpha1.ReasonUnmanaged {
+				t.Errorf("Ready condition = %+v, want status %s with reason %s", ready, tt.wantReady, operatorv1alpha1.ReasonUnmanaged)
+			}
+			if apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.CoreControllerAvailable) == nil {
+				t.Errorf("expected %s condition to be reported", operatorv1alpha1.CoreControllerAvailable)
+			}
+			if mock.StatusUpdateCallCount() != 1 {
+				t.Errorf("StatusUpdate called %d times, want the conditions written in a single status update", mock.StatusUpdateCallCount())
+			}
+		})
+	}
+}
+
+func TestProcessRemovedRequest(t *testing.T) {
+	t.Setenv(externalsecretsImageEnvVarName, commontest.TestExternalSecretsImageName)
+	t.Setenv(bitwardenImageEnvVarName, commontest.TestBitwardenImageName)
+
+	metricsServiceName := testService(metricsServiceAssetName).GetName()
+	tests := []struct {
+		name      string
+		bootstrap *operatorv1alpha1.BootstrapConfig
+		// existing are the updates of the existing resources, keyed by the kind and the name.
+		existing      map[string]func(*unstructured.Unstructured)
+		wantDeleted   []string
+		wantReady     string
+		wantRequeue   bool
+		wantUnmanaged bool
+	}{
+		{
+			name: "rendered resources are deleted and requeued until removed",
+			existing: map[string]func(*unstructured.Unstructured){
+				"Deployment/" + controllerDeploymentName: nil,
+				"Service/" + metricsServiceName: func(u *unstructured.Unstructured) {
+					u.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+				},
+				"Deployment/" + webhookDeploymentName: func(u *unstructured.Unstructured) {
+					u.SetAnnotations(map[string]string{unmanagedResourceAnnotation: "true"})
+				},
+				"Deployment/other-operator": nil,
+			},
+			wantDeleted:   []string{"Deployment/" + controllerDeploymentName},
+			wantReady:     operatorv1alpha1.ReasonInProgress,
+			wantRequeue:   true,
+			wantUnmanaged: true,
+		},
+		{
+			name: "clustersecretstores not created by the operator are retained",
+			bootstrap: func() *operatorv1alpha1.BootstrapConfig {
+				b := testBootstrapConfig()
+				b.ClusterSecretStores = append(b.ClusterSecretStores, operatorv1alpha1.BootstrapClusterSecretStore{Name: "aws"})
+				return b
+			}(),
+			existing: map[string]func(*unstructured.Unstructured){
+				"ClusterSecretStore/vault": nil,
+				"ClusterSecretStore/aws": func(u *unstructured.Unstructured) {
+					u.SetLabels(map[string]string{bootstrapResourceLabelKey: "true"})
+				},
+			},
+			wantDeleted: []string{"ClusterSecretStore/aws"},
+			wantReady:   operatorv1alpha1.ReasonInProgress,
+			wantRequeue: true,
+		},
+		{
+			name:      "removal confirmed",
+			wantReady: operatorv1alpha1.ReasonRemoved,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			r.Scheme = testScheme()
+			mock := &fakes.FakeCtrlClient{}
+			r.CtrlClient = mock
+			r.UncachedClient = mock
+
+			esc := commontest.TestExternalSecretsConfig()
+			esc.Spec.ManagementState = operatorv1alpha1.ManagementStateRemoved
+			esc.Spec.Bootstrap = tt.bootstrap
+			esc.Status.Conditions = []metav1.Condition{
+				{Type: operatorv1alpha1.CoreControllerAvailable, Status: metav1.ConditionTrue},
+				{Type: operatorv1alpha1.Ready, Status: metav1.ConditionTrue, Reason: operatorv1alpha1.ReasonReady},
+			}
+
+			mock.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+				u, ok := obj.(*unstructured.Unstructured)
+				if !ok {
+					return false, nil
+				}
+				update, exist := tt.existing[u.GetKind()+"/"+ns.Name]
+				if !exist {
+					return false, nil
+				}
+				u.SetNamespace(ns.Namespace)
+				u.SetName(ns.Name)
+				if update != nil {
+					update(u)
+				}
+				return true, nil
+			})
+
+			result, err := r.processReconcileRequest(esc, types.NamespacedName{Name: esc.GetName()})
+			if err != nil {
+				t.Fatalf("processReconcileRequest() unexpected error: %v", err)
+			}
+
+			var deleted []string
+			for i := range mock.DeleteCallCount() {
+				_, obj, _ := mock.DeleteArgsForCall(i)
+				deleted = append(deleted, obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetName())
+			}
+			if !reflect.DeepEqual(deleted, tt.wantDeleted) {
+				t.Errorf("deleted %v, want %v", deleted, tt.wantDeleted)
+			}
+			if mock.ApplyCallCount() != 0 || mock.ListCallCount() != 0 {
+				t.Errorf("Apply called %d times and List called %d times, want 0", mock.ApplyCallCount(), mock.ListCallCount())
+			}
+			if got := result.RequeueAfter > 0; got != tt.wantRequeue {
+				t.Errorf("requeued = %v, want %v", got, tt.wantRequeue)
+			}
+			if apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.CoreControllerAvailable) != nil {
+				t.Errorf("expected %s condition to be removed", operatorv1alpha1.CoreControllerAvailable)
+			}
+			ready := apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.Ready)
+			if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != tt.wantReady {
+				t.Errorf("Ready condition = %+v, want False with reason %s", ready, tt.wantReady)
+			}
+			if got := apimeta.IsStatusConditionTrue(esc.Status.Conditions, operatorv1alpha1.UnmanagedResources); got != tt.wantUnmanaged {
+				t.Errorf("%s condition = %v, want %v", operatorv1alpha1.UnmanagedResources, got, tt.wantUnmanaged)
+			}
+		})
+	}
+}
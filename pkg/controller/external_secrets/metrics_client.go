@@ -0,0 +1,95 @@
+package external_secrets
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	operatorclient "github.com/openshift/external-secrets-operator/pkg/controller/client"
+	"github.com/openshift/external-secrets-operator/pkg/metrics"
+)
+
+// metricsClient records the create, update and apply operations on the managed resources in the operator metrics.
+// The operations on the operator's own resources, like adding the finalizer, are not recorded.
+type metricsClient struct {
+	operatorclient.CtrlClient
+
+	scheme *runtime.Scheme
+
+	// isConfigReconciled returns whether the current configuration was reconciled successfully earlier, for
+	// identifying the updates made for correcting the drift of the managed resources from the desired state.
+	isConfigReconciled func() bool
+}
+
+// newMetricsClient returns the client recording the operations on the managed resources made with c.
+func newMetricsClient(c operatorclient.CtrlClient, scheme *runtime.Scheme, isConfigReconciled func() bool) operatorclient.CtrlClient {
+	return &metricsClient{
+		CtrlClient:         c,
+		scheme:             scheme,
+		isConfigReconciled: isConfigReconciled,
+	}
+}
+
+func (c *metricsClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.CtrlClient.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	if len((&client.CreateOptions{}).ApplyOptions(opts).DryRun) > 0 {
+		return nil
+	}
+	c.record(obj, metrics.OperationCreate)
+	return nil
+}
+
+func (c *metricsClient) UpdateWithRetry(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.CtrlClient.UpdateWithRetry(ctx, obj, opts...); err != nil {
+		return err
+	}
+	operation := metrics.OperationUpdate
+	if c.isConfigReconciled() {
+		operation = metrics.OperationDriftCorrection
+	}
+	c.record(obj, operation)
+	return nil
+}
+
+// Apply records the apply of an object not existing as a create, and of an existing object as an update. The
+// dry-run applies are not recorded.
+func (c *metricsClient) Apply(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
+	if operatorclient.IsDryRun(opts...) {
+		return c.CtrlClient.Apply(ctx, obj, opts...)
+	}
+	current, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("failed to create new instance of %T: type does not implement client.Object", obj)
+	}
+	exists, err := c.CtrlClient.Exists(ctx, client.ObjectKeyFromObject(obj), current)
+	if err != nil {
+		return err
+	}
+	if err := c.CtrlClient.Apply(ctx, obj, opts...); err != nil {
+		return err
+	}
+	operation := metrics.OperationCreate
+	switch {
+	case exists && c.isConfigReconciled():
+		operation = metrics.OperationDriftCorrection
+	case exists:
+		operation = metrics.OperationUpdate
+	}
+	c.record(obj, operation)
+	return nil
+}
+
+// record increments the operations count for the kind of the object, when it is a managed resource.
+func (c *metricsClient) record(obj client.Object, operation string) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil || gvk.Group == operatorv1alpha1.GroupVersion.Group {
+		return
+	}
+	metrics.ResourceOperations.WithLabelValues(gvk.Kind, operation).Inc()
+}
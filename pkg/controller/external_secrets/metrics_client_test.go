@@ -0,0 +1,151 @@
+package external_secrets
+
+import (
+	"context"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+	"github.com/openshift/external-secrets-operator/pkg/metrics"
+)
+
+// resourceOperationsCount returns the current value of the managed resource operations counter.
+func resourceOperationsCount(t *testing.T, kind, operation string) float64 {
+	t.Helper()
+	m := &dto.Metric{}
+	if err := metrics.ResourceOperations.WithLabelValues(kind, operation).Write(m); err != nil {
+		t.Fatalf("failed to read metric: %v", err)
+	}
+	return m.GetCounter().GetValue()
+}
+
+func TestMetricsClient(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = operatorv1alpha1.AddToScheme(scheme)
+
+	tests := []struct {
+		name             string
+		obj              client.Object
+		update           bool
+		apply            bool
+		dryRun           bool
+		exists           bool
+		configReconciled bool
+		clientErr        error
+		wantKind         string
+		wantOperation    string
+		wantCount        float64
+	}{
+		{
+			name:          "deployment created",
+			obj:           testDeployment(controllerDeploymentName),
+			wantKind:      "Deployment",
+			wantOperation: metrics.OperationCreate,
+			wantCount:     1,
+		},
+		{
+			name:          "service updated for configuration change",
+			obj:           &corev1.Service{},
+			update:        true,
+			wantKind:      "Service",
+			wantOperation: metrics.OperationUpdate,
+			wantCount:     1,
+		},
+		{
+			name:             "service updated for drift correction",
+			obj:              &corev1.Service{},
+			update:           true,
+			configReconciled: true,
+			wantKind:         "Service",
+			wantOperation:    metrics.OperationDriftCorrection,
+			wantCount:        1,
+		},
+		{
+			name:          "failed create not recorded",
+			obj:           &appsv1.DaemonSet{},
+			clientErr:     commontest.ErrTestClient,
+			wantKind:      "DaemonSet",
+			wantOperation: metrics.OperationCreate,
+		},
+		{
+			name:          "dry-run create not recorded",
+			obj:           testDeployment(controllerDeploymentName),
+			dryRun:        true,
+			wantKind:      "Deployment",
+			wantOperation: metrics.OperationCreate,
+		},
+		{
+			name:          "operator resource update not recorded",
+			obj:           commontest.TestExternalSecretsConfig(),
+			update:        true,
+			wantKind:      "ExternalSecretsConfig",
+			wantOperation: metrics.OperationUpdate,
+		},
+		{
+			name:          "deployment applied when not existing recorded as create",
+			obj:           testDeployment(controllerDeploymentName),
+			apply:         true,
+			wantKind:      "Deployment",
+			wantOperation: metrics.OperationCreate,
+			wantCount:     1,
+		},
+		{
+			name:             "service applied for drift correction",
+			obj:              &corev1.Service{},
+			apply:            true,
+			exists:           true,
+			configReconciled: true,
+			wantKind:         "Service",
+			wantOperation:    metrics.OperationDriftCorrection,
+			wantCount:        1,
+		},
+		{
+			name:          "failed apply not recorded",
+			obj:           &corev1.Service{},
+			apply:         true,
+			exists:        true,
+			clientErr:     commontest.ErrTestClient,
+			wantKind:      "Service",
+			wantOperation: metrics.OperationUpdate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics.ResourceOperations.Reset()
+			mock := &fakes.FakeCtrlClient{}
+			mock.CreateReturns(tt.clientErr)
+			mock.UpdateWithRetryReturns(tt.clientErr)
+			mock.ApplyReturns(tt.clientErr)
+			mock.ExistsReturns(tt.exists, nil)
+			c := newMetricsClient(mock, scheme, func() bool { return tt.configReconciled })
+
+			var err error
+			switch {
+			case tt.apply:
+				err = c.Apply(context.Background(), tt.obj)
+			case tt.update:
+				err = c.UpdateWithRetry(context.Background(), tt.obj)
+			case tt.dryRun:
+				err = c.Create(context.Background(), tt.obj, client.DryRunAll)
+			default:
+				err = c.Create(context.Background(), tt.obj)
+			}
+			if err != tt.clientErr {
+				t.Errorf("Expected error: %v, got: %v", tt.clientErr, err)
+			}
+			if got := resourceOperationsCount(t, tt.wantKind, tt.wantOperation); got != tt.wantCount {
+				t.Errorf("%s %s operations = %v, want %v", tt.wantKind, tt.wantOperation, got, tt.wantCount)
+			}
+		})
+	}
+}
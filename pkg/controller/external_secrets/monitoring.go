@@ -0,0 +1,334 @@
+package external_secrets
+
+import (
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+	"github.com/openshift/external-secrets-operator/pkg/operator/assets"
+)
+
+// serviceMonitorTarget is an external-secrets component service scraped with a ServiceMonitor.
+type serviceMonitorTarget struct {
+	// serviceAssetName is the asset name of the service scraped, the ServiceMonitor is created with
+	// the same name as the service.
+	serviceAssetName string
+	// port is the name of the service port serving the metrics.
+	port string
+	// tls is whether the metrics are served over TLS with the bitwarden-sdk-server certificate.
+	tls bool
+	// honorLabels is whether the labels of the scraped metrics take precedence over the target labels.
+	honorLabels bool
+	// enabled is whether the component is deployed.
+	enabled bool
+}
+
+// getServiceMonitorTargets returns the services of the external-secrets components scraped with ServiceMonitors.
+func getServiceMonitorTargets(esc *operatorv1alpha1.ExternalSecretsConfig) []serviceMonitorTarget {
+	return []serviceMonitorTarget{
+		{
+			// core controller metrics are labeled with the namespace and the name of the ExternalSecret,
+			// which are retained for the alerts.
+			serviceAssetName: metricsServiceAssetName,
+			port:             "metrics",
+			honorLabels:      true,
+			enabled:          true,
+		},
+		{
+			serviceAssetName: webhookServiceAssetName,
+			port:             "metrics",
+			enabled:          true,
+		},
+		{
+			serviceAssetName: certControllerMetricsServiceAssetName,
+			port:             "metrics",
+			enabled:          !isCertManagerConfigEnabled(esc),
+		},
+		{
+			serviceAssetName: bitwardenServiceAssetName,
+			port:             "http",
+			tls:              true,
+			enabled:          isBitwardenConfigEnabled(esc),
+		},
+	}
+}
+
+// createOrApplyMonitoring creates or updates the ServiceMonitors and the PrometheusRules for the external-secrets
+// operand when the Prometheus Operator CRDs are installed, and removes the ones not required anymore. The resources
+// are not watched, the drift is corrected in the periodic reconciliation.
+func (r *Reconciler) createOrApplyMonitoring(esc *operatorv1alpha1.ExternalSecretsConfig, resourceMetadata common.ResourceMetadata) error {
+	if r.isServiceMonitorsInstalled() {
+		for _, target := range getServiceMonitorTargets(esc) {
+			desired := getServiceMonitorObject(esc, target, resourceMetadata)
+			if !isMonitoringEnabled(esc) || !target.enabled {
+				if err := r.deleteMonitoringResource(esc, desired); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := r.createOrApplyMonitoringResource(esc, desired, resourceMetadata); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !r.isPrometheusRulesInstalled() {
+		return nil
+	}
+	desired := []*unstructured.Unstructured{getPrometheusRuleObject(esc, resourceMetadata)}
+	// the operator metrics are scraped with the ServiceMonitor shipped in the operator bundle, and the alerts on
+	// them are created in the operator namespace, for being evaluated along with the operator metrics.
+	if namespace := os.Getenv(operatorNamespaceEnvVarName); namespace != "" {
+		desired = append(desired, getOperatorPrometheusRuleObject(esc, namespace, resourceMetadata))
+	}
+	for _, rule := range desired {
+		if !isAlertsEnabled(esc) {
+			if err := r.deleteMonitoringResource(esc, rule); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := r.createOrApplyMonitoringResource(esc, rule, resourceMetadata); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createOrApplyMonitoringResource creates the ServiceMonitor or the PrometheusRule, or updates it when modified.
+func (r *Reconciler) createOrApplyMonitoringResource(esc *operatorv1alpha1.ExternalSecretsConfig, desired *unstructured.Unstructured, resourceMetadata common.ResourceMetadata) error {
+	kind := desired.GetObjectKind().GroupVersionKind().Kind
+	resourceName := fmt.Sprintf("%s/%s", desired.GetNamespace(), desired.GetName())
+	r.log.V(4).Info("reconciling monitoring resource", "kind", kind, "name", resourceName)
+
+	fetched := &unstructured.Unstructured{}
+	fetched.SetGroupVersionKind(desired.GroupVersionKind())
+	exist, err := r.UncachedClient.Exists(r.ctx, client.ObjectKeyFromObject(desired), fetched)
+	if err != nil {
+		return common.FromClientError(err, "failed to check %s %s resource already exists", resourceName, kind)
+	}
+
+	switch {
+	case exist && r.hasObjectDrifted(desired, fetched):
+		r.log.V(1).Info("monitoring resource has been modified, updating to desired state", "kind", kind, "name", resourceName)
+		common.RemoveObsoleteAnnotations(desired, resourceMetadata)
+		if err := r.UncachedClient.Apply(r.ctx, desired); err != nil {
+			return common.FromClientError(err, "failed to update %s %s resource", resourceName, kind)
+		}
+		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "%s resource %s updated", kind, resourceName)
+	case !exist:
+		if err := r.UncachedClient.Apply(r.ctx, desired); err != nil {
+			return common.FromClientError(err, "failed to create %s %s resource", resourceName, kind)
+		}
+		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "%s resource %s created", kind, resourceName)
+	default:
+		r.log.V(4).Info("monitoring resource already exists and is in expected state", "kind", kind, "name", resourceName)
+	}
+
+	return nil
+}
+
+// deleteMonitoringResource removes the ServiceMonitor or the PrometheusRule created earlier, which is not
+// required anymore.
+func (r *Reconciler) deleteMonitoringResource(esc *operatorv1alpha1.ExternalSecretsConfig, desired *unstructured.Unstructured) error {
+	kind := desired.GetObjectKind().GroupVersionKind().Kind
+	resourceName := fmt.Sprintf("%s/%s", desired.GetNamespace(), desired.GetName())
+
+	fetched := &unstructured.Unstructured{}
+	fetched.SetGroupVersionKind(desired.GroupVersionKind())
+	exist, err := r.UncachedClient.Exists(r.ctx, client.ObjectKeyFromObject(desired), fetched)
+	if err != nil {
+		return common.FromClientError(err, "failed to check %s %s resource already exists", resourceName, kind)
+	}
+	if !exist || fetched.GetLabels()[requestEnqueueLabelKey] != requestEnqueueLabelValue {
+		return nil
+	}
+
+	if err := r.UncachedClient.Delete(r.ctx, fetched); err != nil {
+		return common.FromClientError(err, "failed to delete %s %s resource", resourceName, kind)
+	}
+	r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "%s resource %s deleted", kind, resourceName)
+	return nil
+}
+
+// getServiceMonitorObject returns the ServiceMonitor for scraping the metrics of the external-secrets component
+// service.
+func getServiceMonitorObject(esc *operatorv1alpha1.ExternalSecretsConfig, target serviceMonitorTarget, resourceMetadata common.ResourceMetadata) *unstructured.Unstructured {
+	service := common.DecodeServiceObjBytes(assets.MustAsset(target.serviceAssetName))
+	namespace := getNamespace(esc)
+
+	endpoint := map[string]interface{}{
+		"port":        target.port,
+		"path":        "/metrics",
+		"scheme":      "http",
+		"interval":    getScrapeInterval(esc),
+		"honorLabels": target.honorLabels,
+	}
+	if target.tls {
+		endpoint["scheme"] = "https"
+		endpoint["tlsConfig"] = map[string]interface{}{
+			"ca": map[string]interface{}{
+				"secret": map[string]interface{}{
+					"name": getBitwardenTLSSecretName(esc),
+					"key":  bitwardenCACertKey,
+				},
+			},
+			"serverName": fmt.Sprintf(bitwardenServiceHostFmt, namespace),
+		}
+	}
+
+	serviceMonitor := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"endpoints": []interface{}{endpoint},
+				"namespaceSelector": map[string]interface{}{
+					"matchNames": []interface{}{namespace},
+				},
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{
+						"app.kubernetes.io/name":     service.GetLabels()["app.kubernetes.io/name"],
+						"app.kubernetes.io/instance": service.GetLabels()["app.kubernetes.io/instance"],
+					},
+				},
+			},
+		},
+	}
+	serviceMonitor.SetGroupVersionKind(serviceMonitorGVK)
+	serviceMonitor.SetName(service.GetName())
+	serviceMonitor.SetNamespace(namespace)
+	common.ApplyResourceMetadata(serviceMonitor, resourceMetadata)
+
+	return serviceMonitor
+}
+
+// getPrometheusRuleObject returns the PrometheusRule with the alerts for the sync errors of the ExternalSecrets and
+// the latency of the external-secrets webhook, based on the operand metrics.
+func getPrometheusRuleObject(esc *operatorv1alpha1.ExternalSecretsConfig, resourceMetadata common.ResourceMetadata) *unstructured.Unstructured {
+	namespace := getNamespace(esc)
+	syncErrorsThreshold, webhookLatencyThresholdMilliseconds, _ := getAlertThresholds(esc)
+
+	rules := []interface{}{
+		map[string]interface{}{
+			"alert": "ExternalSecretSyncErrors",
+			"expr": fmt.Sprintf(`sum by (namespace, name) (increase(externalsecret_sync_calls_error{job=%q}[15m])) > %d`,
+				controllerMetricsServiceName, syncErrorsThreshold),
+			"for": "5m",
+			"labels": map[string]interface{}{
+				"severity": "warning",
+			},
+			"annotations": map[string]interface{}{
+				"summary":     "ExternalSecret is failing to synchronize.",
+				"description": "ExternalSecret {{ $labels.namespace }}/{{ $labels.name }} failed to synchronize {{ $value }} times in the last 15 minutes.",
+			},
+		},
+		map[string]interface{}{
+			"alert": "ExternalSecretsWebhookHighLatency",
+			"expr": fmt.Sprintf(`histogram_quantile(0.99, sum by (le, webhook) (rate(controller_runtime_webhook_latency_seconds_bucket{job=%q, namespace=%q}[5m]))) > %g`,
+				webhookServiceName, namespace, float64(webhookLatencyThresholdMilliseconds)/1000),
+			"for": "10m",
+			"labels": map[string]interface{}{
+				"severity": "warning",
+			},
+			"annotations": map[string]interface{}{
+				"summary":     "external-secrets webhook is responding slowly.",
+				"description": fmt.Sprintf("99th percentile latency of the external-secrets webhook {{ $labels.webhook }} is above %dms.", webhookLatencyThresholdMilliseconds),
+			},
+		},
+	}
+
+	return newPrometheusRuleObject(prometheusRuleName, namespace, externalsecretsCommonName, rules, resourceMetadata)
+}
+
+// getOperatorPrometheusRuleObject returns the PrometheusRule with the alert for the expiry of the webhook serving
+// certificate, based on the operator metrics, to be created in the operator namespace.
+func getOperatorPrometheusRuleObject(esc *operatorv1alpha1.ExternalSecretsConfig, namespace string, resourceMetadata common.ResourceMetadata) *unstructured.Unstructured {
+	_, _, certificateExpiryThresholdDays := getAlertThresholds(esc)
+
+	rules := []interface{}{
+		map[string]interface{}{
+			"alert": "ExternalSecretsWebhookCertificateExpiring",
+			"expr": fmt.Sprintf(`(external_secrets_operator_webhook_certificate_expiry_timestamp_seconds{namespace=%q} - time()) < %d`,
+				namespace, certificateExpiryThresholdDays*24*60*60),
+			"for": "15m",
+			"labels": map[string]interface{}{
+				"severity": "warning",
+			},
+			"annotations": map[string]interface{}{
+				"summary":     "external-secrets webhook serving certificate is about to expire.",
+				"description": fmt.Sprintf("external-secrets webhook serving certificate expires in less than %d days.", certificateExpiryThresholdDays),
+			},
+		},
+	}
+
+	return newPrometheusRuleObject(operatorPrometheusRuleName, namespace, operatorPrometheusRuleName, rules, resourceMetadata)
+}
+
+// newPrometheusRuleObject returns the PrometheusRule with the rules in a single group.
+func newPrometheusRuleObject(name, namespace, group string, rules []interface{}, resourceMetadata common.ResourceMetadata) *unstructured.Unstructured {
+	prometheusRule := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"groups": []interface{}{
+					map[string]interface{}{
+						"name":  group,
+						"rules": rules,
+					},
+				},
+			},
+		},
+	}
+	prometheusRule.SetGroupVersionKind(prometheusRuleGVK)
+	prometheusRule.SetName(name)
+	prometheusRule.SetNamespace(namespace)
+	common.ApplyResourceMetadata(prometheusRule, resourceMetadata)
+
+	return prometheusRule
+}
+
+// isMonitoringEnabled returns whether the monitoring resources are to be created for the external-secrets operand,
+// which is the default when not configured.
+func isMonitoringEnabled(esc *operatorv1alpha1.ExternalSecretsConfig) bool {
+	return esc.Spec.ControllerConfig.Monitoring == nil || common.EvalMode(esc.Spec.ControllerConfig.Monitoring.Mode)
+}
+
+// isAlertsEnabled returns whether the PrometheusRule is to be created for the external-secrets operand, which is
+// the default when monitoring is enabled.
+func isAlertsEnabled(esc *operatorv1alpha1.ExternalSecretsConfig) bool {
+	if !isMonitoringEnabled(esc) {
+		return false
+	}
+	monitoring := esc.Spec.ControllerConfig.Monitoring
+	return monitoring == nil || monitoring.Alerts == nil || common.EvalMode(monitoring.Alerts.Mode)
+}
+
+// getScrapeInterval returns the configured scrape interval, or the default when not configured.
+func getScrapeInterval(esc *operatorv1alpha1.ExternalSecretsConfig) string {
+	if monitoring := esc.Spec.ControllerConfig.Monitoring; monitoring != nil && monitoring.ScrapeInterval != "" {
+		return monitoring.ScrapeInterval
+	}
+	return defaultScrapeInterval
+}
+
+// getAlertThresholds returns the configured sync errors, webhook latency and certificate expiry thresholds of
+// the alerts, or the defaults for the ones not configured.
+func getAlertThresholds(esc *operatorv1alpha1.ExternalSecretsConfig) (int32, int32, int32) {
+	var syncErrorsThreshold, webhookLatencyThresholdMilliseconds, certificateExpiryThresholdDays int32 = 0,
+		defaultWebhookLatencyThresholdMilliseconds, defaultCertificateExpiryThresholdDays
+	if monitoring := esc.Spec.ControllerConfig.Monitoring; monitoring != nil && monitoring.Alerts != nil {
+		alerts := monitoring.Alerts
+		syncErrorsThreshold = alerts.SyncErrorsThreshold
+		if alerts.WebhookLatencyThresholdMilliseconds != 0 {
+			webhookLatencyThresholdMilliseconds = alerts.WebhookLatencyThresholdMilliseconds
+		}
+		if alerts.CertificateExpiryThresholdDays != 0 {
+			certificateExpiryThresholdDays = alerts.CertificateExpiryThresholdDays
+		}
+	}
+	return syncErrorsThreshold, webhookLatencyThresholdMilliseconds, certificateExpiryThresholdDays
+}
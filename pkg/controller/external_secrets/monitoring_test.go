@@ -0,0 +1,388 @@
+package external_secrets
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+// existsAsDesired returns the Exists stub which fetches the monitoring resources in their desired state.
+func existsAsDesired(esc *operatorv1alpha1.ExternalSecretsConfig) func(context.Context, types.NamespacedName, client.Object) (bool, error) {
+	return func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+		u := obj.(*unstructured.Unstructured)
+		if u.GetKind() == prometheusRuleGVK.Kind && ns.Name == operatorPrometheusRuleName {
+			testApplied(getOperatorPrometheusRuleObject(esc, ns.Namespace, testResourceMetadata(esc))).DeepCopyInto(u)
+			return true, nil
+		}
+		if u.GetKind() == prometheusRuleGVK.Kind {
+			testApplied(getPrometheusRuleObject(esc, testResourceMetadata(esc))).DeepCopyInto(u)
+			return true, nil
+		}
+		for _, target := range getServiceMonitorTargets(esc) {
+			if !target.enabled {
+				continue
+			}
+			if desired := getServiceMonitorObject(esc, target, testResourceMetadata(esc)); desired.GetName() == ns.Name {
+				testApplied(desired).DeepCopyInto(u)
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+// testManagedMonitoringResource updates the fetched object as a monitoring resource created by the operator.
+func testManagedMonitoringResource(obj client.Object, ns types.NamespacedName) {
+	obj.SetName(ns.Name)
+	obj.SetNamespace(ns.Namespace)
+	obj.SetLabels(map[string]string{requestEnqueueLabelKey: requestEnqueueLabelValue})
+}
+
+func TestGetServiceMonitorObject(t *testing.T) {
+	tests := []struct {
+		name         string
+		target       serviceMonitorTarget
+		esc          func(*operatorv1alpha1.ExternalSecretsConfig)
+		wantName     string
+		wantEndpoint map[string]interface{}
+	}{
+		{
+			name:     "core controller metrics",
+			target:   getServiceMonitorTargets(commontest.TestExternalSecretsConfig())[0],
+			wantName: "external-secrets-metrics",
+			wantEndpoint: map[string]interface{}{
+				"port":        "metrics",
+				"path":        "/metrics",
+				"scheme":      "http",
+				"interval":    "30s",
+				"honorLabels": true,
+			},
+		},
+		{
+			name:   "webhook metrics with configured scrape interval",
+			target: getServiceMonitorTargets(commontest.TestExternalSecretsConfig())[1],
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ControllerConfig.Monitoring = &operatorv1alpha1.MonitoringConfig{
+					Mode:           operatorv1alpha1.Enabled,
+					ScrapeInterval: "1m",
+				}
+			},
+			wantName: "external-secrets-webhook",
+			wantEndpoint: map[string]interface{}{
+				"port":        "metrics",
+				"path":        "/metrics",
+				"scheme":      "http",
+				"interval":    "1m",
+				"honorLabels": false,
+			},
+		},
+		{
+			name:   "bitwarden-sdk-server over TLS",
+			target: getServiceMonitorTargets(commontest.TestExternalSecretsConfig())[3],
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.Plugins.BitwardenSecretManagerProvider = &operatorv1alpha1.BitwardenSecretManagerProvider{
+					Mode:      operatorv1alpha1.Enabled,
+					SecretRef: &operatorv1alpha1.SecretReference{Name: "bitwarden-user-tls"},
+				}
+			},
+			wantName: "bitwarden-sdk-server",
+			wantEndpoint: map[string]interface{}{
+				"port":        "http",
+				"path":        "/metrics",
+				"scheme":      "https",
+				"interval":    "30s",
+				"honorLabels": false,
+				"tlsConfig": map[string]interface{}{
+					"ca": map[string]interface{}{
+						"secret": map[string]interface{}{
+							"name": "bitwarden-user-tls",
+							"key":  "ca.crt",
+						},
+					},
+					"serverName": "bitwarden-sdk-server.external-secrets.svc.cluster.local",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			esc := commontest.TestExternalSecretsConfig()
+			if tt.esc != nil {
+				tt.esc(esc)
+			}
+			obj := getServiceMonitorObject(esc, tt.target, testResourceMetadata(esc))
+
+			if obj.GetName() != tt.wantName || obj.GetNamespace() != commontest.TestExternalSecretsNamespace {
+				t.Errorf("unexpected servicemonitor %s/%s", obj.GetNamespace(), obj.GetName())
+			}
+			if obj.GetLabels()[requestEnqueueLabelKey] != requestEnqueueLabelValue {
+				t.Errorf("expected managed label on servicemonitor, got %v", obj.GetLabels())
+			}
+			endpoints, _, _ := unstructured.NestedSlice(obj.Object, "spec", "endpoints")
+			if len(endpoints) != 1 || !reflect.DeepEqual(endpoints[0], tt.wantEndpoint) {
+				t.Errorf("endpoints = %v, want %v", endpoints, tt.wantEndpoint)
+			}
+			selector, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "selector", "matchLabels")
+			if selector["app.kubernetes.io/instance"] != "external-secrets" || selector["app.kubernetes.io/name"] == "" {
+				t.Errorf("unexpected selector %v", selector)
+			}
+		})
+	}
+}
+
+func TestGetPrometheusRuleObject(t *testing.T) {
+	tests := []struct {
+		name             string
+		alerts           *operatorv1alpha1.AlertsConfig
+		wantExprs        []string
+		wantOperatorExpr string
+	}{
+		{
+			name: "default thresholds",
+			wantExprs: []string{
+				`sum by (namespace, name) (increase(externalsecret_sync_calls_error{job="external-secrets-metrics"}[15m])) > 0`,
+				`histogram_quantile(0.99, sum by (le, webhook) (rate(controller_runtime_webhook_latency_seconds_bucket{job="external-secrets-webhook", namespace="external-secrets"}[5m]))) > 1`,
+			},
+			wantOperatorExpr: `(external_secrets_operator_webhook_certificate_expiry_timestamp_seconds{namespace="external-secrets-operator"} - time()) < 604800`,
+		},
+		{
+			name: "configured thresholds",
+			alerts: &operatorv1alpha1.AlertsConfig{
+				Mode:                                operatorv1alpha1.Enabled,
+				SyncErrorsThreshold:                 5,
+				WebhookLatencyThresholdMilliseconds: 250,
+				CertificateExpiryThresholdDays:      30,
+			},
+			wantExprs: []string{
+				`sum by (namespace, name) (increase(externalsecret_sync_calls_error{job="external-secrets-metrics"}[15m])) > 5`,
+				`histogram_quantile(0.99, sum by (le, webhook) (rate(controller_runtime_webhook_latency_seconds_bucket{job="external-secrets-webhook", namespace="external-secrets"}[5m]))) > 0.25`,
+			},
+			wantOperatorExpr: `(external_secrets_operator_webhook_certificate_expiry_timestamp_seconds{namespace="external-secrets-operator"} - time()) < 2592000`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			esc := commontest.TestExternalSecretsConfig()
+			if tt.alerts != nil {
+				esc.Spec.ControllerConfig.Monitoring = &operatorv1alpha1.MonitoringConfig{
+					Mode:   operatorv1alpha1.Enabled,
+					Alerts: tt.alerts,
+				}
+			}
+			obj := getPrometheusRuleObject(esc, testResourceMetadata(esc))
+
+			if obj.GetName() != prometheusRuleName || obj.GetNamespace() != commontest.TestExternalSecretsNamespace {
+				t.Errorf("unexpected prometheusrule %s/%s", obj.GetNamespace(), obj.GetName())
+			}
+			groups, _, _ := unstructured.NestedSlice(obj.Object, "spec", "groups")
+			if len(groups) != 1 {
+				t.Fatalf("expected 1 rule group, got %d", len(groups))
+			}
+			rules, _, _ := unstructured.NestedSlice(groups[0].(map[string]interface{}), "rules")
+			var exprs []string
+			for _, rule := range rules {
+				exprs = append(exprs, rule.(map[string]interface{})["expr"].(string))
+			}
+			if !reflect.DeepEqual(exprs, tt.wantExprs) {
+				t.Errorf("rule expressions:\n%s\nwant:\n%s", strings.Join(exprs, "\n"), strings.Join(tt.wantExprs, "\n"))
+			}
+
+			obj = getOperatorPrometheusRuleObject(esc, "external-secrets-operator", testResourceMetadata(esc))
+			if obj.GetName() != operatorPrometheusRuleName || obj.GetNamespace() != "external-secrets-operator" {
+				t.Errorf("unexpected operator prometheusrule %s/%s", obj.GetNamespace(), obj.GetName())
+			}
+			groups, _, _ = unstructured.NestedSlice(obj.Object, "spec", "groups")
+			rules, _, _ = unstructured.NestedSlice(groups[0].(map[string]interface{}), "rules")
+			if len(rules) != 1 || rules[0].(map[string]interface{})["expr"] != tt.wantOperatorExpr {
+				t.Errorf("operator rules = %v, want expression %s", rules, tt.wantOperatorExpr)
+			}
+		})
+	}
+}
+
+func TestCreateOrApplyMonitoring(t *testing.T) {
+	tests := []struct {
+		name          string
+		crdsInstalled bool
+		// operatorNamespace is the namespace of the operator, set in the environment.
+		operatorNamespace string
+		esc               func(*operatorv1alpha1.ExternalSecretsConfig)
+		preReq            func(*operatorv1alpha1.ExternalSecretsConfig, *fakes.FakeCtrlClient)
+		wantApply         []string
+		wantDelete        []string
+		wantErr           string
+	}{
+		{
+			name: "prometheus operator not installed",
+		},
+		{
+			name:          "servicemonitors and prometheusrule created",
+			crdsInstalled: true,
+			preReq: func(_ *operatorv1alpha1.ExternalSecretsConfig, m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(doesNotExist())
+			},
+			wantApply: []string{"external-secrets-metrics", "external-secrets-webhook", "external-secrets-cert-controller-metrics", "external-secrets"},
+		},
+		{
+			name:              "operator prometheusrule created in operator namespace",
+			crdsInstalled:     true,
+			operatorNamespace: "external-secrets-operator",
+			preReq: func(esc *operatorv1alpha1.ExternalSecretsConfig, m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+					if ns.Namespace == "external-secrets-operator" {
+						return false, nil
+					}
+					return existsAsDesired(esc)(ctx, ns, obj)
+				})
+			},
+			wantApply: []string{operatorPrometheusRuleName},
+		},
+		{
+			name:          "bitwarden-sdk-server servicemonitor created and cert-controller servicemonitor deleted",
+			crdsInstalled: true,
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ControllerConfig.CertProvider = &operatorv1alpha1.CertProvidersConfig{
+					CertManager: &operatorv1alpha1.CertManagerConfig{Mode: operatorv1alpha1.Enabled},
+				}
+				esc.Spec.Plugins.BitwardenSecretManagerProvider = &operatorv1alpha1.BitwardenSecretManagerProvider{
+					Mode: operatorv1alpha1.Enabled,
+				}
+			},
+			preReq: func(esc *operatorv1alpha1.ExternalSecretsConfig, m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+					switch ns.Name {
+					case "external-secrets-cert-controller-metrics":
+						testManagedMonitoringResource(obj, ns)
+						return true, nil
+					case "bitwarden-sdk-server":
+						return false, nil
+					}
+					return existsAsDesired(esc)(ctx, ns, obj)
+				})
+			},
+			wantApply:  []string{"bitwarden-sdk-server"},
+			wantDelete: []string{"external-secrets-cert-controller-metrics"},
+		},
+		{
+			name:          "monitoring resources in desired state",
+			crdsInstalled: true,
+			preReq: func(esc *operatorv1alpha1.ExternalSecretsConfig, m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(existsAsDesired(esc))
+			},
+		},
+		{
+			name:          "modified prometheusrule is updated",
+			crdsInstalled: true,
+			preReq: func(esc *operatorv1alpha1.ExternalSecretsConfig, m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+					exist, err := existsAsDesired(esc)(ctx, ns, obj)
+					if u := obj.(*unstructured.Unstructured); u.GetKind() == prometheusRuleGVK.Kind {
+						u.Object["spec"] = map[string]interface{}{"groups": []interface{}{}}
+					}
+					return exist, err
+				})
+			},
+			wantApply: []string{"external-secrets"},
+		},
+		{
+			name:          "monitoring disabled removes the monitoring resources",
+			crdsInstalled: true,
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ControllerConfig.Monitoring = &operatorv1alpha1.MonitoringConfig{Mode: operatorv1alpha1.Disabled}
+			},
+			preReq: func(_ *operatorv1alpha1.ExternalSecretsConfig, m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+					if ns.Name == "bitwarden-sdk-server" {
+						return false, nil
+					}
+					testManagedMonitoringResource(obj, ns)
+					return true, nil
+				})
+			},
+			wantDelete: []string{"external-secrets-metrics", "external-secrets-webhook", "external-secrets-cert-controller-metrics", "external-secrets"},
+		},
+		{
+			name:          "alerts disabled does not remove prometheusrule not created by operator",
+			crdsInstalled: true,
+			esc: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.ControllerConfig.Monitoring = &operatorv1alpha1.MonitoringConfig{
+					Mode:   operatorv1alpha1.Enabled,
+					Alerts: &operatorv1alpha1.AlertsConfig{Mode: operatorv1alpha1.Disabled},
+				}
+			},
+			preReq: func(esc *operatorv1alpha1.ExternalSecretsConfig, m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+					if u := obj.(*unstructured.Unstructured); u.GetKind() == prometheusRuleGVK.Kind {
+						return true, nil
+					}
+					return existsAsDesired(esc)(ctx, ns, obj)
+				})
+			},
+		},
+		{
+			name:          "servicemonitor creation fails",
+			crdsInstalled: true,
+			preReq: func(_ *operatorv1alpha1.ExternalSecretsConfig, m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(doesNotExist())
+				m.ApplyReturns(commontest.ErrTestClient)
+			},
+			wantApply: []string{"external-secrets-metrics"},
+			wantErr:   "failed to create external-secrets/external-secrets-metrics ServiceMonitor resource: test client error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			mock := &fakes.FakeCtrlClient{}
+			r.UncachedClient = mock
+			if tt.operatorNamespace != "" {
+				t.Setenv(operatorNamespaceEnvVarName, tt.operatorNamespace)
+			}
+			if tt.crdsInstalled {
+				r.optionalResourcesList[serviceMonitorCRDGKV] = struct{}{}
+				r.optionalResourcesList[prometheusRuleCRDGKV] = struct{}{}
+			}
+
+			esc := commontest.TestExternalSecretsConfig()
+			if tt.esc != nil {
+				tt.esc(esc)
+			}
+			if tt.preReq != nil {
+				tt.preReq(esc, mock)
+			}
+
+			err := r.createOrApplyMonitoring(esc, testResourceMetadata(esc))
+			if (tt.wantErr != "" || err != nil) && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Expected error: %v, got: %v", tt.wantErr, err)
+			}
+
+			var applied, deleted []string
+			for i := range mock.ApplyCallCount() {
+				_, obj, _ := mock.ApplyArgsForCall(i)
+				applied = append(applied, obj.GetName())
+			}
+			for i := range mock.DeleteCallCount() {
+				_, obj, _ := mock.DeleteArgsForCall(i)
+				deleted = append(deleted, obj.GetName())
+			}
+			if !reflect.DeepEqual(applied, tt.wantApply) {
+				t.Errorf("applied %v, want %v", applied, tt.wantApply)
+			}
+			if !reflect.DeepEqual(deleted, tt.wantDelete) {
+				t.Errorf("deleted %v, want %v", deleted, tt.wantDelete)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
@@ -35,22 +37,28 @@ func (r *Reconciler) createOrApplyStaticNetworkPolicies(esc *operatorv1alpha1.Ex
 	staticNetworkPolicies := []struct {
 		assetName string
 		condition bool
+		// metricsPort is the port of the component metrics scraped with the ServiceMonitor, zero when the
+		// policy allows the ingress on the metrics port from all the namespaces.
+		metricsPort int32
 	}{
 		{
 			assetName: denyAllNetworkPolicyAssetName,
 			condition: true, // Always apply deny-all as the base policy
 		},
 		{
-			assetName: allowMainControllerTrafficAssetName,
-			condition: true, // Always apply for main controller
+			assetName:   allowMainControllerTrafficAssetName,
+			condition:   true, // Always apply for main controller
+			metricsPort: componentMetricsPort,
 		},
 		{
-			assetName: allowWebhookTrafficAssetName,
-			condition: true, // Always apply for webhook
+			assetName:   allowWebhookTrafficAssetName,
+			condition:   true, // Always apply for webhook
+			metricsPort: componentMetricsPort,
 		},
 		{
-			assetName: allowCertControllerTrafficAssetName,
-			condition: !isCertManagerConfigEnabled(esc), // Only if cert-controller is enabled
+			assetName:   allowCertControllerTrafficAssetName,
+			condition:   !isCertManagerConfigEnabled(esc), // Only if cert-controller is enabled
+			metricsPort: componentMetricsPort,
 		},
 		{
 			assetName: allowBitwardenServerTrafficAssetName,
@@ -67,7 +75,7 @@ func (r *Reconciler) createOrApplyStaticNetworkPolicies(esc *operatorv1alpha1.Ex
 		if !np.condition {
 			continue
 		}
-		if err := r.createOrApplyNetworkPolicyFromAsset(esc, np.assetName, resourceMetadata, externalSecretsConfigCreateRecon); err != nil {
+		if err := r.createOrApplyNetworkPolicyFromAsset(esc, np.assetName, np.metricsPort, resourceMetadata, externalSecretsConfigCreateRecon); err != nil {
 			return err
 		}
 	}
@@ -98,6 +106,9 @@ func (r *Reconciler) createOrApplyCustomNetworkPolicy(esc *operatorv1alpha1.Exte
 	if err != nil {
 		return err
 	}
+	if err := r.applyOverride(esc, networkPolicy); err != nil {
+		return err
+	}
 
 	networkPolicyName := fmt.Sprintf("%s/%s", networkPolicy.GetNamespace(), networkPolicy.GetName())
 	r.log.V(4).Info("Reconciling custom network policy", "name", networkPolicyName, "component", npConfig.ComponentName)
@@ -113,15 +124,15 @@ func (r *Reconciler) createOrApplyCustomNetworkPolicy(esc *operatorv1alpha1.Exte
 	}
 
 	switch {
-	case exists && common.HasObjectChanged(networkPolicy, fetched, &resourceMetadata):
+	case exists && r.hasObjectDrifted(networkPolicy, fetched):
 		r.log.V(1).Info("NetworkPolicy modified, updating", "name", networkPolicyName)
 		common.RemoveObsoleteAnnotations(networkPolicy, resourceMetadata)
-		if err := r.UpdateWithRetry(r.ctx, networkPolicy); err != nil {
+		if err := r.Apply(r.ctx, networkPolicy); err != nil {
 			return common.FromClientError(err, "failed to update network policy %s", networkPolicyName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "NetworkPolicy %s updated", networkPolicyName)
 	case !exists:
-		if err := r.Create(r.ctx, networkPolicy); err != nil {
+		if err := r.Apply(r.ctx, networkPolicy); err != nil {
 			return common.FromClientError(err, "failed to create network policy %s", networkPolicyName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "NetworkPolicy %s created", networkPolicyName)
@@ -132,11 +143,18 @@ func (r *Reconciler) createOrApplyCustomNetworkPolicy(esc *operatorv1alpha1.Exte
 	return nil
 }
 
-// createOrApplyNetworkPolicyFromAsset decodes a NetworkPolicy YAML asset and ensures it exists in the cluster.
-func (r *Reconciler) createOrApplyNetworkPolicyFromAsset(esc *operatorv1alpha1.ExternalSecretsConfig, assetName string, resourceMetadata common.ResourceMetadata, externalSecretsConfigCreateRecon bool) error {
+// createOrApplyNetworkPolicyFromAsset decodes a NetworkPolicy YAML asset and ensures it exists in the cluster. When
+// monitoring is enabled, the ingress on the metrics port is allowed from the platform monitoring namespace.
+func (r *Reconciler) createOrApplyNetworkPolicyFromAsset(esc *operatorv1alpha1.ExternalSecretsConfig, assetName string, metricsPort int32, resourceMetadata common.ResourceMetadata, externalSecretsConfigCreateRecon bool) error {
 	networkPolicy := common.DecodeNetworkPolicyObjBytes(assets.MustAsset(assetName))
 	updateNamespace(networkPolicy, esc)
+	if metricsPort != 0 && isMonitoringEnabled(esc) {
+		networkPolicy.Spec.Ingress = append(networkPolicy.Spec.Ingress, getMonitoringIngressRule(metricsPort))
+	}
 	common.ApplyResourceMetadata(networkPolicy, resourceMetadata)
+	if err := r.applyOverride(esc, networkPolicy); err != nil {
+		return err
+	}
 
 	networkPolicyName := fmt.Sprintf("%s/%s", networkPolicy.GetNamespace(), networkPolicy.GetName())
 	r.log.V(4).Info("Reconciling static network policy", "name", networkPolicyName)
@@ -152,15 +170,15 @@ func (r *Reconciler) createOrApplyNetworkPolicyFromAsset(esc *operatorv1alpha1.E
 	}
 
 	switch {
-	case exists && common.HasObjectChanged(networkPolicy, fetched, &resourceMetadata):
+	case exists && r.hasObjectDrifted(networkPolicy, fetched):
 		r.log.V(1).Info("NetworkPolicy modified, updating", "name", networkPolicyName)
 		common.RemoveObsoleteAnnotations(networkPolicy, resourceMetadata)
-		if err := r.UpdateWithRetry(r.ctx, networkPolicy); err != nil {
+		if err := r.Apply(r.ctx, networkPolicy); err != nil {
 			return common.FromClientError(err, "failed to update network policy %s", networkPolicyName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "NetworkPolicy %s updated", networkPolicyName)
 	case !exists:
-		if err := r.Create(r.ctx, networkPolicy); err != nil {
+		if err := r.Apply(r.ctx, networkPolicy); err != nil {
 			return common.FromClientError(err, "failed to create network policy %s", networkPolicyName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "NetworkPolicy %s created", networkPolicyName)
@@ -171,6 +189,27 @@ func (r *Reconciler) createOrApplyNetworkPolicyFromAsset(esc *operatorv1alpha1.E
 	return nil
 }
 
+// getMonitoringIngressRule returns the ingress rule allowing the platform monitoring to scrape the metrics port.
+func getMonitoringIngressRule(port int32) networkingv1.NetworkPolicyIngressRule {
+	return networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{
+			{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						corev1.LabelMetadataName: platformMonitoringNamespace,
+					},
+				},
+			},
+		},
+		Ports: []networkingv1.NetworkPolicyPort{
+			{
+				Protocol: ptr.To(corev1.ProtocolTCP),
+				Port:     ptr.To(intstr.FromInt32(port)),
+			},
+		},
+	}
+}
+
 // buildNetworkPolicyFromConfig constructs a NetworkPolicy object from the API configuration.
 func (r *Reconciler) buildNetworkPolicyFromConfig(esc *operatorv1alpha1.ExternalSecretsConfig, npConfig operatorv1alpha1.NetworkPolicy, resourceMetadata common.ResourceMetadata) (*networkingv1.NetworkPolicy, error) {
 	namespace := getNamespace(esc)
@@ -8,13 +8,17 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
 	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
 	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+	"github.com/openshift/external-secrets-operator/pkg/operator/assets"
 )
 
 // staticNetworkPolicies returns a map of all static network policy names to their asset paths.
@@ -52,7 +56,7 @@ func TestCreateOrApplyStaticNetworkPolicies(t *testing.T) {
 					expectedNPMap[name] = testNetworkPolicy(path)
 				}
 
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if np, ok := obj.(*networkingv1.NetworkPolicy); ok {
 						if _, found := expectedNPMap[np.Name]; found {
 							return nil
@@ -68,7 +72,7 @@ func TestCreateOrApplyStaticNetworkPolicies(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					expectedNP := testNetworkPolicy(allowBitwardenServerTrafficAssetName)
 					if np, ok := obj.(*networkingv1.NetworkPolicy); ok {
 						if np.Name == expectedNP.Name {
@@ -94,7 +98,7 @@ func TestCreateOrApplyStaticNetworkPolicies(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if np, ok := obj.(*networkingv1.NetworkPolicy); ok {
 						if np.Name == "allow-api-server-egress-for-cert-controller" {
 							return fmt.Errorf("cert-controller policy should not be created")
@@ -126,7 +130,7 @@ func TestCreateOrApplyStaticNetworkPolicies(t *testing.T) {
 					}
 					return true, nil
 				})
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					return nil
 				})
 			},
@@ -137,7 +141,7 @@ func TestCreateOrApplyStaticNetworkPolicies(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if np, ok := obj.(*networkingv1.NetworkPolicy); ok && np.Name == "deny-all-traffic" {
 						return commontest.ErrTestClient
 					}
@@ -169,7 +173,7 @@ func TestCreateOrApplyStaticNetworkPolicies(t *testing.T) {
 					}
 					return true, nil
 				})
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if _, ok := obj.(*networkingv1.NetworkPolicy); ok {
 						return commontest.ErrTestClient
 					}
@@ -184,7 +188,7 @@ func TestCreateOrApplyStaticNetworkPolicies(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if np, ok := obj.(*networkingv1.NetworkPolicy); ok {
 						// Verify annotations are applied
 						if np.Annotations == nil {
@@ -212,7 +216,7 @@ func TestCreateOrApplyStaticNetworkPolicies(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if np, ok := obj.(*networkingv1.NetworkPolicy); ok {
 						// Verify all annotations from spec are present
 						if np.Annotations["custom-policy"] != "value" {
@@ -275,7 +279,7 @@ func TestCreateOrApplyCustomNetworkPolicies(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if np, ok := obj.(*networkingv1.NetworkPolicy); ok {
 						if np.Name != "test-custom-policy" {
 							return fmt.Errorf("unexpected network policy name: %s", np.Name)
@@ -327,7 +331,7 @@ func TestCreateOrApplyCustomNetworkPolicies(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if _, ok := obj.(*networkingv1.NetworkPolicy); ok {
 						return commontest.ErrTestClient
 					}
@@ -360,7 +364,7 @@ func TestCreateOrApplyCustomNetworkPolicies(t *testing.T) {
 					}
 					return true, nil
 				})
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					return nil
 				})
 			},
@@ -547,3 +551,101 @@ func TestBuildNetworkPolicyFromConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestStaticNetworkPoliciesAllowServiceMonitors(t *testing.T) {
+	r := testReconciler(t)
+	mock := &fakes.FakeCtrlClient{}
+	r.CtrlClient = mock
+
+	esc := commontest.TestExternalSecretsConfig()
+	esc.Spec.Plugins.BitwardenSecretManagerProvider = &operatorv1alpha1.BitwardenSecretManagerProvider{
+		Mode: operatorv1alpha1.Enabled,
+	}
+	if err := r.createOrApplyStaticNetworkPolicies(esc, testResourceMetadata(esc), false); err != nil {
+		t.Fatalf("createOrApplyStaticNetworkPolicies() unexpected error: %v", err)
+	}
+	policies := make([]*networkingv1.NetworkPolicy, 0, mock.ApplyCallCount())
+	for i := range mock.ApplyCallCount() {
+		_, obj, _ := mock.ApplyArgsForCall(i)
+		policies = append(policies, obj.(*networkingv1.NetworkPolicy))
+	}
+
+	monitoringNamespaceLabels := labels.Set{corev1.LabelMetadataName: platformMonitoringNamespace}
+	for _, target := range getServiceMonitorTargets(esc) {
+		if !target.enabled {
+			continue
+		}
+		service := common.DecodeServiceObjBytes(assets.MustAsset(target.serviceAssetName))
+		port := testScrapedPort(t, service, getServiceMonitorObject(esc, target, testResourceMetadata(esc)))
+
+		allowed := false
+		for _, np := range policies {
+			selector, err := metav1.LabelSelectorAsSelector(&np.Spec.PodSelector)
+			if err != nil {
+				t.Fatalf("invalid pod selector in %s network policy: %v", np.GetName(), err)
+			}
+			if !selector.Matches(labels.Set(service.Spec.Selector)) {
+				continue
+			}
+			for _, rule := range np.Spec.Ingress {
+				allowed = allowed || testIngressAllows(t, rule, monitoringNamespaceLabels, port)
+			}
+		}
+		if !allowed {
+			t.Errorf("%s servicemonitor cannot scrape port %d, no network policy allows the ingress from %s", service.GetName(), port, platformMonitoringNamespace)
+		}
+	}
+}
+
+// testScrapedPort returns the container port scraped with the ServiceMonitor of the service.
+func testScrapedPort(t *testing.T, service *corev1.Service, serviceMonitor *unstructured.Unstructured) int32 {
+	endpoints, _, _ := unstructured.NestedSlice(serviceMonitor.Object, "spec", "endpoints")
+	portName := endpoints[0].(map[string]interface{})["port"]
+
+	for _, servicePort := range service.Spec.Ports {
+		if servicePort.Name != portName {
+			continue
+		}
+		if servicePort.TargetPort.Type == intstr.Int {
+			return servicePort.TargetPort.IntVal
+		}
+		for _, assetName := range []string{controllerDeploymentAssetName, webhookDeploymentAssetName, certControllerDeploymentAssetName, bitwardenDeploymentAssetName} {
+			deployment := common.DecodeDeploymentObjBytes(assets.MustAsset(assetName))
+			if !labels.SelectorFromSet(service.Spec.Selector).Matches(labels.Set(deployment.Spec.Template.GetLabels())) {
+				continue
+			}
+			for _, container := range deployment.Spec.Template.Spec.Containers {
+				for _, containerPort := range container.Ports {
+					if containerPort.Name == servicePort.TargetPort.StrVal {
+						return containerPort.ContainerPort
+					}
+				}
+			}
+		}
+	}
+	t.Fatalf("port %v scraped with the servicemonitor not found in %s service", portName, service.GetName())
+	return 0
+}
+
+// testIngressAllows returns whether the ingress rule allows the traffic on the port from the pods in the namespace
+// with the labels.
+func testIngressAllows(t *testing.T, rule networkingv1.NetworkPolicyIngressRule, namespaceLabels labels.Set, port int32) bool {
+	fromAllowed := len(rule.From) == 0
+	for _, peer := range rule.From {
+		if peer.NamespaceSelector == nil || peer.PodSelector != nil || peer.IPBlock != nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(peer.NamespaceSelector)
+		if err != nil {
+			t.Fatalf("invalid namespace selector in network policy: %v", err)
+		}
+		fromAllowed = fromAllowed || selector.Matches(namespaceLabels)
+	}
+
+	portAllowed := len(rule.Ports) == 0
+	for _, p := range rule.Ports {
+		portAllowed = portAllowed || p.Port == nil || p.Port.IntValue() == int(port)
+	}
+
+	return fromAllowed && portAllowed
+}
@@ -0,0 +1,158 @@
+package external_secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+)
+
+// applyOverride patches obj, the desired state of a resource created for the external-secrets deployment, with the
+// override configured for the resource in spec.overrides. The patched object is validated with a dry-run apply, and
+// obj is left unchanged when the patch fails to apply or to validate. The result is recorded for reporting in
+// status.overrides.
+func (r *Reconciler) applyOverride(esc *operatorv1alpha1.ExternalSecretsConfig, obj client.Object) error {
+	if len(esc.Spec.Overrides) == 0 {
+		return nil
+	}
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return common.NewIrrecoverableError(err, "failed to get kind of %s resource", obj.GetName())
+	}
+	index := slices.IndexFunc(esc.Spec.Overrides, func(o operatorv1alpha1.ResourceOverride) bool {
+		return o.Kind == gvk.Kind && o.Name == obj.GetName()
+	})
+	if index < 0 {
+		return nil
+	}
+	override := esc.Spec.Overrides[index]
+
+	status := operatorv1alpha1.ResourceOverrideStatus{
+		Kind:  override.Kind,
+		Name:  override.Name,
+		State: operatorv1alpha1.OverrideStateApplied,
+	}
+	patched, err := patchObject(obj, override)
+	if err == nil {
+		validated, ok := patched.DeepCopyObject().(client.Object)
+		if !ok {
+			return common.NewIrrecoverableError(fmt.Errorf("type %T does not implement client.Object", patched), "failed to validate override of %s %s resource", override.Kind, override.Name)
+		}
+		if err = r.Apply(r.ctx, validated, client.DryRunAll); err != nil && !isInvalidOverrideError(err) {
+			return common.FromClientError(err, "failed to validate override of %s %s resource", override.Kind, override.Name)
+		}
+	}
+	if err != nil {
+		r.log.Error(err, "ignoring invalid override", "kind", override.Kind, "name", override.Name)
+		status.State = operatorv1alpha1.OverrideStateInvalid
+		status.Message = err.Error()
+	} else {
+		r.log.V(1).Info("applied override", "kind", override.Kind, "name", override.Name)
+		reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(patched).Elem())
+	}
+	r.overrideStatuses = append(r.overrideStatuses, status)
+	return nil
+}
+
+// patchObject returns a copy of obj patched with the override. The patch must not change the name or the namespace
+// of the resource, and must not set fields unknown to the kind.
+func patchObject(obj client.Object, override operatorv1alpha1.ResourceOverride) (client.Object, error) {
+	original, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s resource: %w", obj.GetName(), err)
+	}
+
+	var data []byte
+	switch override.PatchType {
+	case operatorv1alpha1.OverridePatchTypeJSONPatch:
+		patch, err := jsonpatch.DecodePatch([]byte(override.Patch))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode JSON patch: %w", err)
+		}
+		if data, err = patch.Apply(original); err != nil {
+			return nil, fmt.Errorf("failed to apply JSON patch: %w", err)
+		}
+	case operatorv1alpha1.OverridePatchTypeStrategicMergePatch:
+		if data, err = strategicpatch.StrategicMergePatch(original, []byte(override.Patch), obj); err != nil {
+			return nil, fmt.Errorf("failed to apply strategic merge patch: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported patch type %q", override.PatchType)
+	}
+
+	patched, ok := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("failed to create new instance of %T: type does not implement client.Object", obj)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(patched); err != nil {
+		return nil, fmt.Errorf("failed to decode patched resource: %w", err)
+	}
+	if patched.GetName() != obj.GetName() || patched.GetNamespace() != obj.GetNamespace() {
+		return nil, fmt.Errorf("patch must not change the name or the namespace of the resource")
+	}
+	return patched, nil
+}
+
+// isInvalidOverrideError returns whether the dry-run apply of a patched resource failed for the patched resource
+// being rejected by the API server, rather than for a failure to reach it.
+func isInvalidOverrideError(err error) bool {
+	return errors.IsInvalid(err) || errors.IsBadRequest(err) || errors.IsForbidden(err)
+}
+
+// setOverridesStatus sets status.overrides with the results of the overrides applied in the reconciliation, and the
+// UnsupportedOverrides condition, which is removed when there are no overrides. Returns whether the status changed.
+func (r *Reconciler) setOverridesStatus(esc *operatorv1alpha1.ExternalSecretsConfig) bool {
+	var statuses []operatorv1alpha1.ResourceOverrideStatus
+	var notApplied []string
+	for _, override := range esc.Spec.Overrides {
+		status := operatorv1alpha1.ResourceOverrideStatus{
+			Kind:    override.Kind,
+			Name:    override.Name,
+			State:   operatorv1alpha1.OverrideStateNotFound,
+			Message: "no resource of the kind with the name is created for the external-secrets deployment",
+		}
+		if index := slices.IndexFunc(r.overrideStatuses, func(s operatorv1alpha1.ResourceOverrideStatus) bool {
+			return s.Kind == override.Kind && s.Name == override.Name
+		}); index >= 0 {
+			status = r.overrideStatuses[index]
+		}
+		if status.State != operatorv1alpha1.OverrideStateApplied {
+			notApplied = append(notApplied, fmt.Sprintf("%s %s", status.Kind, status.Name))
+		}
+		statuses = append(statuses, status)
+	}
+	changed := !reflect.DeepEqual(esc.Status.Overrides, statuses)
+	esc.Status.Overrides = statuses
+
+	if len(statuses) == 0 {
+		return apimeta.RemoveStatusCondition(&esc.Status.Conditions, operatorv1alpha1.UnsupportedOverrides) || changed
+	}
+	cond := metav1.Condition{
+		Type:               operatorv1alpha1.UnsupportedOverrides,
+		Status:             metav1.ConditionTrue,
+		Reason:             operatorv1alpha1.ReasonCompleted,
+		Message:            fmt.Sprintf("%d overrides applied to the external-secrets resources, which is not a supported configuration", len(statuses)),
+		ObservedGeneration: esc.GetGeneration(),
+	}
+	if len(notApplied) > 0 {
+		cond.Reason = operatorv1alpha1.ReasonFailed
+		cond.Message = fmt.Sprintf("%d of %d overrides not applied, see status.overrides: %s",
+			len(notApplied), len(statuses), strings.Join(notApplied, ", "))
+	}
+	return apimeta.SetStatusCondition(&esc.Status.Conditions, cond) || changed
+}
@@ -0,0 +1,211 @@
+package external_secrets
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	operatorclient "github.com/openshift/external-secrets-operator/pkg/controller/client"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+func TestApplyOverride(t *testing.T) {
+	tests := []struct {
+		name       string
+		override   operatorv1alpha1.ResourceOverride
+		dryRunErr  error
+		wantState  operatorv1alpha1.OverrideState
+		wantDryRun bool
+		wantErr    bool
+		verify     func(*testing.T, *appsv1.Deployment)
+	}{
+		{
+			name: "strategic merge patch applied",
+			override: operatorv1alpha1.ResourceOverride{
+				Kind:      "Deployment",
+				Name:      controllerDeploymentName,
+				PatchType: operatorv1alpha1.OverridePatchTypeStrategicMergePatch,
+				Patch:     `{"spec":{"template":{"spec":{"dnsPolicy":"Default","containers":[{"name":"external-secrets","ports":[{"name":"debug","containerPort":6060}]}]}}}}`,
+			},
+			wantState:  operatorv1alpha1.OverrideStateApplied,
+			wantDryRun: true,
+			verify: func(t *testing.T, d *appsv1.Deployment) {
+				spec := d.Spec.Template.Spec
+				if spec.DNSPolicy != corev1.DNSDefault {
+					t.Errorf("dnsPolicy = %q, want %q", spec.DNSPolicy, corev1.DNSDefault)
+				}
+				if len(spec.Containers) != 1 || spec.Containers[0].Image != commontest.TestExternalSecretsImageName ||
+					len(spec.Containers[0].Ports) != 1 || spec.Containers[0].Ports[0].ContainerPort != 6060 {
+					t.Errorf("containers = %+v, want the container merged with the debug port", spec.Containers)
+				}
+			},
+		},
+		{
+			name: "JSON patch applied",
+			override: operatorv1alpha1.ResourceOverride{
+				Kind:      "Deployment",
+				Name:      controllerDeploymentName,
+				PatchType: operatorv1alpha1.OverridePatchTypeJSONPatch,
+				Patch:     `[{"op":"add","path":"/spec/template/spec/automountServiceAccountToken","value":false}]`,
+			},
+			wantState:  operatorv1alpha1.OverrideStateApplied,
+			wantDryRun: true,
+			verify: func(t *testing.T, d *appsv1.Deployment) {
+				if token := d.Spec.Template.Spec.AutomountServiceAccountToken; token == nil || *token {
+					t.Errorf("automountServiceAccountToken = %v, want false", token)
+				}
+			},
+		},
+		{
+			name: "override of another resource not applied",
+			override: operatorv1alpha1.ResourceOverride{
+				Kind:      "Deployment",
+				Name:      webhookDeploymentName,
+				PatchType: operatorv1alpha1.OverridePatchTypeStrategicMergePatch,
+				Patch:     `{"spec":{"template":{"spec":{"dnsPolicy":"Default"}}}}`,
+			},
+		},
+		{
+			name: "JSON patch of missing field is invalid",
+			override: operatorv1alpha1.ResourceOverride{
+				Kind:      "Deployment",
+				Name:      controllerDeploymentName,
+				PatchType: operatorv1alpha1.OverridePatchTypeJSONPatch,
+				Patch:     `[{"op":"replace","path":"/spec/template/spec/hostname","value":"debug"}]`,
+			},
+			wantState: operatorv1alpha1.OverrideStateInvalid,
+		},
+		{
+			name: "patch with unknown field is invalid",
+			override: operatorv1alpha1.ResourceOverride{
+				Kind:      "Deployment",
+				Name:      controllerDeploymentName,
+				PatchType: operatorv1alpha1.OverridePatchTypeStrategicMergePatch,
+				Patch:     `{"spec":{"template":{"spec":{"dnsPolcy":"Default"}}}}`,
+			},
+			wantState: operatorv1alpha1.OverrideStateInvalid,
+		},
+		{
+			name: "patch renaming the resource is invalid",
+			override: operatorv1alpha1.ResourceOverride{
+				Kind:      "Deployment",
+				Name:      controllerDeploymentName,
+				PatchType: operatorv1alpha1.OverridePatchTypeJSONPatch,
+				Patch:     `[{"op":"replace","path":"/metadata/name","value":"renamed"}]`,
+			},
+			wantState: operatorv1alpha1.OverrideStateInvalid,
+		},
+		{
+			name: "patch rejected by dry-run is invalid",
+			override: operatorv1alpha1.ResourceOverride{
+				Kind:      "Deployment",
+				Name:      controllerDeploymentName,
+				PatchType: operatorv1alpha1.OverridePatchTypeStrategicMergePatch,
+				Patch:     `{"spec":{"template":{"spec":{"dnsPolicy":"Unknown"}}}}`,
+			},
+			dryRunErr: apierrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "Deployment"}, controllerDeploymentName,
+				field.ErrorList{field.NotSupported(field.NewPath("spec", "template", "spec", "dnsPolicy"), "Unknown", []string{"ClusterFirst", "Default"})}),
+			wantState:  operatorv1alpha1.OverrideStateInvalid,
+			wantDryRun: true,
+		},
+		{
+			name: "dry-run failure is retried",
+			override: operatorv1alpha1.ResourceOverride{
+				Kind:      "Deployment",
+				Name:      controllerDeploymentName,
+				PatchType: operatorv1alpha1.OverridePatchTypeStrategicMergePatch,
+				Patch:     `{"spec":{"template":{"spec":{"dnsPolicy":"Default"}}}}`,
+			},
+			dryRunErr:  errors.New("connection refused"),
+			wantDryRun: true,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			_ = appsv1.AddToScheme(r.Scheme)
+			mock := &fakes.FakeCtrlClient{}
+			r.CtrlClient = mock
+			mock.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
+				if !operatorclient.IsDryRun(opts...) {
+					t.Errorf("override validated with an apply which is not a dry-run")
+				}
+				return tt.dryRunErr
+			})
+			esc := commontest.TestExternalSecretsConfig()
+			esc.Spec.Overrides = []operatorv1alpha1.ResourceOverride{tt.override}
+
+			deployment := testDeployment(controllerDeploymentName)
+			err := r.applyOverride(esc, deployment)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyOverride() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := mock.ApplyCallCount() > 0; got != tt.wantDryRun {
+				t.Errorf("dry-run apply called = %v, want %v", got, tt.wantDryRun)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var state operatorv1alpha1.OverrideState
+			if len(r.overrideStatuses) > 0 {
+				state = r.overrideStatuses[0].State
+			}
+			if state != tt.wantState {
+				t.Errorf("override state = %q, want %q (statuses %+v)", state, tt.wantState, r.overrideStatuses)
+			}
+			if tt.verify != nil {
+				tt.verify(t, deployment)
+			} else if deployment.Spec.Template.Spec.DNSPolicy != "" {
+				t.Errorf("deployment modified by an override not applied: %+v", deployment.Spec.Template.Spec)
+			}
+		})
+	}
+}
+
+func TestSetOverridesStatus(t *testing.T) {
+	r := testReconciler(t)
+	esc := commontest.TestExternalSecretsConfig()
+	esc.Spec.Overrides = []operatorv1alpha1.ResourceOverride{
+		{Kind: "Deployment", Name: controllerDeploymentName},
+		{Kind: "Service", Name: "missing"},
+	}
+	r.overrideStatuses = []operatorv1alpha1.ResourceOverrideStatus{
+		{Kind: "Deployment", Name: controllerDeploymentName, State: operatorv1alpha1.OverrideStateApplied},
+	}
+
+	if !r.setOverridesStatus(esc) {
+		t.Errorf("setOverridesStatus() expected status to change")
+	}
+	if len(esc.Status.Overrides) != 2 || esc.Status.Overrides[1].State != operatorv1alpha1.OverrideStateNotFound {
+		t.Errorf("status.overrides = %+v, want the missing Service not found", esc.Status.Overrides)
+	}
+	cond := apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.UnsupportedOverrides)
+	if cond == nil || cond.Reason != operatorv1alpha1.ReasonFailed {
+		t.Errorf("%s condition = %+v, want reason %s", operatorv1alpha1.UnsupportedOverrides, cond, operatorv1alpha1.ReasonFailed)
+	}
+	if r.setOverridesStatus(esc) {
+		t.Errorf("setOverridesStatus() expected no change for the same results")
+	}
+
+	esc.Spec.Overrides = nil
+	r.overrideStatuses = nil
+	if !r.setOverridesStatus(esc) {
+		t.Errorf("setOverridesStatus() expected status to change when overrides are removed")
+	}
+	if esc.Status.Overrides != nil || apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.UnsupportedOverrides) != nil {
+		t.Errorf("expected status.overrides and %s condition to be removed", operatorv1alpha1.UnsupportedOverrides)
+	}
+}
@@ -0,0 +1,260 @@
+package external_secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	operatorclient "github.com/openshift/external-secrets-operator/pkg/controller/client"
+)
+
+const (
+	// maxPlannedChanges is the maximum number of the changes reported in the status.
+	maxPlannedChanges = 256
+)
+
+// plannedActionSymbols are the symbols prefixing the resources in the written changes for the planned actions.
+var plannedActionSymbols = map[operatorv1alpha1.PlannedAction]string{
+	operatorv1alpha1.PlannedActionCreate: "+",
+	operatorv1alpha1.PlannedActionUpdate: "~",
+	operatorv1alpha1.PlannedActionDelete: "-",
+}
+
+// ResourceChange is a change the controller would make to a managed resource.
+type ResourceChange struct {
+	// Object is the desired state of the resource, or the current state of the resource to be deleted.
+	Object *unstructured.Unstructured
+
+	// Action is the change the controller would make to the resource.
+	Action operatorv1alpha1.PlannedAction
+
+	// Fields are the fields of the resource differing from the desired state, for the update action.
+	Fields []FieldChange
+}
+
+// FieldChange is a field of a managed resource differing from the desired state.
+type FieldChange struct {
+	// Path is the path of the field in the resource.
+	Path string
+
+	// Current is the value of the field in the resource, and is nil when not set.
+	Current interface{}
+
+	// Desired is the value of the field in the desired state, and is nil when the field is to be removed.
+	Desired interface{}
+}
+
+// Plan returns the changes the controller would make to the resources in the cluster for deploying the
+// external-secrets operand with the configuration in the ExternalSecretsConfig and the ExternalSecretsManager,
+// without making them. The bitwarden clustersecretstore is not planned, as it requires the CA certificate issued
+// for bitwarden-sdk-server.
+func Plan(ctx context.Context, config *rest.Config, scheme *runtime.Scheme, esc *operatorv1alpha1.ExternalSecretsConfig, esm *operatorv1alpha1.ExternalSecretsManager) ([]ResourceChange, error) {
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	optionalResources := make(map[string]struct{})
+	for _, crd := range []struct {
+		name, groupVersion, gkv string
+	}{
+		{name: certificateCRDName, groupVersion: certificateCRDGroupVersion, gkv: certificateCRDGKV},
+		{name: credentialsRequestCRDName, groupVersion: credentialsRequestCRDGroupVersion, gkv: credentialsRequestCRDGKV},
+		{name: serviceMonitorCRDName, groupVersion: monitoringCRDGroupVersion, gkv: serviceMonitorCRDGKV},
+		{name: prometheusRuleCRDName, groupVersion: monitoringCRDGroupVersion, gkv: prometheusRuleCRDGKV},
+	} {
+		exist, err := isCRDInstalled(config, crd.name, crd.groupVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s/%s CRD is installed: %w", crd.groupVersion, crd.name, err)
+		}
+		if exist {
+			optionalResources[crd.gkv] = struct{}{}
+		}
+	}
+
+	if esm == nil {
+		esm = &operatorv1alpha1.ExternalSecretsManager{}
+	}
+	live := &operatorclient.CtrlClientImpl{Client: c}
+	r := &Reconciler{
+		CtrlClient:            live,
+		UncachedClient:        live,
+		Scheme:                scheme,
+		ctx:                   ctx,
+		eventRecorder:         &record.FakeRecorder{},
+		log:                   logr.Discard(),
+		esm:                   esm,
+		optionalResourcesList: optionalResources,
+	}
+	if err := r.reconcileClusterWorkloadIdentity(esc); err != nil {
+		return nil, err
+	}
+	return r.planChanges(esc, live)
+}
+
+// planChanges returns the changes the reconciler would make to the resources read with the live client.
+func (r *Reconciler) planChanges(esc *operatorv1alpha1.ExternalSecretsConfig, live operatorclient.CtrlClient) ([]ResourceChange, error) {
+	c := &renderClient{scheme: r.Scheme, esc: esc, esm: r.esm, live: live}
+	p := newRenderReconciler(r.ctx, c, r.log, r.optionalResourcesList)
+	p.clusterWorkloadIdentity = r.clusterWorkloadIdentity
+	if err := p.renderResources(esc); err != nil {
+		return nil, err
+	}
+	return c.changes, nil
+}
+
+// planExternalSecretsDeployment computes the changes the controller would make to the resources created for the
+// external-secrets deployment, and reports them in the status instead of making them.
+func (r *Reconciler) planExternalSecretsDeployment(esc *operatorv1alpha1.ExternalSecretsConfig) error {
+	if err := r.reconcileClusterWorkloadIdentity(esc); err != nil {
+		return err
+	}
+	changes, err := r.planChanges(esc, r.UncachedClient)
+	if err != nil {
+		return err
+	}
+
+	// the status is updated only when the planned changes or the generation planned for differ, for not writing
+	// the status on every periodic plan.
+	planned := plannedChanges(changes)
+	if esc.Status.Plan != nil && esc.Status.Plan.ObservedGeneration == esc.GetGeneration() &&
+		equality.Semantic.DeepEqual(esc.Status.Plan.Changes, planned) {
+		r.log.V(4).Info("planned changes unchanged, skipping status update", "name", esc.GetName())
+		return nil
+	}
+	if esc.Status.Plan == nil || !equality.Semantic.DeepEqual(esc.Status.Plan.Changes, planned) {
+		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Planned", "%d changes planned to the external-secrets resources", len(changes))
+	}
+	esc.Status.Plan = &operatorv1alpha1.ManagementPlan{
+		ObservedGeneration: esc.GetGeneration(),
+		LastPlannedTime:    metav1.Now(),
+		Changes:            planned,
+	}
+	return r.updateStatus(r.ctx, esc)
+}
+
+// plannedChanges returns the changes to be reported in the status, limited to maxPlannedChanges.
+func plannedChanges(changes []ResourceChange) []operatorv1alpha1.PlannedChange {
+	planned := make([]operatorv1alpha1.PlannedChange, 0, min(len(changes), maxPlannedChanges))
+	for _, change := range changes[:min(len(changes), maxPlannedChanges)] {
+		fields := make([]string, 0, len(change.Fields))
+		for _, field := range change.Fields {
+			fields = append(fields, field.Path)
+		}
+		planned = append(planned, operatorv1alpha1.PlannedChange{
+			APIVersion: change.Object.GetAPIVersion(),
+			Kind:       change.Object.GetKind(),
+			Namespace:  change.Object.GetNamespace(),
+			Name:       change.Object.GetName(),
+			Action:     change.Action,
+			Fields:     fields,
+		})
+	}
+	return planned
+}
+
+// WriteChanges writes the changes with a line for each resource, followed by a line for each field of the resource
+// with the current and the desired values.
+func WriteChanges(out io.Writer, changes []ResourceChange) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(out, "No changes, the resources are in the desired state.")
+		return err
+	}
+	for _, change := range changes {
+		name := change.Object.GetName()
+		if namespace := change.Object.GetNamespace(); namespace != "" {
+			name = namespace + "/" + name
+		}
+		if _, err := fmt.Fprintf(out, "%s %s %s %s\n", plannedActionSymbols[change.Action], change.Object.GetAPIVersion(), change.Object.GetKind(), name); err != nil {
+			return err
+		}
+		for _, field := range change.Fields {
+			if _, err := fmt.Fprintf(out, "    %s: %s -> %s\n", field.Path, formatFieldValue(field.Current), formatFieldValue(field.Desired)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// formatFieldValue returns the value of a field encoded as JSON, or <unset> when the field is not set.
+func formatFieldValue(value interface{}) string {
+	if value == nil {
+		return "<unset>"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// diffObject returns the fields of the applied object differing from the current object, where the applied object
+// is the object returned by the API server for a dry-run apply of the desired object. The applied object has the
+// fields defaulted by the API server and set by other controllers as in the current object, the differences are
+// the fields the apply would change or remove. Only the labels and annotations of the metadata are compared.
+func diffObject(current, applied *unstructured.Unstructured) []FieldChange {
+	var changes []FieldChange
+	for _, field := range []string{"labels", "annotations"} {
+		currentValue, _, _ := unstructured.NestedFieldNoCopy(current.Object, "metadata", field)
+		appliedValue, _, _ := unstructured.NestedFieldNoCopy(applied.Object, "metadata", field)
+		diffField("metadata."+field, currentValue, appliedValue, &changes)
+	}
+	keys := slices.Collect(maps.Keys(current.Object))
+	keys = append(keys, slices.Collect(maps.Keys(applied.Object))...)
+	slices.Sort(keys)
+	for _, key := range slices.Compact(keys) {
+		switch key {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		}
+		diffField(key, current.Object[key], applied.Object[key], &changes)
+	}
+	return changes
+}
+
+// diffField appends the changes of the applied value of the field at the path from the current value.
+func diffField(path string, current, applied interface{}, changes *[]FieldChange) {
+	switch appliedValue := applied.(type) {
+	case map[string]interface{}:
+		if currentValue, ok := current.(map[string]interface{}); ok {
+			keys := slices.Collect(maps.Keys(currentValue))
+			keys = append(keys, slices.Collect(maps.Keys(appliedValue))...)
+			slices.Sort(keys)
+			for _, key := range slices.Compact(keys) {
+				diffField(path+"."+key, currentValue[key], appliedValue[key], changes)
+			}
+			return
+		}
+		if current == nil && len(appliedValue) == 0 {
+			return
+		}
+	case []interface{}:
+		if current == nil && len(appliedValue) == 0 {
+			return
+		}
+		if currentValue, ok := current.([]interface{}); ok && len(currentValue) == len(appliedValue) {
+			for i := range appliedValue {
+				diffField(fmt.Sprintf("%s[%d]", path, i), currentValue[i], appliedValue[i], changes)
+			}
+			return
+		}
+	}
+	if !equality.Semantic.DeepEqual(current, applied) {
+		*changes = append(*changes, FieldChange{Path: path, Current: current, Desired: applied})
+	}
+}
@@ -0,0 +1,225 @@
+package external_secrets
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	operatorclient "github.com/openshift/external-secrets-operator/pkg/controller/client"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+func TestDiffObject(t *testing.T) {
+	tests := []struct {
+		name    string
+		current map[string]interface{}
+		desired map[string]interface{}
+		want    []FieldChange
+	}{
+		{
+			name: "fields not applied are ignored",
+			current: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "test", "resourceVersion": "10", "labels": map[string]interface{}{"app": "test", "extra": "true"}},
+				"spec":     map[string]interface{}{"replicas": int64(1), "revisionHistoryLimit": int64(10)},
+				"status":   map[string]interface{}{"replicas": int64(1)},
+			},
+			desired: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "test", "resourceVersion": "11", "labels": map[string]interface{}{"app": "test", "extra": "true"}},
+				"spec":     map[string]interface{}{"replicas": int64(1), "revisionHistoryLimit": int64(10)},
+			},
+		},
+		{
+			name: "fields removed by the apply are reported",
+			current: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "test", "labels": map[string]interface{}{"app": "test", "team": "platform"}},
+				"spec":     map[string]interface{}{"replicas": int64(1), "paused": true},
+			},
+			desired: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "test", "labels": map[string]interface{}{"app": "test"}},
+				"spec":     map[string]interface{}{"replicas": int64(1)},
+			},
+			want: []FieldChange{
+				{Path: "metadata.labels.team", Current: "platform"},
+				{Path: "spec.paused", Current: true},
+			},
+		},
+		{
+			name: "changed and missing fields are reported",
+			current: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "test", "labels": map[string]interface{}{"app": "test"}},
+				"spec":     map[string]interface{}{"replicas": int64(1)},
+			},
+			desired: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "test", "labels": map[string]interface{}{"app": "test", "team": "platform"}},
+				"spec":     map[string]interface{}{"replicas": int64(2), "paused": false},
+			},
+			want: []FieldChange{
+				{Path: "metadata.labels.team", Desired: "platform"},
+				{Path: "spec.paused", Desired: false},
+				{Path: "spec.replicas", Current: int64(1), Desired: int64(2)},
+			},
+		},
+		{
+			name: "lists of same length are compared by index",
+			current: map[string]interface{}{
+				"spec": map[string]interface{}{"containers": []interface{}{
+					map[string]interface{}{"name": "controller", "image": "old", "terminationMessagePath": "/dev/termination-log"},
+				}},
+			},
+			desired: map[string]interface{}{
+				"spec": map[string]interface{}{"containers": []interface{}{
+					map[string]interface{}{"name": "controller", "image": "new", "terminationMessagePath": "/dev/termination-log"},
+				}},
+			},
+			want: []FieldChange{
+				{Path: "spec.containers[0].image", Current: "old", Desired: "new"},
+			},
+		},
+		{
+			name: "lists of different length are compared as a whole",
+			current: map[string]interface{}{
+				"spec": map[string]interface{}{"args": []interface{}{"--a"}},
+			},
+			desired: map[string]interface{}{
+				"spec": map[string]interface{}{"args": []interface{}{"--a", "--b"}, "volumes": []interface{}{}},
+			},
+			want: []FieldChange{
+				{Path: "spec.args", Current: []interface{}{"--a"}, Desired: []interface{}{"--a", "--b"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := diffObject(&unstructured.Unstructured{Object: tt.current}, &unstructured.Unstructured{Object: tt.desired})
+			if !reflect.DeepEqual(changes, tt.want) {
+				t.Errorf("diffObject() = %v, want %v", changes, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanExternalSecretsDeployment(t *testing.T) {
+	t.Setenv(externalsecretsImageEnvVarName, commontest.TestExternalSecretsImageName)
+	t.Setenv(bitwardenImageEnvVarName, commontest.TestBitwardenImageName)
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(certmanagerv1.AddToScheme(scheme))
+	utilruntime.Must(operatorv1alpha1.AddToScheme(scheme))
+
+	r := testReconciler(t)
+	r.Scheme = scheme
+	esc := commontest.TestExternalSecretsConfig()
+	esc.Spec.ManagementState = operatorv1alpha1.ManagementStatePlan
+
+	outdated, err := r.getDeploymentObject(controllerDeploymentAssetName, esc, testResourceMetadata(esc))
+	if err != nil {
+		t.Fatalf("failed to get deployment object: %v", err)
+	}
+	outdated.Spec.Template.Spec.Containers[0].Image = "external-secrets:outdated"
+
+	var updatedStatus *operatorv1alpha1.ExternalSecretsConfigStatus
+	mock := &fakes.FakeCtrlClient{}
+	mock.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+		if o, ok := obj.(*appsv1.Deployment); ok && ns.Name == outdated.GetName() {
+			outdated.DeepCopyInto(o)
+			return true, nil
+		}
+		return false, nil
+	})
+	mock.GetCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) error {
+		switch o := obj.(type) {
+		case *operatorv1alpha1.ExternalSecretsConfig:
+			esc.DeepCopyInto(o)
+		case *unstructured.Unstructured:
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(outdated)
+			if err != nil {
+				return err
+			}
+			o.SetUnstructuredContent(content)
+		}
+		return nil
+	})
+	mock.StatusUpdateCalls(func(ctx context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+		updatedStatus = obj.(*operatorv1alpha1.ExternalSecretsConfig).Status.DeepCopy()
+		return nil
+	})
+	// dry-run applies return the applied object unchanged, as the API server would for the deployment differing
+	// only in the applied fields.
+	mock.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
+		if !operatorclient.IsDryRun(opts...) {
+			t.Errorf("planExternalSecretsDeployment() applied %s %s", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName())
+		}
+		return nil
+	})
+	r.CtrlClient = mock
+	r.UncachedClient = mock
+
+	if err := r.planExternalSecretsDeployment(esc); err != nil {
+		t.Fatalf("planExternalSecretsDeployment() unexpected error: %v", err)
+	}
+	if mock.CreateCallCount() != 0 || mock.UpdateWithRetryCallCount() != 0 || mock.DeleteCallCount() != 0 {
+		t.Errorf("planExternalSecretsDeployment() modified resources: %d created, %d updated, %d deleted",
+			mock.CreateCallCount(), mock.UpdateWithRetryCallCount(), mock.DeleteCallCount())
+	}
+	if updatedStatus == nil || updatedStatus.Plan == nil {
+		t.Fatalf("planExternalSecretsDeployment() did not update status with plan")
+	}
+
+	var deploymentChange *operatorv1alpha1.PlannedChange
+	for i, change := range updatedStatus.Plan.Changes {
+		switch {
+		case change.Kind == "Deployment" && change.Name == outdated.GetName():
+			deploymentChange = &updatedStatus.Plan.Changes[i]
+		case change.Action != operatorv1alpha1.PlannedActionCreate:
+			t.Errorf("unexpected %s action planned for %s %s", change.Action, change.Kind, change.Name)
+		}
+	}
+	wantChange := &operatorv1alpha1.PlannedChange{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Namespace:  outdated.GetNamespace(),
+		Name:       outdated.GetName(),
+		Action:     operatorv1alpha1.PlannedActionUpdate,
+		Fields:     []string{"spec.template.spec.containers[0].image"},
+	}
+	if !reflect.DeepEqual(deploymentChange, wantChange) {
+		t.Errorf("planned deployment change = %+v, want %+v", deploymentChange, wantChange)
+	}
+	if updatedStatus.Plan.ObservedGeneration != esc.GetGeneration() || updatedStatus.Plan.LastPlannedTime.Equal(&metav1.Time{}) {
+		t.Errorf("unexpected plan metadata: %+v", updatedStatus.Plan)
+	}
+
+	// planning again with the same changes for the same generation does not update the status.
+	esc.Status.Plan = updatedStatus.Plan.DeepCopy()
+	if err := r.planExternalSecretsDeployment(esc); err != nil {
+		t.Fatalf("planExternalSecretsDeployment() unexpected error: %v", err)
+	}
+	if mock.StatusUpdateCallCount() != 1 {
+		t.Errorf("planExternalSecretsDeployment() updated status %d times for unchanged plan, want 1", mock.StatusUpdateCallCount())
+	}
+
+	// planning for a new generation updates the status.
+	esc.SetGeneration(esc.GetGeneration() + 1)
+	if err := r.planExternalSecretsDeployment(esc); err != nil {
+		t.Fatalf("planExternalSecretsDeployment() unexpected error: %v", err)
+	}
+	if mock.StatusUpdateCallCount() != 2 || updatedStatus.Plan.ObservedGeneration != esc.GetGeneration() {
+		t.Errorf("planExternalSecretsDeployment() did not update status for new generation: %d updates, plan %+v",
+			mock.StatusUpdateCallCount(), updatedStatus.Plan)
+	}
+}
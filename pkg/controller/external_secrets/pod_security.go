@@ -0,0 +1,123 @@
+package external_secrets
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+)
+
+const (
+	// podSecurityViolationMessage is contained in the error returned by the API server when a pod is rejected for
+	// not satisfying the enforce level of the namespace.
+	podSecurityViolationMessage = "violates PodSecurity"
+
+	// podSecurityWarningPrefix is the prefix of the warnings returned by the API server when a pod does not
+	// satisfy the warn level of the namespace.
+	podSecurityWarningPrefix = "would violate PodSecurity"
+)
+
+// podSecurityNamespaceLabels returns the namespace labels for the configured Pod Security Admission levels, along
+// with the label disabling the synchronization of the levels by OpenShift.
+func podSecurityNamespaceLabels(esc *operatorv1alpha1.ExternalSecretsConfig) map[string]string {
+	config := esc.Spec.ControllerConfig.NamespaceConfig
+	labels := make(map[string]string)
+	if config == nil {
+		return labels
+	}
+	if config.PodSecurity != nil {
+		for label, level := range map[string]operatorv1alpha1.PodSecurityLevel{
+			podSecurityEnforceLabel: config.PodSecurity.Enforce,
+			podSecurityAuditLabel:   config.PodSecurity.Audit,
+			podSecurityWarnLabel:    config.PodSecurity.Warn,
+		} {
+			labels[label] = string(getPodSecurityLevel(level))
+		}
+		labels[podSecurityLabelSyncLabel] = "false"
+	}
+	if common.EvalMode(config.ClusterMonitoring) {
+		labels[clusterMonitoringLabel] = "true"
+	}
+	return labels
+}
+
+// validatePodSecurity verifies the pods of the enabled external-secrets components are admitted under the Pod
+// Security Admission levels configured on the namespace, by creating the pods of the deployments in dry-run mode.
+// The configuration is rejected when the pods do not satisfy the enforce level, and the pods not satisfying the warn
+// level are reported in the events. The pods not satisfying the audit level are recorded in the API server audit log.
+func (r *Reconciler) validatePodSecurity(esc *operatorv1alpha1.ExternalSecretsConfig, resourceMetadata common.ResourceMetadata) error {
+	config := esc.Spec.ControllerConfig.NamespaceConfig
+	if config == nil || config.PodSecurity == nil {
+		return nil
+	}
+
+	for _, assetName := range getDeploymentAssetNames(esc) {
+		deployment, err := r.getDeploymentObject(assetName, esc, resourceMetadata)
+		if err != nil {
+			return err
+		}
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: deployment.GetName() + "-",
+				Namespace:    deployment.GetNamespace(),
+				Labels:       deployment.Spec.Template.GetLabels(),
+				Annotations:  deployment.Spec.Template.GetAnnotations(),
+			},
+			Spec: *deployment.Spec.Template.Spec.DeepCopy(),
+		}
+		var warnings []string
+		if err := r.UncachedClient.Create(withAPIWarnings(r.ctx, &warnings), pod, client.DryRunAll); err != nil {
+			if apierrors.IsForbidden(err) && strings.Contains(err.Error(), podSecurityViolationMessage) {
+				return common.NewIrrecoverableError(err, "%s deployment pods do not satisfy the pod security level configured for enforce", deployment.GetName())
+			}
+			return common.FromClientError(err, "failed to verify pod security of %s deployment pods", deployment.GetName())
+		}
+		for _, warning := range warnings {
+			if strings.HasPrefix(warning, podSecurityWarningPrefix) {
+				r.eventRecorder.Eventf(esc, corev1.EventTypeWarning, "PodSecurityViolation", "%s deployment pods do not satisfy the pod security level configured for warn: %s",
+					deployment.GetName(), warning)
+			}
+		}
+	}
+
+	return nil
+}
+
+// apiWarningsKey is the context key for collecting the warnings returned by the API server for a request.
+type apiWarningsKey struct{}
+
+// withAPIWarnings returns a context for collecting the warnings returned by the API server for the requests made
+// with it into warnings.
+func withAPIWarnings(ctx context.Context, warnings *[]string) context.Context {
+	return context.WithValue(ctx, apiWarningsKey{}, warnings)
+}
+
+// apiWarningHandler collects the warnings returned by the API server for the requests made with a context created
+// by withAPIWarnings, and passes all the warnings to the wrapped handler.
+type apiWarningHandler struct {
+	rest.WarningHandlerWithContext
+}
+
+// HandleWarningHeaderWithContext implements rest.WarningHandlerWithContext.
+func (h *apiWarningHandler) HandleWarningHeaderWithContext(ctx context.Context, code int, agent string, text string) {
+	if warnings, ok := ctx.Value(apiWarningsKey{}).(*[]string); ok && code == 299 {
+		*warnings = append(*warnings, text)
+	}
+	h.WarningHandlerWithContext.HandleWarningHeaderWithContext(ctx, code, agent, text)
+}
+
+// getPodSecurityLevel returns the configured level, or the restricted level when not configured.
+func getPodSecurityLevel(level operatorv1alpha1.PodSecurityLevel) operatorv1alpha1.PodSecurityLevel {
+	if level == "" {
+		return operatorv1alpha1.PodSecurityLevelRestricted
+	}
+	return level
+}
@@ -0,0 +1,183 @@
+package external_secrets
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+func TestPodSecurityNamespaceLabels(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     *operatorv1alpha1.NamespaceConfig
+		wantLabels map[string]string
+	}{
+		{
+			name:       "namespace config not set",
+			wantLabels: map[string]string{},
+		},
+		{
+			name: "pod security levels defaulted to restricted",
+			config: &operatorv1alpha1.NamespaceConfig{
+				PodSecurity: &operatorv1alpha1.PodSecurityConfig{
+					Warn: operatorv1alpha1.PodSecurityLevelBaseline,
+				},
+			},
+			wantLabels: map[string]string{
+				"pod-security.kubernetes.io/enforce":             "restricted",
+				"pod-security.kubernetes.io/audit":               "restricted",
+				"pod-security.kubernetes.io/warn":                "baseline",
+				"security.openshift.io/scc.podSecurityLabelSync": "false",
+			},
+		},
+		{
+			name: "cluster monitoring enabled",
+			config: &operatorv1alpha1.NamespaceConfig{
+				ClusterMonitoring: operatorv1alpha1.Enabled,
+			},
+			wantLabels: map[string]string{
+				"openshift.io/cluster-monitoring": "true",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			esc := commontest.TestExternalSecretsConfig()
+			esc.Spec.ControllerConfig.NamespaceConfig = tt.config
+			if labels := podSecurityNamespaceLabels(esc); !reflect.DeepEqual(labels, tt.wantLabels) {
+				t.Errorf("labels = %v, want %v", labels, tt.wantLabels)
+			}
+		})
+	}
+}
+
+func TestValidatePodSecurity(t *testing.T) {
+	t.Setenv(externalsecretsImageEnvVarName, commontest.TestExternalSecretsImageName)
+	t.Setenv(bitwardenImageEnvVarName, commontest.TestBitwardenImageName)
+
+	tests := []struct {
+		name          string
+		podSecurity   *operatorv1alpha1.PodSecurityConfig
+		bitwarden     bool
+		createErr     error
+		warnings      []string
+		wantPods      []string
+		wantErr       string
+		wantIrrecover bool
+		wantEvents    []string
+	}{
+		{
+			name: "pod security not configured",
+		},
+		{
+			name:        "operand pods admitted",
+			podSecurity: &operatorv1alpha1.PodSecurityConfig{},
+			wantPods:    []string{"external-secrets-", "external-secrets-webhook-", "external-secrets-cert-controller-"},
+		},
+		{
+			name:        "operand pods with bitwarden-sdk-server admitted",
+			podSecurity: &operatorv1alpha1.PodSecurityConfig{},
+			bitwarden:   true,
+			wantPods:    []string{"external-secrets-", "external-secrets-webhook-", "external-secrets-cert-controller-", "bitwarden-sdk-server-"},
+		},
+		{
+			name:        "operand pods denied for enforce level",
+			podSecurity: &operatorv1alpha1.PodSecurityConfig{},
+			createErr: apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "",
+				errors.New(`violates PodSecurity "restricted:latest": allowPrivilegeEscalation != false`)),
+			wantPods:      []string{"external-secrets-"},
+			wantErr:       "external-secrets deployment pods do not satisfy the pod security level configured for enforce",
+			wantIrrecover: true,
+		},
+		{
+			name:        "operand pods dry run failed",
+			podSecurity: &operatorv1alpha1.PodSecurityConfig{},
+			createErr:   apierrors.NewServerTimeout(schema.GroupResource{Resource: "pods"}, "create", 1),
+			wantPods:    []string{"external-secrets-"},
+			wantErr:     "failed to verify pod security of external-secrets deployment pods",
+		},
+		{
+			name:        "operand pods not satisfying warn level reported",
+			podSecurity: &operatorv1alpha1.PodSecurityConfig{Warn: operatorv1alpha1.PodSecurityLevelRestricted},
+			warnings: []string{
+				`would violate PodSecurity "restricted:latest": seccompProfile`,
+				"metadata.name: unrelated warning",
+			},
+			wantPods: []string{"external-secrets-", "external-secrets-webhook-", "external-secrets-cert-controller-"},
+			wantEvents: []string{
+				`Warning PodSecurityViolation external-secrets deployment pods do not satisfy the pod security level configured for warn: would violate PodSecurity "restricted:latest": seccompProfile`,
+				`Warning PodSecurityViolation external-secrets-webhook deployment pods do not satisfy the pod security level configured for warn: would violate PodSecurity "restricted:latest": seccompProfile`,
+				`Warning PodSecurityViolation external-secrets-cert-controller deployment pods do not satisfy the pod security level configured for warn: would violate PodSecurity "restricted:latest": seccompProfile`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			mock := &fakes.FakeCtrlClient{}
+			var pods []string
+			mock.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				createOpts := &client.CreateOptions{}
+				createOpts.ApplyOptions(opts)
+				if !reflect.DeepEqual(createOpts.DryRun, []string{metav1.DryRunAll}) {
+					t.Errorf("pod created without dry run: %v", createOpts.DryRun)
+				}
+				if obj.GetNamespace() != "external-secrets" {
+					t.Errorf("pod created in namespace %q, want external-secrets", obj.GetNamespace())
+				}
+				pods = append(pods, obj.GetGenerateName())
+				handler := &apiWarningHandler{WarningHandlerWithContext: rest.NoWarnings{}}
+				for _, warning := range tt.warnings {
+					handler.HandleWarningHeaderWithContext(ctx, 299, "-", warning)
+				}
+				return tt.createErr
+			})
+			r.UncachedClient = mock
+
+			esc := commontest.TestExternalSecretsConfig()
+			if tt.podSecurity != nil {
+				esc.Spec.ControllerConfig.NamespaceConfig = &operatorv1alpha1.NamespaceConfig{PodSecurity: tt.podSecurity}
+			}
+			if tt.bitwarden {
+				esc.Spec.Plugins.BitwardenSecretManagerProvider = &operatorv1alpha1.BitwardenSecretManagerProvider{
+					Mode: operatorv1alpha1.Enabled,
+				}
+			}
+
+			err := r.validatePodSecurity(esc, common.ResourceMetadata{})
+			if (tt.wantErr != "" || err != nil) && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("validatePodSecurity() err: %v, wantErr: %v", err, tt.wantErr)
+			}
+			if common.IsIrrecoverableError(err) != tt.wantIrrecover {
+				t.Errorf("validatePodSecurity() irrecoverable: %v, want: %v", common.IsIrrecoverableError(err), tt.wantIrrecover)
+			}
+			if !reflect.DeepEqual(pods, tt.wantPods) {
+				t.Errorf("pods created = %q, want %q", pods, tt.wantPods)
+			}
+
+			var events []string
+			for len(r.eventRecorder.(*record.FakeRecorder).Events) > 0 {
+				events = append(events, <-r.eventRecorder.(*record.FakeRecorder).Events)
+			}
+			if !reflect.DeepEqual(events, tt.wantEvents) {
+				t.Errorf("events = %q, want %q", events, tt.wantEvents)
+			}
+		})
+	}
+}
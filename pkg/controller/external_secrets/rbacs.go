@@ -99,6 +99,10 @@ func (r *Reconciler) createOrApplyCertControllerRBACResources(esc *operatorv1alp
 
 // createOrApplyClusterRole creates or updates given ClusterRole object.
 func (r *Reconciler) createOrApplyClusterRole(esc *operatorv1alpha1.ExternalSecretsConfig, obj *rbacv1.ClusterRole, resourceMetadata common.ResourceMetadata, recon bool) error {
+	if err := r.applyOverride(esc, obj); err != nil {
+		return err
+	}
+
 	var (
 		exist           bool
 		err             error
@@ -114,10 +118,10 @@ func (r *Reconciler) createOrApplyClusterRole(esc *operatorv1alpha1.ExternalSecr
 	if exist && recon {
 		r.eventRecorder.Eventf(esc, corev1.EventTypeWarning, "ResourceAlreadyExists", "%s clusterrole resource already exists, maybe from previous installation", clusterRoleName)
 	}
-	if exist && common.HasObjectChanged(obj, fetched, &resourceMetadata) {
+	if exist && r.hasObjectDrifted(obj, fetched) {
 		r.log.V(1).Info("clusterrole has been modified, updating to desired state", "name", clusterRoleName)
 		common.RemoveObsoleteAnnotations(obj, resourceMetadata)
-		if err := r.UpdateWithRetry(r.ctx, obj); err != nil {
+		if err := r.Apply(r.ctx, obj); err != nil {
 			return common.FromClientError(err, "failed to update %s clusterrole resource", clusterRoleName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "clusterrole resource %s reconciled back to desired state", clusterRoleName)
@@ -125,7 +129,7 @@ func (r *Reconciler) createOrApplyClusterRole(esc *operatorv1alpha1.ExternalSecr
 		r.log.V(4).Info("clusterrole resource already exists and is in expected state", "name", clusterRoleName)
 	}
 	if !exist {
-		if err := r.Create(r.ctx, obj); err != nil {
+		if err := r.Apply(r.ctx, obj); err != nil {
 			return common.FromClientError(err, "failed to create %s clusterrole resource", clusterRoleName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "clusterrole resource %s created", clusterRoleName)
@@ -145,6 +149,10 @@ func (r *Reconciler) getClusterRoleObject(esc *operatorv1alpha1.ExternalSecretsC
 
 // createOrApplyClusterRoleBinding creates or updates given ClusterRoleBinding object.
 func (r *Reconciler) createOrApplyClusterRoleBinding(esc *operatorv1alpha1.ExternalSecretsConfig, obj *rbacv1.ClusterRoleBinding, resourceMetadata common.ResourceMetadata, recon bool) error {
+	if err := r.applyOverride(esc, obj); err != nil {
+		return err
+	}
+
 	var (
 		exist                  bool
 		err                    error
@@ -160,10 +168,10 @@ func (r *Reconciler) createOrApplyClusterRoleBinding(esc *operatorv1alpha1.Exter
 	if exist && recon {
 		r.eventRecorder.Eventf(esc, corev1.EventTypeWarning, "ResourceAlreadyExists", "%s clusterrolebinding resource already exists, maybe from previous installation", clusterRoleBindingName)
 	}
-	if exist && common.HasObjectChanged(obj, fetched, &resourceMetadata) {
+	if exist && r.hasObjectDrifted(obj, fetched) {
 		r.log.V(1).Info("clusterrolebinding has been modified, updating to desired state", "name", clusterRoleBindingName)
 		common.RemoveObsoleteAnnotations(obj, resourceMetadata)
-		if err := r.UpdateWithRetry(r.ctx, obj); err != nil {
+		if err := r.Apply(r.ctx, obj); err != nil {
 			return common.FromClientError(err, "failed to update %s clusterrolebinding resource", clusterRoleBindingName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "clusterrolebinding resource %s reconciled back to desired state", clusterRoleBindingName)
@@ -171,7 +179,7 @@ func (r *Reconciler) createOrApplyClusterRoleBinding(esc *operatorv1alpha1.Exter
 		r.log.V(4).Info("clusterrolebinding resource already exists and is in expected state", "name", clusterRoleBindingName)
 	}
 	if !exist {
-		if err := r.Create(r.ctx, obj); err != nil {
+		if err := r.Apply(r.ctx, obj); err != nil {
 			return common.FromClientError(err, "failed to create %s clusterrolebinding resource", clusterRoleBindingName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "clusterrolebinding resource %s created", clusterRoleBindingName)
@@ -193,6 +201,9 @@ func (r *Reconciler) getClusterRoleBindingObject(esc *operatorv1alpha1.ExternalS
 
 // createOrApplyRole creates or updates given Role object.
 func (r *Reconciler) createOrApplyRole(esc *operatorv1alpha1.ExternalSecretsConfig, obj *rbacv1.Role, resourceMetadata common.ResourceMetadata, recon bool) error {
+	if err := r.applyOverride(esc, obj); err != nil {
+		return err
+	}
 	roleName := fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
 	r.log.V(4).Info("reconciling role resource", "name", roleName)
 	fetched := &rbacv1.Role{}
@@ -204,10 +215,10 @@ func (r *Reconciler) createOrApplyRole(esc *operatorv1alpha1.ExternalSecretsConf
 	if exist && recon {
 		r.eventRecorder.Eventf(esc, corev1.EventTypeWarning, "ResourceAlreadyExists", "%s role resource already exists, maybe from previous installation", roleName)
 	}
-	if exist && common.HasObjectChanged(obj, fetched, &resourceMetadata) {
+	if exist && r.hasObjectDrifted(obj, fetched) {
 		r.log.V(1).Info("role has been modified, updating to desired state", "name", roleName)
 		common.RemoveObsoleteAnnotations(obj, resourceMetadata)
-		if err := r.UpdateWithRetry(r.ctx, obj); err != nil {
+		if err := r.Apply(r.ctx, obj); err != nil {
 			return common.FromClientError(err, "failed to update %s role resource", roleName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "role resource %s reconciled back to desired state", roleName)
@@ -215,7 +226,7 @@ func (r *Reconciler) createOrApplyRole(esc *operatorv1alpha1.ExternalSecretsConf
 		r.log.V(4).Info("role resource already exists and is in expected state", "name", roleName)
 	}
 	if !exist {
-		if err := r.Create(r.ctx, obj); err != nil {
+		if err := r.Apply(r.ctx, obj); err != nil {
 			return common.FromClientError(err, "failed to create %s role resource", roleName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "role resource %s created", roleName)
@@ -236,6 +247,9 @@ func (r *Reconciler) getRoleObject(esc *operatorv1alpha1.ExternalSecretsConfig,
 
 // createOrApplyRoleBinding creates or updates given RoleBinding object.
 func (r *Reconciler) createOrApplyRoleBinding(esc *operatorv1alpha1.ExternalSecretsConfig, obj *rbacv1.RoleBinding, resourceMetadata common.ResourceMetadata, recon bool) error {
+	if err := r.applyOverride(esc, obj); err != nil {
+		return err
+	}
 	roleBindingName := fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
 	r.log.V(4).Info("reconciling rolebinding resource", "name", roleBindingName)
 	fetched := &rbacv1.RoleBinding{}
@@ -247,10 +261,10 @@ func (r *Reconciler) createOrApplyRoleBinding(esc *operatorv1alpha1.ExternalSecr
 	if exist && recon {
 		r.eventRecorder.Eventf(esc, corev1.EventTypeWarning, "ResourceAlreadyExists", "%s rolebinding resource already exists, maybe from previous installation", roleBindingName)
 	}
-	if exist && common.HasObjectChanged(obj, fetched, &resourceMetadata) {
+	if exist && r.hasObjectDrifted(obj, fetched) {
 		r.log.V(1).Info("rolebinding has been modified, updating to desired state", "name", roleBindingName)
 		common.RemoveObsoleteAnnotations(obj, resourceMetadata)
-		if err := r.UpdateWithRetry(r.ctx, obj); err != nil {
+		if err := r.Apply(r.ctx, obj); err != nil {
 			return common.FromClientError(err, "failed to update %s rolebinding resource", roleBindingName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "rolebinding resource %s reconciled back to desired state", roleBindingName)
@@ -258,7 +272,7 @@ func (r *Reconciler) createOrApplyRoleBinding(esc *operatorv1alpha1.ExternalSecr
 		r.log.V(4).Info("rolebinding resource already exists and is in expected state", "name", roleBindingName)
 	}
 	if !exist {
-		if err := r.Create(r.ctx, obj); err != nil {
+		if err := r.Apply(r.ctx, obj); err != nil {
 			return common.FromClientError(err, "failed to create %s rolebinding resource", roleBindingName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "rolebinding resource %s created", roleBindingName)
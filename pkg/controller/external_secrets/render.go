@@ -0,0 +1,310 @@
+package external_secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	operatorclient "github.com/openshift/external-secrets-operator/pkg/controller/client"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+)
+
+var _ operatorclient.CtrlClient = &renderClient{}
+
+// renderClient is an in-memory client recording the objects created and updated by the reconciler, used for
+// rendering the operand resources without a cluster, and for planning the changes to the resources in a cluster.
+type renderClient struct {
+	scheme *runtime.Scheme
+	esc    *operatorv1alpha1.ExternalSecretsConfig
+	esm    *operatorv1alpha1.ExternalSecretsManager
+
+	// live is the client for reading the resources in the cluster when planning the changes, and is nil
+	// when rendering.
+	live operatorclient.CtrlClient
+
+	// objects are the desired objects created or updated by the reconciler, in the order of creation.
+	objects []*unstructured.Unstructured
+
+	// changes are the changes to the resources in the cluster, recorded when planning.
+	changes []ResourceChange
+}
+
+// Render returns the resources created by the controller for deploying the external-secrets operand with the
+// configuration in the ExternalSecretsConfig and the ExternalSecretsManager, in the order of creation, without
+// requiring a cluster. Cert-manager issuers and the secrets referenced in the configuration are assumed to exist,
+// and all the optional APIs are assumed to be installed. The resources depending on the cluster state are not
+// rendered: the workload identity derived from the cluster configuration, and the bitwarden clustersecretstore
+// which requires the CA certificate issued for bitwarden-sdk-server.
+func Render(scheme *runtime.Scheme, esc *operatorv1alpha1.ExternalSecretsConfig, esm *operatorv1alpha1.ExternalSecretsManager) ([]*unstructured.Unstructured, error) {
+	if esm == nil {
+		esm = &operatorv1alpha1.ExternalSecretsManager{}
+	}
+	c := &renderClient{scheme: scheme, esc: esc, esm: esm}
+	r := newRenderReconciler(context.Background(), c, logr.Discard(), map[string]struct{}{
+		certificateCRDGKV:        {},
+		credentialsRequestCRDGKV: {},
+		serviceMonitorCRDGKV:     {},
+		prometheusRuleCRDGKV:     {},
+	})
+	if err := r.renderResources(esc); err != nil {
+		return nil, err
+	}
+	return c.objects, nil
+}
+
+// newRenderReconciler returns a reconciler using the render client for all the operations, and discarding the events.
+func newRenderReconciler(ctx context.Context, c *renderClient, log logr.Logger, optionalResources map[string]struct{}) *Reconciler {
+	return &Reconciler{
+		CtrlClient:            c,
+		UncachedClient:        c,
+		Scheme:                c.scheme,
+		ctx:                   ctx,
+		eventRecorder:         &record.FakeRecorder{},
+		log:                   log,
+		esm:                   c.esm,
+		optionalResourcesList: optionalResources,
+		reconcileState:        reconcileState{rendering: true},
+	}
+}
+
+// renderResources runs the reconciliation of the resources created for the external-secrets deployment, except for
+// the ones depending on the cluster state.
+func (r *Reconciler) renderResources(esc *operatorv1alpha1.ExternalSecretsConfig) error {
+	if err := r.validateExternalSecretsConfig(esc); err != nil {
+		return common.NewIrrecoverableError(err, "%s configuration validation failed", esc.GetName())
+	}
+	resourceMetadata, err := r.getResourceMetadata(esc)
+	if err != nil {
+		return err
+	}
+	for _, render := range []func() error{
+		func() error { return r.createOrApplyNamespace(esc, resourceMetadata) },
+		func() error { return r.createOrApplyNetworkPolicies(esc, resourceMetadata, false) },
+		func() error { return r.createOrApplyServiceAccounts(esc, resourceMetadata, false) },
+		func() error { return r.createOrApplyCertificates(esc, resourceMetadata, false) },
+		func() error { return r.createOrApplySecret(esc, resourceMetadata, false) },
+		func() error { return r.ensureTrustedCABundleConfigMap(esc, resourceMetadata) },
+		func() error { return r.createOrApplyWorkloadIdentityConfigMaps(esc, resourceMetadata) },
+		func() error { return r.createOrApplyCredentialsRequests(esc, resourceMetadata) },
+		func() error { return r.createOrApplyRBACResource(esc, resourceMetadata, false) },
+		func() error { return r.createOrApplyServices(esc, resourceMetadata, false) },
+		func() error { return r.createOrApplyMonitoring(esc, resourceMetadata) },
+		func() error { return r.createOrApplyDeployments(esc, resourceMetadata, false) },
+		func() error { return r.createOrApplyAutoscalers(esc, resourceMetadata, false) },
+		func() error { return r.createOrApplyValidatingWebhookConfiguration(esc, resourceMetadata, false) },
+	} {
+		if err := render(); err != nil {
+			return err
+		}
+	}
+
+	if esc.Spec.Bootstrap != nil {
+		for _, store := range esc.Spec.Bootstrap.ClusterSecretStores {
+			desired, err := getBootstrapClusterSecretStoreObject(store, resourceMetadata)
+			if err != nil {
+				return err
+			}
+			// clustersecretstores not created by the operator are not modified, and are not rendered.
+			if err := r.createOrApplyBootstrapClusterSecretStore(esc, desired, resourceMetadata); err != nil && !errors.Is(err, errBootstrapConflict) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// find returns the index of the recorded object of the kind with the key, or -1 when not recorded.
+func (c *renderClient) find(gvk schema.GroupVersionKind, key client.ObjectKey) int {
+	for i, object := range c.objects {
+		if object.GroupVersionKind() == gvk && object.GetNamespace() == key.Namespace && object.GetName() == key.Name {
+			return i
+		}
+	}
+	return -1
+}
+
+// toUnstructured returns a copy of the object as unstructured, with the apiVersion and kind set.
+func (c *renderClient) toUnstructured(obj client.Object) (*unstructured.Unstructured, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return nil, err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj.DeepCopyObject())
+	if err != nil {
+		return nil, err
+	}
+	object := &unstructured.Unstructured{Object: content}
+	object.SetGroupVersionKind(gvk)
+	return object, nil
+}
+
+func (c *renderClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	switch o := obj.(type) {
+	case *operatorv1alpha1.ExternalSecretsConfig:
+		c.esc.DeepCopyInto(o)
+		return nil
+	case *operatorv1alpha1.ExternalSecretsManager:
+		c.esm.DeepCopyInto(o)
+		return nil
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return err
+	}
+	i := c.find(gvk, key)
+	if i < 0 {
+		if c.live != nil {
+			return c.live.Get(ctx, key, obj)
+		}
+		// secrets referenced in the configuration are provided by the user.
+		if _, ok := obj.(*corev1.Secret); ok {
+			return nil
+		}
+		return apierrors.NewNotFound(schema.GroupResource{Group: gvk.Group, Resource: gvk.Kind}, key.Name)
+	}
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		u.SetUnstructuredContent(c.objects[i].DeepCopy().UnstructuredContent())
+		return nil
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(c.objects[i].DeepCopy().UnstructuredContent(), obj)
+}
+
+func (c *renderClient) Exists(ctx context.Context, key client.ObjectKey, obj client.Object) (bool, error) {
+	if c.live != nil {
+		return c.live.Exists(ctx, key, obj)
+	}
+	switch obj.(type) {
+	case *certmanagerv1.Issuer, *certmanagerv1.ClusterIssuer:
+		// issuers referenced in the configuration are provided by the user.
+		return true, nil
+	}
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return false, err
+	}
+	return c.find(gvk, key) >= 0, nil
+}
+
+func (c *renderClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	object, err := c.toUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	if c.find(object.GroupVersionKind(), client.ObjectKeyFromObject(object)) >= 0 {
+		return apierrors.NewAlreadyExists(schema.GroupResource{Group: object.GroupVersionKind().Group, Resource: object.GetKind()}, object.GetName())
+	}
+	c.objects = append(c.objects, object)
+	if c.live != nil {
+		c.changes = append(c.changes, ResourceChange{Object: object, Action: operatorv1alpha1.PlannedActionCreate})
+	}
+	return nil
+}
+
+func (c *renderClient) Update(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	object, err := c.toUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	key := client.ObjectKeyFromObject(object)
+	if c.live != nil {
+		// the resources are updated by the reconciler when drifted from the desired state, and the changes are the
+		// fields differing in the object returned for a dry-run apply of the desired state.
+		current := &unstructured.Unstructured{}
+		current.SetGroupVersionKind(object.GroupVersionKind())
+		if err := c.live.Get(ctx, key, current); err != nil {
+			return err
+		}
+		applied, ok := obj.DeepCopyObject().(client.Object)
+		if !ok {
+			return fmt.Errorf("failed to create new instance of %T: type does not implement client.Object", obj)
+		}
+		if err := c.live.Apply(ctx, applied, client.DryRunAll); err != nil {
+			return err
+		}
+		appliedObject, err := c.toUnstructured(applied)
+		if err != nil {
+			return err
+		}
+		// the applies only taking over the ownership of the fields are not changes.
+		if fields := diffObject(current, appliedObject); len(fields) > 0 {
+			c.changes = append(c.changes, ResourceChange{
+				Object: object,
+				Action: operatorv1alpha1.PlannedActionUpdate,
+				Fields: fields,
+			})
+		}
+	}
+	if i := c.find(object.GroupVersionKind(), key); i >= 0 {
+		c.objects[i] = object
+	} else {
+		c.objects = append(c.objects, object)
+	}
+	return nil
+}
+
+func (c *renderClient) UpdateWithRetry(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.Update(ctx, obj, opts...)
+}
+
+func (c *renderClient) Apply(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
+	// dry-run applies validate the objects with the resources in the cluster, when planning.
+	if operatorclient.IsDryRun(opts...) {
+		if c.live != nil {
+			return c.live.Apply(ctx, obj, opts...)
+		}
+		return nil
+	}
+	current, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("failed to create new instance of %T: type does not implement client.Object", obj)
+	}
+	exists, err := c.Exists(ctx, client.ObjectKeyFromObject(obj), current)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return c.Update(ctx, obj)
+	}
+	return c.Create(ctx, obj)
+}
+
+func (c *renderClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if c.live != nil {
+		return c.live.List(ctx, list, opts...)
+	}
+	return nil
+}
+
+func (c *renderClient) StatusUpdate(context.Context, client.Object, ...client.SubResourceUpdateOption) error {
+	return nil
+}
+
+func (c *renderClient) Delete(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	if c.live == nil {
+		return nil
+	}
+	object, err := c.toUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	c.changes = append(c.changes, ResourceChange{Object: object, Action: operatorv1alpha1.PlannedActionDelete})
+	return nil
+}
+
+func (c *renderClient) Patch(context.Context, client.Object, client.Patch, ...client.PatchOption) error {
+	return nil
+}
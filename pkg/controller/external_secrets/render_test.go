@@ -0,0 +1,127 @@
+package external_secrets
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+func TestRender(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(certmanagerv1.AddToScheme(scheme))
+	utilruntime.Must(operatorv1alpha1.AddToScheme(scheme))
+
+	tests := []struct {
+		name            string
+		image           string
+		updateESC       func(*operatorv1alpha1.ExternalSecretsConfig)
+		wantObjects     []string
+		unwantedObjects []string
+		wantErr         string
+	}{
+		{
+			name:  "default configuration renders cert-controller resources",
+			image: commontest.TestExternalSecretsImageName,
+			wantObjects: []string{
+				"Namespace /external-secrets",
+				"Secret external-secrets/external-secrets-webhook",
+				"Deployment external-secrets/external-secrets",
+				"Deployment external-secrets/external-secrets-webhook",
+				"Deployment external-secrets/external-secrets-cert-controller",
+				"ValidatingWebhookConfiguration /externalsecret-validate",
+			},
+			unwantedObjects: []string{
+				"Certificate external-secrets/external-secrets-webhook",
+				"Deployment external-secrets/bitwarden-sdk-server",
+				"HorizontalPodAutoscaler external-secrets/external-secrets-webhook",
+			},
+		},
+		{
+			name:  "cert-manager and bitwarden enabled renders certificates and bitwarden-sdk-server resources",
+			image: commontest.TestExternalSecretsImageName,
+			updateESC: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.Plugins.BitwardenSecretManagerProvider = &operatorv1alpha1.BitwardenSecretManagerProvider{
+					Mode: operatorv1alpha1.Enabled,
+				}
+				esc.Spec.ControllerConfig.CertProvider = &operatorv1alpha1.CertProvidersConfig{
+					CertManager: &operatorv1alpha1.CertManagerConfig{
+						Mode:      operatorv1alpha1.Enabled,
+						IssuerRef: &operatorv1alpha1.ObjectReference{Name: "cluster-issuer", Kind: "ClusterIssuer"},
+					},
+				}
+				esc.Spec.ControllerConfig.ComponentConfigs = []operatorv1alpha1.ComponentConfig{
+					{ComponentName: operatorv1alpha1.BitwardenSDKServer, Autoscaling: &operatorv1alpha1.AutoscalingConfig{MaxReplicas: 3}},
+				}
+			},
+			wantObjects: []string{
+				"Certificate external-secrets/external-secrets-webhook",
+				"Certificate external-secrets/bitwarden-tls-certs",
+				"Service external-secrets/bitwarden-sdk-server",
+				"Deployment external-secrets/bitwarden-sdk-server",
+				"HorizontalPodAutoscaler external-secrets/bitwarden-sdk-server",
+			},
+			unwantedObjects: []string{
+				"Secret external-secrets/external-secrets-webhook",
+				"Deployment external-secrets/external-secrets-cert-controller",
+			},
+		},
+		{
+			name:  "bootstrap clustersecretstores are rendered",
+			image: commontest.TestExternalSecretsImageName,
+			updateESC: func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+				esc.Spec.Bootstrap = &operatorv1alpha1.BootstrapConfig{
+					ClusterSecretStores: []operatorv1alpha1.BootstrapClusterSecretStore{
+						{Name: "vault", Spec: runtime.RawExtension{Raw: []byte(`{"provider":{"vault":{"server":"https://vault"}}}`)}},
+					},
+				}
+			},
+			wantObjects: []string{
+				"ClusterSecretStore /vault",
+			},
+		},
+		{
+			name:    "operand image not configured",
+			wantErr: "failed to update image in external-secrets-webhook deployment object: RELATED_IMAGE_EXTERNAL_SECRETS environment variable with externalsecrets image not set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(externalsecretsImageEnvVarName, tt.image)
+			t.Setenv(bitwardenImageEnvVarName, commontest.TestBitwardenImageName)
+			esc := commontest.TestExternalSecretsConfig()
+			if tt.updateESC != nil {
+				tt.updateESC(esc)
+			}
+
+			objects, err := Render(scheme, esc, commontest.TestExternalSecretsManager())
+			if (tt.wantErr != "" || err != nil) && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("Render() err: %v, wantErr: %v", err, tt.wantErr)
+			}
+			rendered := make([]string, 0, len(objects))
+			for _, object := range objects {
+				rendered = append(rendered, fmt.Sprintf("%s %s/%s", object.GetKind(), object.GetNamespace(), object.GetName()))
+			}
+			for _, want := range tt.wantObjects {
+				if !slices.Contains(rendered, want) {
+					t.Errorf("Render() did not render %s, rendered: %v", want, rendered)
+				}
+			}
+			for _, unwanted := range tt.unwantedObjects {
+				if slices.Contains(rendered, unwanted) {
+					t.Errorf("Render() rendered unexpected %s", unwanted)
+				}
+			}
+		})
+	}
+}
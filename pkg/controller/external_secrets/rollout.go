@@ -0,0 +1,169 @@
+package external_secrets
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+)
+
+const (
+	// defaultProgressDeadlineSeconds is the progress deadline of a deployment, when not configured.
+	defaultProgressDeadlineSeconds int32 = 600
+
+	// progressDeadlineExceededReason is the reason of the Progressing condition of a deployment, when the rollout
+	// did not progress within the progress deadline.
+	progressDeadlineExceededReason = "ProgressDeadlineExceeded"
+)
+
+// rolloutStageDeployment is a deployment of an external-secrets component rolled out in a stage.
+type rolloutStageDeployment struct {
+	assetName      string
+	deploymentName string
+	// imageUpdated is whether the deployment was updated with a new image in the current reconciliation, when
+	// the status of the deployment fetched does not reflect the rollout yet.
+	imageUpdated bool
+}
+
+// rolloutStages are the deployments of the external-secrets components in the order of the stages of the rollout.
+// A deployment is updated with a new image only when the deployments of the previous stages are healthy, for a
+// faulty image to be rolled out only to the components of one stage. The webhook is rolled out first, as the other
+// components depend on it for the validation of their resources.
+var rolloutStages = [][]rolloutStageDeployment{
+	{
+		{assetName: webhookDeploymentAssetName, deploymentName: webhookDeploymentName},
+	},
+	{
+		{assetName: certControllerDeploymentAssetName, deploymentName: certControllerDeploymentName},
+		{assetName: controllerDeploymentAssetName, deploymentName: controllerDeploymentName},
+		{assetName: bitwardenDeploymentAssetName, deploymentName: bitwardenDeploymentName},
+	},
+}
+
+// isImageUpdated returns whether the image of any of the containers of the desired deployment is different from the
+// image of the container in the fetched deployment, which rolls out the new image.
+func isImageUpdated(desired, fetched *appsv1.Deployment) bool {
+	images := make(map[string]string, len(fetched.Spec.Template.Spec.Containers))
+	for _, container := range fetched.Spec.Template.Spec.Containers {
+		images[container.Name] = container.Image
+	}
+	for _, container := range desired.Spec.Template.Spec.Containers {
+		if image, ok := images[container.Name]; ok && image != container.Image {
+			return true
+		}
+	}
+	return false
+}
+
+// errRolloutStalled is returned when the rollout of a deployment of a previous stage did not progress within the
+// progress deadline. The deployment waiting for the previous stage is then not updated, and the stalled rollout is
+// reported with the Degraded condition while the other resources are reconciled.
+var errRolloutStalled = errors.New("rollout of a previous stage did not complete within the progress deadline")
+
+// checkRolloutDeadline records the deployment with the stalled rollouts and returns errRolloutStalled, when the
+// rollout of the deployment did not progress within the progress deadline, which stops the rollout of the next
+// stages until the deployment is fixed.
+func (r *Reconciler) checkRolloutDeadline(deployment *appsv1.Deployment) error {
+	cond := getProgressDeadlineExceeded(deployment)
+	if cond == nil {
+		return nil
+	}
+	deploymentName := fmt.Sprintf("%s/%s", deployment.GetNamespace(), deployment.GetName())
+	r.log.V(1).Info("deployment rollout did not complete within the progress deadline", "name", deploymentName, "message", cond.Message)
+	if !slices.Contains(r.stalledRollouts, deploymentName) {
+		r.stalledRollouts = append(r.stalledRollouts, deploymentName)
+	}
+	return fmt.Errorf("%w: %s: %s", errRolloutStalled, cond.Reason, cond.Message)
+}
+
+// getProgressDeadlineExceeded returns the Progressing condition of the deployment when the rollout did not
+// progress within the progress deadline, or nil otherwise.
+func getProgressDeadlineExceeded(deployment *appsv1.Deployment) *appsv1.DeploymentCondition {
+	cond := getDeploymentCondition(deployment, appsv1.DeploymentProgressing)
+	if cond == nil || cond.Status != corev1.ConditionFalse || cond.Reason != progressDeadlineExceededReason {
+		return nil
+	}
+	return cond
+}
+
+// stalledRolloutsMessage returns the message reporting the deployments recorded with the rollout stalled.
+func (r *Reconciler) stalledRolloutsMessage() string {
+	return fmt.Sprintf("rollout of deployments %s did not complete within the progress deadline", strings.Join(r.stalledRollouts, ", "))
+}
+
+// waitForRolloutStages verifies the deployments of the previous stages of the rollout are healthy, before the
+// deployment is updated with a new image. A deployment is healthy when rolled out and available, and the webhook
+// additionally when passing the verification of serving the admission requests. Returns a retry required error
+// while the deployments are progressing or the webhook verification is failing, and errRolloutStalled when the
+// rollout of a deployment did not progress within its progress deadline.
+func (r *Reconciler) waitForRolloutStages(esc *operatorv1alpha1.ExternalSecretsConfig, previous []rolloutStageDeployment, deploymentName string) error {
+	for _, stage := range previous {
+		key := types.NamespacedName{Name: stage.deploymentName, Namespace: getNamespace(esc)}
+		if stage.imageUpdated {
+			return common.NewRetryRequiredError(fmt.Errorf("%s deployment updated with new image", key),
+				"waiting for rollout of %s deployment before rolling out %s deployment", key, deploymentName)
+		}
+		deployment := &appsv1.Deployment{}
+		exist, err := r.Exists(r.ctx, key, deployment)
+		if err != nil {
+			return common.FromClientError(err, "failed to fetch %s deployment", key)
+		}
+		if !exist {
+			continue
+		}
+		if err := r.checkRolloutDeadline(deployment); err != nil {
+			return err
+		}
+		if !isDeploymentRolledOut(deployment) || !isDeploymentAvailable(deployment) {
+			return common.NewRetryRequiredError(fmt.Errorf("rollout of %s deployment is in progress", key),
+				"waiting for rollout of %s deployment before rolling out %s deployment", key, deploymentName)
+		}
+		if stage.deploymentName != webhookDeploymentName {
+			continue
+		}
+
+		err = r.verifyWebhook(esc)
+		if _, ok := err.(*webhookHealthError); err != nil && !ok {
+			return err
+		}
+		if err == nil {
+			continue
+		}
+		now := time.Now()
+		if r.webhookVerificationWaitStart.IsZero() {
+			r.webhookVerificationWaitStart = now
+		}
+		if isRolloutDeadlineExceeded(deployment, r.webhookVerificationWaitStart, now) {
+			return common.NewRetryRequiredError(err, "rollout of %s deployment stopped, webhook verification failing since %s exceeding the progress deadline",
+				deploymentName, r.webhookVerificationWaitStart.Format(time.RFC3339))
+		}
+		return common.NewRetryRequiredError(err, "waiting for webhook verification before rolling out %s deployment", deploymentName)
+	}
+	return nil
+}
+
+// isRolloutDeadlineExceeded returns whether the progress deadline of the deployment elapsed since the rollout
+// started waiting for the deployment.
+func isRolloutDeadlineExceeded(deployment *appsv1.Deployment, waitStart, now time.Time) bool {
+	deadline := time.Duration(ptr.Deref(deployment.Spec.ProgressDeadlineSeconds, defaultProgressDeadlineSeconds)) * time.Second
+	return now.Sub(waitStart) > deadline
+}
+
+// getDeploymentCondition returns the condition of the deployment of the type, or nil when not present.
+func getDeploymentCondition(deployment *appsv1.Deployment, condType appsv1.DeploymentConditionType) *appsv1.DeploymentCondition {
+	for i := range deployment.Status.Conditions {
+		if deployment.Status.Conditions[i].Type == condType {
+			return &deployment.Status.Conditions[i]
+		}
+	}
+	return nil
+}
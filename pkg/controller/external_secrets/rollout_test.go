@@ -0,0 +1,278 @@
+package external_secrets
+
+import (
+	"context"
+	"crypto/tls"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	webhook "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+func TestCreateOrApplyDeploymentsRollout(t *testing.T) {
+	const (
+		oldImage = commontest.TestExternalSecretsImageName + ":v0.19.0"
+		newImage = commontest.TestExternalSecretsImageName + ":v0.20.0"
+	)
+	caBundle, servingCert := testWebhookCertificates(t, time.Now().Add(90*24*time.Hour))
+
+	// webhookStatus sets the status of the webhook deployment rolled out with the new image, with the rollout
+	// completed at the given time.
+	webhookStatus := func(available int32, completed time.Time) func(*appsv1.Deployment) {
+		return func(deployment *appsv1.Deployment) {
+			for i := range deployment.Spec.Template.Spec.Containers {
+				deployment.Spec.Template.Spec.Containers[i].Image = newImage
+			}
+			testDeploymentStatus(deployment, available)
+			deployment.Status.Conditions = append(deployment.Status.Conditions, appsv1.DeploymentCondition{
+				Type:           appsv1.DeploymentProgressing,
+				Status:         corev1.ConditionTrue,
+				Reason:         "NewReplicaSetAvailable",
+				LastUpdateTime: metav1.NewTime(completed),
+			})
+		}
+	}
+
+	tests := []struct {
+		name string
+		// webhook updates the existing webhook deployment, which is otherwise deployed with the old image.
+		webhook     func(*appsv1.Deployment)
+		servingCert []byte
+		// waitStart is the time the rollout started waiting for the webhook verification in the earlier
+		// reconciliations.
+		waitStart   time.Time
+		wantApplied []string
+		wantErr     string
+		wantStalled []string
+	}{
+		{
+			name:        "webhook rolled out first",
+			wantApplied: []string{webhookDeploymentName},
+			wantErr:     "waiting for rollout of external-secrets/external-secrets-webhook deployment before rolling out external-secrets/external-secrets-cert-controller deployment",
+		},
+		{
+			name:    "webhook rollout in progress",
+			webhook: webhookStatus(0, time.Now()),
+			wantErr: "waiting for rollout of external-secrets/external-secrets-webhook deployment before rolling out external-secrets/external-secrets-cert-controller deployment",
+		},
+		{
+			name: "webhook rollout exceeded progress deadline",
+			webhook: func(deployment *appsv1.Deployment) {
+				webhookStatus(0, time.Now())(deployment)
+				deployment.Status.Conditions[1].Status = corev1.ConditionFalse
+				deployment.Status.Conditions[1].Reason = progressDeadlineExceededReason
+			},
+			wantStalled: []string{"external-secrets/external-secrets-webhook"},
+		},
+		{
+			name:    "webhook available with verification failing",
+			webhook: webhookStatus(1, time.Now()),
+			wantErr: "waiting for webhook verification before rolling out external-secrets/external-secrets-cert-controller deployment",
+		},
+		{
+			name:    "webhook rolled out earlier with verification failing",
+			webhook: webhookStatus(1, time.Now().Add(-time.Hour)),
+			wantErr: "waiting for webhook verification before rolling out external-secrets/external-secrets-cert-controller deployment",
+		},
+		{
+			name:      "webhook verification failing after progress deadline",
+			webhook:   webhookStatus(1, time.Now().Add(-time.Hour)),
+			waitStart: time.Now().Add(-time.Hour),
+			wantErr:   "rollout of external-secrets/external-secrets-cert-controller deployment stopped, webhook verification failing since",
+		},
+		{
+			name:        "webhook available and verified",
+			webhook:     webhookStatus(1, time.Now()),
+			servingCert: servingCert,
+			waitStart:   time.Now().Add(-time.Minute),
+			wantApplied: []string{certControllerDeploymentName, controllerDeploymentName},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			r.webhookVerificationWaitStart = tt.waitStart
+			mock := &fakes.FakeCtrlClient{}
+			r.CtrlClient = mock
+			r.UncachedClient = mock
+			esc := commontest.TestExternalSecretsConfig()
+
+			t.Setenv(externalsecretsImageEnvVarName, oldImage)
+			existing := make(map[string]*appsv1.Deployment)
+			for _, assetName := range getDeploymentAssetNames(esc) {
+				deployment, err := r.getDeploymentObject(assetName, esc, testResourceMetadata(esc))
+				if err != nil {
+					t.Fatalf("getDeploymentObject() unexpected error: %v", err)
+				}
+				existing[deployment.GetName()] = testApplied(deployment)
+			}
+			if tt.webhook != nil {
+				tt.webhook(existing[webhookDeploymentName])
+				testApplied(existing[webhookDeploymentName])
+			}
+			t.Setenv(externalsecretsImageEnvVarName, newImage)
+
+			mock.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+				switch o := obj.(type) {
+				case *appsv1.Deployment:
+					if deployment, ok := existing[ns.Name]; ok {
+						deployment.DeepCopyInto(o)
+						return true, nil
+					}
+				case *webhook.ValidatingWebhookConfiguration:
+					assetName := validatingWebhookExternalSecretCRDAssetName
+					if ns.Name != "externalsecret-validate" {
+						assetName = validatingWebhookSecretStoreCRDAssetName
+					}
+					testValidatingWebhookConfiguration(assetName).DeepCopyInto(o)
+					for i := range o.Webhooks {
+						o.Webhooks[i].ClientConfig.CABundle = caBundle
+					}
+					return true, nil
+				case *corev1.Secret:
+					if tt.servingCert == nil {
+						return false, nil
+					}
+					o.Data = map[string][]byte{corev1.TLSCertKey: tt.servingCert}
+					return true, nil
+				}
+				return false, nil
+			})
+			var applied []string
+			mock.ApplyCalls(func(ctx context.Context, obj client.Object, _ ...client.ApplyOption) error {
+				if _, ok := obj.(*appsv1.Deployment); ok {
+					applied = append(applied, obj.GetName())
+				}
+				return nil
+			})
+
+			origDial := dialWebhookTLS
+			dialWebhookTLS = func(string, *tls.Config) error { return nil }
+			defer func() { dialWebhookTLS = origDial }()
+
+			err := r.createOrApplyDeployments(esc, testResourceMetadata(esc), false)
+			if (tt.wantErr != "" || err != nil) && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("createOrApplyDeployments() err: %v, wantErr: %v", err, tt.wantErr)
+			}
+			if common.IsIrrecoverableError(err) {
+				t.Errorf("createOrApplyDeployments() unexpected irrecoverable error: %v", err)
+			}
+			if !slices.Equal(r.stalledRollouts, tt.wantStalled) {
+				t.Errorf("stalled rollouts %v, want %v", r.stalledRollouts, tt.wantStalled)
+			}
+			switch {
+			case err == nil && !r.webhookVerificationWaitStart.IsZero():
+				t.Errorf("webhook verification wait start not reset after deployments reconciled")
+			case strings.Contains(tt.wantErr, "webhook verification") && r.webhookVerificationWaitStart.IsZero():
+				t.Errorf("webhook verification wait start not recorded while waiting")
+			case !tt.waitStart.IsZero() && err != nil && !r.webhookVerificationWaitStart.Equal(tt.waitStart):
+				t.Errorf("webhook verification wait start %v, want %v", r.webhookVerificationWaitStart, tt.waitStart)
+			}
+			if len(applied) != len(tt.wantApplied) {
+				t.Fatalf("applied deployments %v, want %v", applied, tt.wantApplied)
+			}
+			for i := range applied {
+				if applied[i] != tt.wantApplied[i] {
+					t.Errorf("applied deployments %v, want %v", applied, tt.wantApplied)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderDeploymentsNotGated(t *testing.T) {
+	r := testReconciler(t)
+	r.rendering = true
+	mock := &fakes.FakeCtrlClient{}
+	r.CtrlClient = mock
+	esc := commontest.TestExternalSecretsConfig()
+
+	t.Setenv(externalsecretsImageEnvVarName, commontest.TestExternalSecretsImageName+":v0.19.0")
+	existing := make(map[string]*appsv1.Deployment)
+	for _, assetName := range getDeploymentAssetNames(esc) {
+		deployment, err := r.getDeploymentObject(assetName, esc, testResourceMetadata(esc))
+		if err != nil {
+			t.Fatalf("getDeploymentObject() unexpected error: %v", err)
+		}
+		existing[deployment.GetName()] = testApplied(deployment)
+	}
+	t.Setenv(externalsecretsImageEnvVarName, commontest.TestExternalSecretsImageName+":v0.20.0")
+
+	mock.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+		if deployment, ok := existing[ns.Name]; ok {
+			deployment.DeepCopyInto(obj.(*appsv1.Deployment))
+			return true, nil
+		}
+		return false, nil
+	})
+
+	if err := r.createOrApplyDeployments(esc, testResourceMetadata(esc), false); err != nil {
+		t.Fatalf("createOrApplyDeployments() unexpected error: %v", err)
+	}
+	if mock.ApplyCallCount() != len(existing) {
+		t.Errorf("applied %d deployments, want all the %d deployments rendered", mock.ApplyCallCount(), len(existing))
+	}
+}
+
+func TestStalledRolloutReported(t *testing.T) {
+	r := testReconciler(t)
+	mock := &fakes.FakeCtrlClient{}
+	r.CtrlClient = mock
+	r.UncachedClient = mock
+	esc := commontest.TestExternalSecretsConfig()
+	t.Setenv(externalsecretsImageEnvVarName, commontest.TestExternalSecretsImageName)
+
+	existing := make(map[string]*appsv1.Deployment)
+	for _, assetName := range getDeploymentAssetNames(esc) {
+		deployment, err := r.getDeploymentObject(assetName, esc, testResourceMetadata(esc))
+		if err != nil {
+			t.Fatalf("getDeploymentObject() unexpected error: %v", err)
+		}
+		existing[deployment.GetName()] = testApplied(deployment)
+	}
+	stalled := existing[webhookDeploymentName]
+	testDeploymentStatus(stalled, 0)
+	stalled.Status.Conditions = append(stalled.Status.Conditions, appsv1.DeploymentCondition{
+		Type:    appsv1.DeploymentProgressing,
+		Status:  corev1.ConditionFalse,
+		Reason:  progressDeadlineExceededReason,
+		Message: "ReplicaSet has timed out progressing.",
+	})
+	// the deployment after the stalled one has drifted, and must still be reconciled.
+	delete(existing[controllerDeploymentName].Labels, "app")
+
+	mock.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+		if deployment, ok := existing[ns.Name]; ok {
+			deployment.DeepCopyInto(obj.(*appsv1.Deployment))
+			return true, nil
+		}
+		return false, nil
+	})
+	var applied []string
+	mock.ApplyCalls(func(ctx context.Context, obj client.Object, _ ...client.ApplyOption) error {
+		applied = append(applied, obj.GetName())
+		return nil
+	})
+
+	if err := r.createOrApplyDeployments(esc, testResourceMetadata(esc), false); err != nil {
+		t.Fatalf("createOrApplyDeployments() unexpected error: %v", err)
+	}
+	if want := []string{"external-secrets/" + webhookDeploymentName}; !slices.Equal(r.stalledRollouts, want) {
+		t.Errorf("stalledRollouts = %v, want %v", r.stalledRollouts, want)
+	}
+	if !slices.Contains(applied, controllerDeploymentName) {
+		t.Errorf("applied deployments %v, want %s reconciled after the stalled rollout", applied, controllerDeploymentName)
+	}
+}
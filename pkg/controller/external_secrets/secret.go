@@ -32,10 +32,10 @@ func (r *Reconciler) createOrApplySecret(esc *operatorv1alpha1.ExternalSecretsCo
 		r.eventRecorder.Eventf(esc, corev1.EventTypeWarning, "ResourceAlreadyExists", "%s secret resource already exists, maybe from previous installation", secretName)
 	}
 
-	if exist && common.ObjectMetadataModified(desired, fetched, &resourceMetadata) {
+	if exist && r.hasObjectDrifted(desired, fetched) {
 		r.log.V(1).Info("secret has been modified, updating to desired state", "name", secretName)
 		common.RemoveObsoleteAnnotations(desired, resourceMetadata)
-		if err := r.UpdateWithRetry(r.ctx, desired); err != nil {
+		if err := r.Apply(r.ctx, desired); err != nil {
 			return common.FromClientError(err, "failed to update %s secret resource", secretName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "secret resource %s reconciled back to desired state", secretName)
@@ -44,7 +44,7 @@ func (r *Reconciler) createOrApplySecret(esc *operatorv1alpha1.ExternalSecretsCo
 	}
 
 	if !exist {
-		if err := r.Create(r.ctx, desired); err != nil {
+		if err := r.Apply(r.ctx, desired); err != nil {
 			return common.FromClientError(err, "failed to create %s secret resource", secretName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "secret resource %s created", secretName)
@@ -104,7 +104,7 @@ func TestCreateOrApplySecret(t *testing.T) {
 					}
 					return true, nil
 				})
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if _, ok := obj.(*corev1.Secret); ok {
 						return commontest.ErrTestClient
 					}
@@ -125,11 +125,15 @@ func TestCreateOrApplySecret(t *testing.T) {
 				})
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					if o, ok := obj.(*corev1.Secret); ok {
-						secret := testSecret(webhookTLSSecretAssetName)
-						secret.DeepCopyInto(o)
+						esc := testExternalSecretsConfigForSecrets()
+						testApplied(r.getSecretObject(esc, testResourceMetadata(esc))).DeepCopyInto(o)
 					}
 					return true, nil
 				})
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
+					t.Errorf("Apply was called unexpectedly for %s", obj.GetName())
+					return nil
+				})
 			},
 		},
 		{
@@ -148,7 +152,7 @@ func TestCreateOrApplySecret(t *testing.T) {
 					}
 					return true, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if _, ok := obj.(*corev1.Secret); ok {
 						return commontest.ErrTestClient
 					}
@@ -164,7 +168,7 @@ func TestCreateOrApplySecret(t *testing.T) {
 					return false, nil
 				})
 
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					return nil
 				})
 			},
@@ -180,7 +184,7 @@ func TestCreateOrApplySecret(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if secret, ok := obj.(*corev1.Secret); ok {
 						// Verify annotations are applied
 						if secret.Annotations == nil {
@@ -208,7 +212,7 @@ func TestCreateOrApplySecret(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if secret, ok := obj.(*corev1.Secret); ok {
 						// Verify all annotations from spec are present
 						if secret.Annotations["allowed-secret-annotation"] != "value" {
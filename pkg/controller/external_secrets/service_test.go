@@ -44,7 +44,7 @@ func TestCreateOrApplyServices(t *testing.T) {
 					}
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if svc, ok := obj.(*corev1.Service); ok {
 						if svc.Name == bitwardenSDKServerContainerName {
 							return commontest.ErrTestClient // trigger error
@@ -86,7 +86,7 @@ func TestCreateOrApplyServices(t *testing.T) {
 					}
 					return false, nil
 				})
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					return commontest.ErrTestClient
 				})
 			},
@@ -98,7 +98,7 @@ func TestCreateOrApplyServices(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if svc, ok := obj.(*corev1.Service); ok {
 						if svc.Name != "external-secrets-webhook" {
 							t.Errorf("Expected webhook service to be created, got %s", svc.Name)
@@ -116,7 +116,7 @@ func TestCreateOrApplyServices(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					switch svc := obj.(type) {
 					case *corev1.Service:
 						capturedService = svc.DeepCopy()
@@ -150,7 +150,7 @@ func TestCreateOrApplyServices(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					switch svc := obj.(type) {
 					case *corev1.Service:
 						// Verify all annotations from spec are present
@@ -14,24 +14,29 @@ import (
 // createOrApplyServiceAccounts ensures required service Account resources exist and are correctly configured.
 func (r *Reconciler) createOrApplyServiceAccounts(esc *operatorv1alpha1.ExternalSecretsConfig, resourceMetadata common.ResourceMetadata, externalSecretsConfigCreateRecon bool) error {
 	serviceAccountsToCreate := []struct {
-		assetName string
-		condition bool
+		assetName     string
+		componentName operatorv1alpha1.ComponentName
+		condition     bool
 	}{
 		{
-			assetName: controllerServiceAccountAssetName,
-			condition: true,
+			assetName:     controllerServiceAccountAssetName,
+			componentName: operatorv1alpha1.CoreController,
+			condition:     true,
 		},
 		{
-			assetName: webhookServiceAccountAssetName,
-			condition: true,
+			assetName:     webhookServiceAccountAssetName,
+			componentName: operatorv1alpha1.Webhook,
+			condition:     true,
 		},
 		{
-			assetName: certControllerServiceAccountAssetName,
-			condition: !isCertManagerConfigEnabled(esc),
+			assetName:     certControllerServiceAccountAssetName,
+			componentName: operatorv1alpha1.CertController,
+			condition:     !isCertManagerConfigEnabled(esc),
 		},
 		{
-			assetName: bitwardenServiceAccountAssetName,
-			condition: isBitwardenConfigEnabled(esc),
+			assetName:     bitwardenServiceAccountAssetName,
+			componentName: operatorv1alpha1.BitwardenSDKServer,
+			condition:     isBitwardenConfigEnabled(esc),
 		},
 	}
 
@@ -57,10 +62,22 @@ func (r *Reconciler) createOrApplyServiceAccounts(esc *operatorv1alpha1.External
 			r.eventRecorder.Eventf(esc, corev1.EventTypeWarning, "ResourceAlreadyExists", "%s serviceaccount already exists, possibly from a previous install", serviceAccountName)
 		}
 
-		if exist && common.HasObjectChanged(desired, fetched, &resourceMetadata) {
+		var existing *corev1.ServiceAccount
+		if exist {
+			existing = fetched
+		}
+		saMetadata, err := updateServiceAccountWorkloadIdentity(desired, existing, r.getWorkloadIdentityConfig(esc, serviceAccount.componentName), resourceMetadata)
+		if err != nil {
+			return common.NewIrrecoverableError(err, "failed to update workload identity configuration of serviceaccount %s", serviceAccountName)
+		}
+		if err := r.applyOverride(esc, desired); err != nil {
+			return err
+		}
+
+		if exist && r.hasObjectDrifted(desired, fetched) {
 			r.log.V(1).Info("ServiceAccount modified, updating", "name", serviceAccountName)
-			common.RemoveObsoleteAnnotations(desired, resourceMetadata)
-			if err := r.UpdateWithRetry(r.ctx, desired); err != nil {
+			common.RemoveObsoleteAnnotations(desired, saMetadata)
+			if err := r.Apply(r.ctx, desired); err != nil {
 				return common.FromClientError(err, "failed to update serviceaccount %s", serviceAccountName)
 			}
 			r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "ServiceAccount %s updated", serviceAccountName)
@@ -69,7 +86,7 @@ func (r *Reconciler) createOrApplyServiceAccounts(esc *operatorv1alpha1.External
 		}
 
 		if !exist {
-			if err := r.Create(r.ctx, desired); err != nil {
+			if err := r.Apply(r.ctx, desired); err != nil {
 				return common.FromClientError(err, "failed to create serviceaccount %s", serviceAccountName)
 			}
 			r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "Created serviceaccount %s", serviceAccountName)
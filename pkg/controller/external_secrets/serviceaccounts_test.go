@@ -44,7 +44,7 @@ func TestCreateOrApplyServiceAccounts(t *testing.T) {
 					expectedSAMap[name] = testServiceAccount(path)
 				}
 
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if sa, ok := obj.(*corev1.ServiceAccount); ok {
 						if _, found := expectedSAMap[sa.Name]; found {
 							return nil
@@ -61,7 +61,7 @@ func TestCreateOrApplyServiceAccounts(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					expectedSA := testServiceAccount("external-secrets/resources/serviceaccount_bitwarden-sdk-server.yml")
 					if sa, ok := obj.(*corev1.ServiceAccount); ok {
 						if sa.Name == expectedSA.Name {
@@ -84,7 +84,7 @@ func TestCreateOrApplyServiceAccounts(t *testing.T) {
 		{
 			name: "cert-controller serviceaccount skipped when cert-manager enabled",
 			preReq: func(r *Reconciler, m *fakes.FakeCtrlClient) {
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if sa, ok := obj.(*corev1.ServiceAccount); ok {
 						if sa.Name == "external-secrets-cert-controller" {
 							return errTest // should not be called
@@ -112,7 +112,7 @@ func TestCreateOrApplyServiceAccounts(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if sa, ok := obj.(*corev1.ServiceAccount); ok && sa.Name == "external-secrets" {
 						return errTest
 					}
@@ -127,7 +127,7 @@ func TestCreateOrApplyServiceAccounts(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if sa, ok := obj.(*corev1.ServiceAccount); ok {
 						// Verify annotations are applied
 						if sa.Annotations == nil {
@@ -159,7 +159,7 @@ func TestCreateOrApplyServiceAccounts(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if sa, ok := obj.(*corev1.ServiceAccount); ok {
 						if sa.Annotations == nil {
 							t.Error("serviceaccount annotations should not be nil")
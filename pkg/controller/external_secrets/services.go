@@ -52,6 +52,9 @@ func (r *Reconciler) createOrApplyServiceFromAsset(esc *operatorv1alpha1.Externa
 	service := common.DecodeServiceObjBytes(assets.MustAsset(assetName))
 	updateNamespace(service, esc)
 	common.ApplyResourceMetadata(service, resourceMetadata)
+	if err := r.applyOverride(esc, service); err != nil {
+		return err
+	}
 
 	serviceName := fmt.Sprintf("%s/%s", service.GetNamespace(), service.GetName())
 	r.log.V(4).Info("Reconciling service", "name", serviceName)
@@ -66,15 +69,15 @@ func (r *Reconciler) createOrApplyServiceFromAsset(esc *operatorv1alpha1.Externa
 		r.eventRecorder.Eventf(esc, corev1.EventTypeWarning, "ResourceAlreadyExists", "%s already exists", serviceName)
 	}
 	switch {
-	case exists && common.HasObjectChanged(service, fetched, &resourceMetadata):
+	case exists && r.hasObjectDrifted(service, fetched):
 		r.log.V(1).Info("Service modified, updating", "name", serviceName)
 		common.RemoveObsoleteAnnotations(service, resourceMetadata)
-		if err := r.UpdateWithRetry(r.ctx, service); err != nil {
+		if err := r.Apply(r.ctx, service); err != nil {
 			return common.FromClientError(err, "failed to update service %s", serviceName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "Service %s updated", serviceName)
 	case !exists:
-		if err := r.Create(r.ctx, service); err != nil {
+		if err := r.Apply(r.ctx, service); err != nil {
 			return common.FromClientError(err, "failed to create service %s", serviceName)
 		}
 		r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "Service %s created", serviceName)
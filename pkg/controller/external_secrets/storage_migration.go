@@ -0,0 +1,196 @@
+package external_secrets
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+)
+
+// storageMigration is the progress of the storage version migration of an external-secrets CRD.
+type storageMigration struct {
+	// crdName is the name of the CRD with the objects being migrated.
+	crdName string
+	// continueToken is the token for listing the next batch of the objects to migrate.
+	continueToken string
+	// migrated is the number of the objects rewritten at the storage version.
+	migrated int
+}
+
+// listExternalSecretsCRDs returns the CRDs of the external-secrets resources.
+func (r *Reconciler) listExternalSecretsCRDs() ([]crdv1.CustomResourceDefinition, error) {
+	crds := &crdv1.CustomResourceDefinitionList{}
+	if err := r.UncachedClient.List(r.ctx, crds, client.MatchingLabels{requestEnqueueLabelKey: requestEnqueueLabelValue}); err != nil {
+		return nil, common.FromClientError(err, "failed to list external-secrets customresourcedefinitions")
+	}
+	return crds.Items, nil
+}
+
+// getStorageVersion returns the version of the CRD the objects are stored at in etcd.
+func getStorageVersion(crd *crdv1.CustomResourceDefinition) string {
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			return version.Name
+		}
+	}
+	return ""
+}
+
+// isStorageVersionMigrated returns whether all the objects of the CRD are stored at the storage version.
+func isStorageVersionMigrated(crd *crdv1.CustomResourceDefinition) bool {
+	storageVersion := getStorageVersion(crd)
+	return storageVersion == "" || slices.Equal(crd.Status.StoredVersions, []string{storageVersion})
+}
+
+// migrateStorageVersions rewrites the objects of the external-secrets CRDs stored in etcd at a version other than
+// the storage version, like after an operand upgrade changing the storage version, for the older versions to be
+// removable from the CRDs. The objects are rewritten with no-op updates at a limited rate, in batches of at most
+// storageMigrationBatchSize objects per reconciliation, and status.storedVersions of the CRD is updated to the
+// storage version when all the objects are rewritten. The progress is reported in the StorageVersionMigrated
+// condition.
+func (r *Reconciler) migrateStorageVersions(esc *operatorv1alpha1.ExternalSecretsConfig) error {
+	crds, err := r.listExternalSecretsCRDs()
+	if err != nil {
+		return err
+	}
+	var pending []*crdv1.CustomResourceDefinition
+	for i := range crds {
+		if !isStorageVersionMigrated(&crds[i]) {
+			pending = append(pending, &crds[i])
+		}
+	}
+
+	cond := metav1.Condition{
+		Type:               operatorv1alpha1.StorageVersionMigrated,
+		Status:             metav1.ConditionTrue,
+		Reason:             operatorv1alpha1.ReasonCompleted,
+		Message:            "objects of all the external-secrets customresourcedefinitions are stored at the storage version",
+		ObservedGeneration: esc.GetGeneration(),
+	}
+	if len(pending) == 0 {
+		r.storageMigration = nil
+		return r.updateStorageMigrationCondition(esc, cond)
+	}
+
+	cond.Status = metav1.ConditionFalse
+	cond.Reason = operatorv1alpha1.ReasonInProgress
+	r.storageMigrationPending = true
+
+	// objects are rewritten through the external-secrets webhook, the migration waits for the webhook to be available.
+	available, err := r.isWebhookAvailable(esc)
+	if err != nil {
+		return err
+	}
+	if !available {
+		cond.Message = fmt.Sprintf("waiting for the external-secrets webhook to be available, customresourcedefinitions pending: %d", len(pending))
+		return r.updateStorageMigrationCondition(esc, cond)
+	}
+
+	crd := pending[0]
+	if r.storageMigration != nil {
+		if i := slices.IndexFunc(pending, func(c *crdv1.CustomResourceDefinition) bool { return c.GetName() == r.storageMigration.crdName }); i >= 0 {
+			crd = pending[i]
+		}
+	}
+	if r.storageMigration == nil || r.storageMigration.crdName != crd.GetName() {
+		r.storageMigration = &storageMigration{crdName: crd.GetName()}
+	}
+	storageVersion := getStorageVersion(crd)
+
+	done, err := r.migrateStorageVersionBatch(crd)
+	if err != nil {
+		return err
+	}
+	if !done {
+		cond.Message = fmt.Sprintf("migrating objects of %s to storage version %s, objects rewritten: %d, customresourcedefinitions pending: %d",
+			crd.GetName(), storageVersion, r.storageMigration.migrated, len(pending))
+		return r.updateStorageMigrationCondition(esc, cond)
+	}
+
+	crd.Status.StoredVersions = []string{storageVersion}
+	if err := r.UncachedClient.StatusUpdate(r.ctx, crd); err != nil {
+		return common.FromClientError(err, "failed to update stored versions of %s customresourcedefinition", crd.GetName())
+	}
+	r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "StorageVersionMigrated", "%d objects of %s customresourcedefinition migrated to storage version %s",
+		r.storageMigration.migrated, crd.GetName(), storageVersion)
+	r.storageMigration = nil
+
+	if len(pending) == 1 {
+		r.storageMigrationPending = false
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = operatorv1alpha1.ReasonCompleted
+		cond.Message = "objects of all the external-secrets customresourcedefinitions are stored at the storage version"
+	} else {
+		cond.Message = fmt.Sprintf("migrated objects of %s to storage version %s, customresourcedefinitions pending: %d",
+			crd.GetName(), storageVersion, len(pending)-1)
+	}
+	return r.updateStorageMigrationCondition(esc, cond)
+}
+
+// migrateStorageVersionBatch rewrites the next batch of the objects of the CRD with no-op updates, which stores the
+// objects at the storage version, and returns whether all the objects are rewritten. The batch is limited to the
+// updates allowed by the rate limiter, and is skipped when none are allowed.
+func (r *Reconciler) migrateStorageVersionBatch(crd *crdv1.CustomResourceDefinition) (bool, error) {
+	if r.storageMigrationLimiter == nil {
+		r.storageMigrationLimiter = rate.NewLimiter(rate.Limit(storageMigrationQPS), storageMigrationBatchSize)
+	}
+	batchSize := int(r.storageMigrationLimiter.Tokens())
+	if batchSize < 1 {
+		r.log.V(4).Info("storage version migration rate limited, skipping batch", "name", crd.GetName())
+		return false, nil
+	}
+
+	gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: getStorageVersion(crd), Kind: crd.Spec.Names.Kind}
+	list := unstructuredList(gvk)
+	err := r.UncachedClient.List(r.ctx, list, client.Limit(int64(batchSize)), client.Continue(r.storageMigration.continueToken))
+	if apierrors.IsResourceExpired(err) {
+		// the list is restarted when the continue token expired, the objects rewritten again are no-op updates.
+		r.log.V(1).Info("continue token for storage version migration expired, restarting", "name", crd.GetName())
+		r.storageMigration.continueToken = ""
+		return false, nil
+	}
+	if err != nil {
+		return false, common.FromClientError(err, "failed to list %s resources for storage version migration", strings.ToLower(gvk.Kind))
+	}
+
+	r.storageMigrationLimiter.AllowN(time.Now(), len(list.Items))
+	for i := range list.Items {
+		obj := &list.Items[i]
+		// objects deleted or updated since listed need not be rewritten, the update stores them at the storage
+		// version. They are not counted as rewritten.
+		err := r.UncachedClient.Update(r.ctx, obj)
+		if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+			continue
+		}
+		if err != nil {
+			return false, common.FromClientError(err, "failed to migrate %s/%s %s to storage version", obj.GetNamespace(), obj.GetName(), strings.ToLower(gvk.Kind))
+		}
+		r.storageMigration.migrated++
+	}
+
+	r.storageMigration.continueToken = list.GetContinue()
+	return r.storageMigration.continueToken == "", nil
+}
+
+// updateStorageMigrationCondition sets the StorageVersionMigrated condition and updates the status when changed.
+func (r *Reconciler) updateStorageMigrationCondition(esc *operatorv1alpha1.ExternalSecretsConfig, cond metav1.Condition) error {
+	if !apimeta.SetStatusCondition(&esc.Status.Conditions, cond) {
+		return nil
+	}
+	if err := r.updateStatus(r.ctx, esc); err != nil {
+		return common.FromClientError(err, "failed to update %s/%s status with storage version migration", esc.GetNamespace(), esc.GetName())
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package external_secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+	appsv1 "k8s.io/api/apps/v1"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+func TestMigrateStorageVersions(t *testing.T) {
+	tests := []struct {
+		name             string
+		storedVersions   []string
+		webhookAvailable bool
+		// pages are the names of the objects returned in each page of the list.
+		pages [][]string
+		// updateErrs are the errors returned for the updates of the objects.
+		updateErrs     map[string]error
+		wantUpdated    []string
+		wantStatus     metav1.ConditionStatus
+		wantMessage    string
+		wantPending    bool
+		wantMigrated   bool
+		wantNextUpdate []string
+	}{
+		{
+			name:           "objects stored at the storage version",
+			storedVersions: []string{"v1"},
+			wantStatus:     metav1.ConditionTrue,
+			wantMessage:    "objects of all the external-secrets customresourcedefinitions are stored at the storage version",
+		},
+		{
+			name:           "migration waiting for the webhook",
+			storedVersions: []string{"v1beta1", "v1"},
+			pages:          [][]string{{"es-1"}},
+			wantStatus:     metav1.ConditionFalse,
+			wantMessage:    "waiting for the external-secrets webhook to be available, customresourcedefinitions pending: 1",
+			wantPending:    true,
+		},
+		{
+			name:             "objects migrated in a batch",
+			storedVersions:   []string{"v1beta1", "v1"},
+			webhookAvailable: true,
+			pages:            [][]string{{"es-1", "es-2"}},
+			wantUpdated:      []string{"es-1", "es-2"},
+			wantStatus:       metav1.ConditionTrue,
+			wantMessage:      "objects of all the external-secrets customresourcedefinitions are stored at the storage version",
+			wantMigrated:     true,
+		},
+		{
+			name:             "objects migrated in batches",
+			storedVersions:   []string{"v1beta1", "v1"},
+			webhookAvailable: true,
+			pages:            [][]string{{"es-1", "es-2"}, {"es-3"}},
+			wantUpdated:      []string{"es-1", "es-2"},
+			wantStatus:       metav1.ConditionFalse,
+			wantMessage:      "migrating objects of ExternalSecret.external-secrets.io to storage version v1, objects rewritten: 2, customresourcedefinitions pending: 1",
+			wantPending:      true,
+			wantNextUpdate:   []string{"es-3"},
+		},
+		{
+			name:             "objects deleted or updated since listed not counted",
+			storedVersions:   []string{"v1beta1", "v1"},
+			webhookAvailable: true,
+			pages:            [][]string{{"es-1", "es-2", "es-3"}, {"es-4"}},
+			updateErrs: map[string]error{
+				"es-1": apierrors.NewNotFound(schema.GroupResource{Group: "external-secrets.io", Resource: "externalsecrets"}, "es-1"),
+				"es-2": apierrors.NewConflict(schema.GroupResource{Group: "external-secrets.io", Resource: "externalsecrets"}, "es-2", errors.New("object modified")),
+			},
+			wantUpdated:    []string{"es-1", "es-2", "es-3"},
+			wantStatus:     metav1.ConditionFalse,
+			wantMessage:    "migrating objects of ExternalSecret.external-secrets.io to storage version v1, objects rewritten: 1, customresourcedefinitions pending: 1",
+			wantPending:    true,
+			wantNextUpdate: []string{"es-4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			mock := &fakes.FakeCtrlClient{}
+			r.CtrlClient = mock
+			r.UncachedClient = mock
+
+			crd := testExternalSecretsCRD("ExternalSecret", tt.storedVersions...)
+			mock.ListCalls(func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+				switch l := list.(type) {
+				case *crdv1.CustomResourceDefinitionList:
+					l.Items = []crdv1.CustomResourceDefinition{*crd.DeepCopy()}
+				case *unstructured.UnstructuredList:
+					listOpts := &client.ListOptions{}
+					listOpts.ApplyOptions(opts)
+					page := 0
+					if listOpts.Continue != "" {
+						page = 1
+					}
+					l.Items = nil
+					l.SetContinue("")
+					for _, name := range tt.pages[page] {
+						l.Items = append(l.Items, testExternalSecretsResource("ExternalSecret", name, "external-secrets.io/v1beta1", nil))
+					}
+					if page+1 < len(tt.pages) {
+						l.SetContinue("next")
+					}
+				}
+				return nil
+			})
+			mock.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+				if o, ok := obj.(*appsv1.Deployment); ok && tt.webhookAvailable {
+					testDeploymentStatus(o, 1)
+					return true, nil
+				}
+				return false, nil
+			})
+			mock.UpdateCalls(func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				return tt.updateErrs[obj.GetName()]
+			})
+			var storedVersions []string
+			mock.StatusUpdateCalls(func(ctx context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+				if o, ok := obj.(*crdv1.CustomResourceDefinition); ok {
+					storedVersions = o.Status.StoredVersions
+				}
+				return nil
+			})
+
+			esc := commontest.TestExternalSecretsConfig()
+			verify := func(wantUpdated []string, wantStatus metav1.ConditionStatus, wantMessage string, wantPending, wantMigrated bool) {
+				t.Helper()
+				if err := r.migrateStorageVersions(esc); err != nil {
+					t.Fatalf("migrateStorageVersions() unexpected error: %v", err)
+				}
+				var updated []string
+				for i := range mock.UpdateCallCount() {
+					_, obj, _ := mock.UpdateArgsForCall(i)
+					updated = append(updated, obj.GetName())
+				}
+				if strings.Join(updated, ",") != strings.Join(wantUpdated, ",") {
+					t.Errorf("updated objects %v, want %v", updated, wantUpdated)
+				}
+				cond := apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.StorageVersionMigrated)
+				if cond == nil || cond.Status != wantStatus || cond.Message != wantMessage {
+					t.Errorf("%s condition = %+v, want status %s with message %q", operatorv1alpha1.StorageVersionMigrated, cond, wantStatus, wantMessage)
+				}
+				if r.storageMigrationPending != wantPending {
+					t.Errorf("storage migration pending = %v, want %v", r.storageMigrationPending, wantPending)
+				}
+				if (storedVersions != nil) != wantMigrated || (wantMigrated && strings.Join(storedVersions, ",") != "v1") {
+					t.Errorf("stored versions of the customresourcedefinition updated to %v, want migrated %v", storedVersions, wantMigrated)
+				}
+			}
+
+			verify(tt.wantUpdated, tt.wantStatus, tt.wantMessage, tt.wantPending, tt.wantMigrated)
+			if tt.wantNextUpdate != nil {
+				verify(append(tt.wantUpdated, tt.wantNextUpdate...), metav1.ConditionTrue,
+					"objects of all the external-secrets customresourcedefinitions are stored at the storage version", false, true)
+			}
+		})
+	}
+}
+
+func TestMigrateStorageVersionBatchRateLimited(t *testing.T) {
+	r := testReconciler(t)
+	mock := &fakes.FakeCtrlClient{}
+	r.UncachedClient = mock
+	mock.ListCalls(func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+		listOpts := &client.ListOptions{}
+		listOpts.ApplyOptions(opts)
+		l := list.(*unstructured.UnstructuredList)
+		for i := range listOpts.Limit {
+			l.Items = append(l.Items, testExternalSecretsResource("ExternalSecret", fmt.Sprintf("es-%d", i), "external-secrets.io/v1", nil))
+		}
+		l.SetContinue("next")
+		return nil
+	})
+
+	crd := testExternalSecretsCRD("ExternalSecret", "v1beta1", "v1")
+	r.storageMigration = &storageMigration{crdName: crd.GetName()}
+	// the limiter is not refilled in the test, the batches are limited to the updates allowed.
+	r.storageMigrationLimiter = rate.NewLimiter(0, storageMigrationBatchSize)
+	r.storageMigrationLimiter.AllowN(time.Now(), storageMigrationBatchSize-5)
+
+	for _, wantUpdates := range []int{5, 5} {
+		if _, err := r.migrateStorageVersionBatch(&crd); err != nil {
+			t.Fatalf("migrateStorageVersionBatch() unexpected error: %v", err)
+		}
+		if mock.UpdateCallCount() != wantUpdates {
+			t.Errorf("updated %d objects, want %d allowed by the rate limiter", mock.UpdateCallCount(), wantUpdates)
+		}
+	}
+	if mock.ListCallCount() != 1 {
+		t.Errorf("listed objects %d times, want the batch skipped when rate limited", mock.ListCallCount())
+	}
+}
@@ -11,13 +11,17 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/go-logr/logr/testr"
 
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 
 	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	operatorclient "github.com/openshift/external-secrets-operator/pkg/controller/client"
 	"github.com/openshift/external-secrets-operator/pkg/controller/common"
 	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
 	"github.com/openshift/external-secrets-operator/pkg/operator/assets"
@@ -46,6 +50,15 @@ func testReconciler(t *testing.T) *Reconciler {
 	}
 }
 
+// testScheme returns a scheme with the types of the resources created for the external-secrets deployment.
+func testScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(certmanagerv1.AddToScheme(scheme))
+	utilruntime.Must(operatorv1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
 // testService returns a Service object decoded from the specified asset file.
 func testService(assetName string) *corev1.Service {
 	service := common.DecodeServiceObjBytes(assets.MustAsset(assetName))
@@ -141,3 +154,18 @@ func testNetworkPolicy(assetName string) *networkingv1.NetworkPolicy {
 	networkPolicy.SetLabels(controllerDefaultResourceLabels)
 	return networkPolicy
 }
+
+// testApplied sets the managed fields of obj as applied by the operator with server-side apply, for the object
+// fetched from the cluster to match the fields owned by the operator, and returns obj.
+func testApplied[T client.Object](obj T) T {
+	content, err := operatorclient.ApplyConfiguration(obj, nil)
+	if err != nil {
+		panic(err)
+	}
+	entry, err := operatorclient.AppliedManagedFieldsEntry(content)
+	if err != nil {
+		panic(err)
+	}
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{entry})
+	return obj
+}
@@ -0,0 +1,64 @@
+package external_secrets
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	operatorclient "github.com/openshift/external-secrets-operator/pkg/controller/client"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+)
+
+// isUnmanagedResource returns whether the resource is annotated by the users for the controller to not correct its
+// drift from the desired state.
+func isUnmanagedResource(obj client.Object) bool {
+	return obj.GetAnnotations()[unmanagedResourceAnnotation] == "true"
+}
+
+// hasObjectDrifted returns whether the fetched object has drifted from the desired object and is to be applied. The
+// resources annotated with unmanagedResourceAnnotation are not applied, and are recorded for reporting in the
+// UnmanagedResources condition.
+func (r *Reconciler) hasObjectDrifted(desired, fetched client.Object, yield ...operatorclient.FieldPath) bool {
+	if isUnmanagedResource(fetched) {
+		r.log.V(1).Info("skipping reconciliation of unmanaged resource", "namespace", fetched.GetNamespace(), "name", fetched.GetName())
+		r.unmanagedResources = append(r.unmanagedResources, r.resourceReference(fetched))
+		return false
+	}
+	return common.HasObjectDrifted(desired, fetched, yield...)
+}
+
+// resourceReference returns the kind, namespace and name of the resource, for reporting in the status.
+func (r *Reconciler) resourceReference(obj client.Object) string {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if gvk, err := apiutil.GVKForObject(obj, r.Scheme); err == nil {
+		kind = gvk.Kind
+	}
+	if obj.GetNamespace() == "" {
+		return fmt.Sprintf("%s %s", kind, obj.GetName())
+	}
+	return fmt.Sprintf("%s %s/%s", kind, obj.GetNamespace(), obj.GetName())
+}
+
+// setUnmanagedResourcesCondition sets the UnmanagedResources condition listing the unmanaged resources found in the
+// reconciliation, or removes the condition when there are none, and returns whether the conditions changed.
+func (r *Reconciler) setUnmanagedResourcesCondition(esc *operatorv1alpha1.ExternalSecretsConfig) bool {
+	if len(r.unmanagedResources) == 0 {
+		return apimeta.RemoveStatusCondition(&esc.Status.Conditions, operatorv1alpha1.UnmanagedResources)
+	}
+	resources := slices.Clone(r.unmanagedResources)
+	slices.Sort(resources)
+	return apimeta.SetStatusCondition(&esc.Status.Conditions, metav1.Condition{
+		Type:   operatorv1alpha1.UnmanagedResources,
+		Status: metav1.ConditionTrue,
+		Reason: operatorv1alpha1.ReasonUnmanaged,
+		Message: fmt.Sprintf("resources annotated with %s=true are not reconciled: %s",
+			unmanagedResourceAnnotation, strings.Join(slices.Compact(resources), ", ")),
+		ObservedGeneration: esc.GetGeneration(),
+	})
+}
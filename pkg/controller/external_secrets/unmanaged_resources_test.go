@@ -0,0 +1,173 @@
+package external_secrets
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+func TestUnmanagedResources(t *testing.T) {
+	unmanaged := map[string]string{unmanagedResourceAnnotation: "true"}
+
+	tests := []struct {
+		name          string
+		fetched       []client.Object
+		wantDrifted   []bool
+		wantCondition string
+	}{
+		{
+			name: "resources without annotation are reconciled",
+			fetched: []client.Object{
+				&networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "allow-api-server", Namespace: "external-secrets"}},
+				&networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "allow-webhook", Namespace: "external-secrets",
+					Annotations: map[string]string{unmanagedResourceAnnotation: "false"}}},
+			},
+			wantDrifted: []bool{true, true},
+		},
+		{
+			name: "annotated resources are not reconciled and are reported",
+			fetched: []client.Object{
+				&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "external-secrets-controller", Annotations: unmanaged}},
+				&networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "allow-api-server", Namespace: "external-secrets"}},
+				&networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "allow-webhook", Namespace: "external-secrets", Annotations: unmanaged}},
+			},
+			wantDrifted: []bool{false, true, false},
+			wantCondition: "resources annotated with operator.openshift.io/external-secrets-unmanaged=true are not reconciled: " +
+				"ClusterRole external-secrets-controller, NetworkPolicy external-secrets/allow-webhook",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			_ = networkingv1.AddToScheme(r.Scheme)
+			_ = rbacv1.AddToScheme(r.Scheme)
+			esc := commontest.TestExternalSecretsConfig()
+			esc.Status.Conditions = []metav1.Condition{
+				{Type: operatorv1alpha1.UnmanagedResources, Status: metav1.ConditionTrue, Reason: operatorv1alpha1.ReasonUnmanaged},
+			}
+
+			var drifted []bool
+			for _, fetched := range tt.fetched {
+				desired := fetched.DeepCopyObject().(client.Object)
+				desired.SetAnnotations(nil)
+				drifted = append(drifted, r.hasObjectDrifted(desired, fetched))
+			}
+			if !reflect.DeepEqual(drifted, tt.wantDrifted) {
+				t.Errorf("hasObjectDrifted() = %v, want %v", drifted, tt.wantDrifted)
+			}
+
+			if !r.setUnmanagedResourcesCondition(esc) {
+				t.Errorf("setUnmanagedResourcesCondition() expected conditions to change")
+			}
+			cond := apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.UnmanagedResources)
+			switch {
+			case tt.wantCondition == "" && cond != nil:
+				t.Errorf("expected %s condition to be removed, got %+v", operatorv1alpha1.UnmanagedResources, cond)
+			case tt.wantCondition != "" && (cond == nil || cond.Message != tt.wantCondition):
+				t.Errorf("%s condition = %+v, want message %q", operatorv1alpha1.UnmanagedResources, cond, tt.wantCondition)
+			}
+		})
+	}
+}
+
+func TestHasObjectDrifted(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired client.Object
+		modify  func(fetched client.Object)
+		want    bool
+	}{
+		{
+			name:    "serviceaccount secrets added by the platform are not a drift",
+			desired: testServiceAccount(controllerServiceAccountAssetName),
+			modify: func(fetched client.Object) {
+				sa := fetched.(*corev1.ServiceAccount)
+				sa.Secrets = []corev1.ObjectReference{{Name: "external-secrets-token"}}
+				sa.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "external-secrets-dockercfg"}}
+			},
+		},
+		{
+			name: "serviceaccount workload identity annotation changed",
+			desired: func() client.Object {
+				sa := testServiceAccount(controllerServiceAccountAssetName)
+				sa.SetAnnotations(map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/new"})
+				return sa
+			}(),
+			modify: func(fetched client.Object) {
+				fetched.SetAnnotations(map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/old"})
+			},
+			want: true,
+		},
+		{
+			name:    "configmap data changed",
+			desired: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "external-secrets"}, Data: map[string]string{"key": "new"}},
+			modify: func(fetched client.Object) {
+				fetched.(*corev1.ConfigMap).Data["key"] = "old"
+			},
+			want: true,
+		},
+		{
+			name:    "configmap data unchanged",
+			desired: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "external-secrets"}, Data: map[string]string{"key": "new"}},
+			modify:  func(client.Object) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			fetched := testApplied(tt.desired.DeepCopyObject().(client.Object))
+			tt.modify(fetched)
+			if got := r.hasObjectDrifted(tt.desired, fetched); got != tt.want {
+				t.Errorf("hasObjectDrifted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmanagedResourcesOnReconcileError(t *testing.T) {
+	t.Setenv(externalsecretsImageEnvVarName, commontest.TestExternalSecretsImageName)
+	r := testReconciler(t)
+	_ = corev1.AddToScheme(r.Scheme)
+	mock := &fakes.FakeCtrlClient{}
+	r.CtrlClient = mock
+	r.UncachedClient = mock
+	esc := commontest.TestExternalSecretsConfig()
+
+	mock.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+		namespace, ok := obj.(*corev1.Namespace)
+		if !ok {
+			return false, commontest.ErrTestClient
+		}
+		namespace.SetName(ns.Name)
+		namespace.SetAnnotations(map[string]string{unmanagedResourceAnnotation: "true"})
+		return true, nil
+	})
+
+	if _, err := r.processReconcileRequest(esc, types.NamespacedName{Name: esc.GetName()}); !errors.Is(err, commontest.ErrTestClient) {
+		t.Fatalf("processReconcileRequest() error = %v, want %v", err, commontest.ErrTestClient)
+	}
+	ready := apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.Ready)
+	if ready == nil || ready.Status != metav1.ConditionFalse {
+		t.Errorf("Ready condition = %+v, want status %s", ready, metav1.ConditionFalse)
+	}
+	cond := apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.UnmanagedResources)
+	if cond == nil || cond.Status != metav1.ConditionTrue || !strings.Contains(cond.Message, "Namespace external-secrets") {
+		t.Errorf("%s condition = %+v, want the unmanaged namespace reported", operatorv1alpha1.UnmanagedResources, cond)
+	}
+}
@@ -0,0 +1,259 @@
+package external_secrets
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+)
+
+// upgradeCheckListLimit is the number of the resources fetched in a page when listing the external-secrets resources.
+const upgradeCheckListLimit = 500
+
+// vaultJWTServiceAccountTokenPath is the path of the Vault JWT authentication service account token configuration
+// in the SecretStore and ClusterSecretStore resources.
+var vaultJWTServiceAccountTokenPath = []string{"spec", "provider", "vault", "auth", "jwt", "kubernetesServiceAccountToken"}
+
+// deprecatedFields are the fields of the external-secrets resources deprecated and dropped in the next versions,
+// keyed by the group and kind of the resources. "[]" in a path matches every item of a list.
+var deprecatedFields = map[schema.GroupKind][][]string{
+	{Group: "external-secrets.io", Kind: "ExternalSecret"}: {
+		{"spec", "data", "[]", "sourceRef", "generatorRef"},
+	},
+	{Group: "external-secrets.io", Kind: "ClusterExternalSecret"}: {
+		{"spec", "namespaceSelector"},
+		{"spec", "externalSecretSpec", "data", "[]", "sourceRef", "generatorRef"},
+	},
+	{Group: "external-secrets.io", Kind: "SecretStore"}: {
+		append(slices.Clone(vaultJWTServiceAccountTokenPath), "audiences"),
+		append(slices.Clone(vaultJWTServiceAccountTokenPath), "expirationSeconds"),
+	},
+	{Group: "external-secrets.io", Kind: "ClusterSecretStore"}: {
+		append(slices.Clone(vaultJWTServiceAccountTokenPath), "audiences"),
+		append(slices.Clone(vaultJWTServiceAccountTokenPath), "expirationSeconds"),
+	},
+}
+
+// operatorConditionSpec is the spec of the OLM OperatorCondition resource, with the conditions reported by the operator.
+type operatorConditionSpec struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// checkUpgradeable scans the external-secrets resources for the API versions and the fields deprecated and dropped
+// in the next versions, lists the resources using them in status.upgradeBlockers, and sets the Upgradeable
+// condition in the status and in the OperatorCondition of the operator, for OLM to block the upgrade of the
+// operator. The resources are scanned at most once in upgradeCheckInterval for an ExternalSecretsConfig reporting
+// no blockers, and in every reconciliation while blockers are reported, for the fixed blockers to be cleared.
+func (r *Reconciler) checkUpgradeable(esc *operatorv1alpha1.ExternalSecretsConfig) error {
+	if !r.isUpgradeCheckRequired(esc) {
+		return nil
+	}
+
+	blockers, err := r.getUpgradeBlockers()
+	if err != nil {
+		return err
+	}
+
+	cond := metav1.Condition{
+		Type:               operatorv1alpha1.Upgradeable,
+		Status:             metav1.ConditionTrue,
+		Reason:             operatorv1alpha1.ReasonReady,
+		Message:            "no external-secrets resources use deprecated API versions or fields",
+		ObservedGeneration: esc.GetGeneration(),
+	}
+	if len(blockers) > 0 {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = operatorv1alpha1.ReasonDeprecatedAPIInUse
+		cond.Message = fmt.Sprintf("%d external-secrets resources use API versions or fields dropped in the next versions, the resources must be updated before upgrading", len(blockers))
+	}
+	if err := r.updateOperatorCondition(cond); err != nil {
+		return err
+	}
+
+	blockers = blockers[:min(len(blockers), maxUpgradeBlockers)]
+	changed := !reflect.DeepEqual(esc.Status.UpgradeBlockers, blockers)
+	esc.Status.UpgradeBlockers = blockers
+	changed = apimeta.SetStatusCondition(&esc.Status.Conditions, cond) || changed
+	if changed {
+		if err := r.updateStatus(r.ctx, esc); err != nil {
+			return common.FromClientError(err, "failed to update %s/%s status with upgrade blockers", esc.GetNamespace(), esc.GetName())
+		}
+	}
+
+	if r.upgradeCheckTimes == nil {
+		r.upgradeCheckTimes = make(map[types.UID]time.Time)
+	}
+	r.upgradeCheckTimes[esc.GetUID()] = time.Now()
+	return nil
+}
+
+// isUpgradeCheckRequired returns whether the external-secrets resources must be scanned for the upgrade blockers of
+// the ExternalSecretsConfig, when the Upgradeable condition is not reported for its current generation, reports
+// blockers, or was last checked earlier than upgradeCheckInterval.
+func (r *Reconciler) isUpgradeCheckRequired(esc *operatorv1alpha1.ExternalSecretsConfig) bool {
+	cond := apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.Upgradeable)
+	if cond == nil || cond.ObservedGeneration != esc.GetGeneration() || cond.Status != metav1.ConditionTrue {
+		return true
+	}
+	checked, ok := r.upgradeCheckTimes[esc.GetUID()]
+	return !ok || time.Since(checked) >= upgradeCheckInterval
+}
+
+// getUpgradeBlockers returns the external-secrets CRDs with the objects stored in etcd at a deprecated version, and
+// the external-secrets resources using a deprecated field.
+func (r *Reconciler) getUpgradeBlockers() ([]operatorv1alpha1.UpgradeBlocker, error) {
+	crds, err := r.listExternalSecretsCRDs()
+	if err != nil {
+		return nil, err
+	}
+
+	var blockers []operatorv1alpha1.UpgradeBlocker
+	for _, crd := range crds {
+		var deprecatedVersions []string
+		for _, version := range crd.Spec.Versions {
+			if version.Deprecated {
+				deprecatedVersions = append(deprecatedVersions, version.Name)
+			}
+		}
+		for _, version := range crd.Status.StoredVersions {
+			if slices.Contains(deprecatedVersions, version) {
+				blockers = append(blockers, operatorv1alpha1.UpgradeBlocker{
+					Kind:   "CustomResourceDefinition",
+					Name:   crd.GetName(),
+					Reason: fmt.Sprintf("objects stored at deprecated version %s", version),
+				})
+			}
+		}
+
+		storageVersion := getStorageVersion(&crd)
+		gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: storageVersion, Kind: crd.Spec.Names.Kind}
+		fields := deprecatedFields[gvk.GroupKind()]
+		if storageVersion == "" || len(fields) == 0 {
+			continue
+		}
+		resourceBlockers, err := r.getResourceUpgradeBlockers(gvk, fields)
+		if err != nil {
+			return nil, err
+		}
+		blockers = append(blockers, resourceBlockers...)
+	}
+	return blockers, nil
+}
+
+// getResourceUpgradeBlockers returns the resources of the kind using one of the deprecated fields. The resources
+// written with a deprecated API version are not listed, as they are converted to the storage version, and are
+// reported with status.storedVersions of the CRD until migrated.
+func (r *Reconciler) getResourceUpgradeBlockers(gvk schema.GroupVersionKind, fields [][]string) ([]operatorv1alpha1.UpgradeBlocker, error) {
+	var blockers []operatorv1alpha1.UpgradeBlocker
+	list := unstructuredList(gvk)
+	for {
+		if err := r.UncachedClient.List(r.ctx, list, client.Limit(upgradeCheckListLimit), client.Continue(list.GetContinue())); err != nil {
+			return nil, common.FromClientError(err, "failed to list %s resources", strings.ToLower(gvk.Kind))
+		}
+		for i := range list.Items {
+			reasons := getDeprecatedFieldsUsage(&list.Items[i], fields)
+			if len(reasons) == 0 {
+				continue
+			}
+			blockers = append(blockers, operatorv1alpha1.UpgradeBlocker{
+				Kind:      gvk.Kind,
+				Namespace: list.Items[i].GetNamespace(),
+				Name:      list.Items[i].GetName(),
+				Reason:    strings.Join(reasons, ", "),
+			})
+		}
+		if list.GetContinue() == "" {
+			return blockers, nil
+		}
+	}
+}
+
+// getDeprecatedFieldsUsage returns the deprecated fields used by the resource.
+func getDeprecatedFieldsUsage(obj *unstructured.Unstructured, fields [][]string) []string {
+	var reasons []string
+	for _, path := range fields {
+		if hasField(obj.Object, path) {
+			reasons = append(reasons, fmt.Sprintf("uses deprecated field %s", strings.ReplaceAll(strings.Join(path, "."), ".[]", "[]")))
+		}
+	}
+	return reasons
+}
+
+// hasField returns whether the field in the path is set in the object. "[]" in the path matches every item of a list.
+func hasField(obj interface{}, path []string) bool {
+	if len(path) == 0 {
+		return obj != nil
+	}
+	if path[0] == "[]" {
+		items, _ := obj.([]interface{})
+		for _, item := range items {
+			if hasField(item, path[1:]) {
+				return true
+			}
+		}
+		return false
+	}
+	fields, ok := obj.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	return hasField(fields[path[0]], path[1:])
+}
+
+// updateOperatorCondition sets the Upgradeable condition in the OperatorCondition created by OLM for the operator,
+// which is not present when the operator is not installed by OLM.
+func (r *Reconciler) updateOperatorCondition(cond metav1.Condition) error {
+	name, namespace := os.Getenv(operatorConditionNameEnvVarName), os.Getenv(operatorNamespaceEnvVarName)
+	if name == "" || namespace == "" {
+		r.log.V(4).Info("operator not installed by OLM, skipping update of the operatorcondition")
+		return nil
+	}
+
+	key := types.NamespacedName{Name: name, Namespace: namespace}
+	operatorCondition := &unstructured.Unstructured{}
+	operatorCondition.SetGroupVersionKind(operatorConditionGVK)
+	exist, err := r.UncachedClient.Exists(r.ctx, key, operatorCondition)
+	if err != nil {
+		return common.FromClientError(err, "failed to fetch %s operatorcondition", key)
+	}
+	if !exist {
+		r.log.V(1).Info("operatorcondition of the operator not found", "name", key)
+		return nil
+	}
+
+	spec := operatorConditionSpec{}
+	if content, ok := operatorCondition.Object["spec"].(map[string]interface{}); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, &spec); err != nil {
+			return common.NewRetryRequiredError(err, "failed to decode spec of %s operatorcondition", key)
+		}
+	}
+	// observedGeneration of the ExternalSecretsConfig is not relevant for the OperatorCondition.
+	cond.ObservedGeneration = 0
+	if !apimeta.SetStatusCondition(&spec.Conditions, cond) {
+		return nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&spec)
+	if err != nil {
+		return common.NewRetryRequiredError(err, "failed to encode spec of %s operatorcondition", key)
+	}
+	if err := unstructured.SetNestedField(operatorCondition.Object, content["conditions"], "spec", "conditions"); err != nil {
+		return common.NewRetryRequiredError(err, "failed to update spec of %s operatorcondition", key)
+	}
+	if err := r.UncachedClient.Update(r.ctx, operatorCondition); err != nil {
+		return common.FromClientError(err, "failed to update %s operatorcondition", key)
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package external_secrets
+
+import (
+	"context"
+	"testing"
+
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+// testExternalSecretsCRD returns an external-secrets CRD of the kind, served at v1 and at the deprecated v1beta1,
+// with the objects stored at the given versions.
+func testExternalSecretsCRD(kind string, storedVersions ...string) crdv1.CustomResourceDefinition {
+	return crdv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: kind + ".external-secrets.io"},
+		Spec: crdv1.CustomResourceDefinitionSpec{
+			Group: "external-secrets.io",
+			Names: crdv1.CustomResourceDefinitionNames{Kind: kind},
+			Versions: []crdv1.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true, Storage: true},
+				{Name: "v1beta1", Deprecated: true},
+			},
+		},
+		Status: crdv1.CustomResourceDefinitionStatus{StoredVersions: storedVersions},
+	}
+}
+
+// testExternalSecretsResource returns an external-secrets resource of the kind with the spec, written with the
+// API version.
+func testExternalSecretsResource(kind, name, apiVersion string, spec map[string]interface{}) unstructured.Unstructured {
+	obj := unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetAPIVersion("external-secrets.io/v1")
+	obj.SetKind(kind)
+	obj.SetNamespace("test")
+	obj.SetName(name)
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply, APIVersion: apiVersion}})
+	return obj
+}
+
+func TestCheckUpgradeable(t *testing.T) {
+	tests := []struct {
+		name         string
+		crds         []crdv1.CustomResourceDefinition
+		resources    []unstructured.Unstructured
+		wantStatus   metav1.ConditionStatus
+		wantBlockers []operatorv1alpha1.UpgradeBlocker
+	}{
+		{
+			name: "no deprecated API versions or fields used",
+			crds: []crdv1.CustomResourceDefinition{testExternalSecretsCRD("ExternalSecret", "v1")},
+			resources: []unstructured.Unstructured{
+				testExternalSecretsResource("ExternalSecret", "es", "external-secrets.io/v1", map[string]interface{}{
+					"data": []interface{}{map[string]interface{}{"secretKey": "key"}},
+				}),
+			},
+			wantStatus: metav1.ConditionTrue,
+		},
+		{
+			name: "resource written earlier with deprecated API version",
+			crds: []crdv1.CustomResourceDefinition{testExternalSecretsCRD("ExternalSecret", "v1")},
+			resources: []unstructured.Unstructured{
+				testExternalSecretsResource("ExternalSecret", "es", "external-secrets.io/v1beta1", map[string]interface{}{
+					"data": []interface{}{map[string]interface{}{"secretKey": "key"}},
+				}),
+			},
+			wantStatus: metav1.ConditionTrue,
+		},
+		{
+			name:       "objects stored at deprecated version",
+			crds:       []crdv1.CustomResourceDefinition{testExternalSecretsCRD("ClusterSecretStore", "v1beta1", "v1")},
+			wantStatus: metav1.ConditionFalse,
+			wantBlockers: []operatorv1alpha1.UpgradeBlocker{
+				{Kind: "CustomResourceDefinition", Name: "ClusterSecretStore.external-secrets.io", Reason: "objects stored at deprecated version v1beta1"},
+			},
+		},
+		{
+			name: "resources using deprecated fields",
+			crds: []crdv1.CustomResourceDefinition{
+				testExternalSecretsCRD("ExternalSecret", "v1"),
+				testExternalSecretsCRD("ClusterExternalSecret", "v1"),
+				testExternalSecretsCRD("SecretStore", "v1"),
+			},
+			resources: []unstructured.Unstructured{
+				testExternalSecretsResource("ExternalSecret", "es", "external-secrets.io/v1beta1", map[string]interface{}{
+					"data": []interface{}{
+						map[string]interface{}{"secretKey": "key"},
+						map[string]interface{}{"sourceRef": map[string]interface{}{"generatorRef": map[string]interface{}{"kind": "Password"}}},
+					},
+				}),
+				testExternalSecretsResource("ClusterExternalSecret", "ces", "external-secrets.io/v1", map[string]interface{}{
+					"namespaceSelector": map[string]interface{}{"matchLabels": map[string]interface{}{"team": "a"}},
+				}),
+				testExternalSecretsResource("SecretStore", "vault", "external-secrets.io/v1", map[string]interface{}{
+					"provider": map[string]interface{}{"vault": map[string]interface{}{"auth": map[string]interface{}{"jwt": map[string]interface{}{
+						"kubernetesServiceAccountToken": map[string]interface{}{"audiences": []interface{}{"vault"}},
+					}}}},
+				}),
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantBlockers: []operatorv1alpha1.UpgradeBlocker{
+				{Kind: "ExternalSecret", Namespace: "test", Name: "es", Reason: "uses deprecated field spec.data[].sourceRef.generatorRef"},
+				{Kind: "ClusterExternalSecret", Namespace: "test", Name: "ces", Reason: "uses deprecated field spec.namespaceSelector"},
+				{Kind: "SecretStore", Namespace: "test", Name: "vault", Reason: "uses deprecated field spec.provider.vault.auth.jwt.kubernetesServiceAccountToken.audiences"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			mock := &fakes.FakeCtrlClient{}
+			r.CtrlClient = mock
+			r.UncachedClient = mock
+			mock.ListCalls(func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+				switch l := list.(type) {
+				case *crdv1.CustomResourceDefinitionList:
+					l.Items = tt.crds
+				case *unstructured.UnstructuredList:
+					l.Items = nil
+					for _, resource := range tt.resources {
+						if resource.GetKind()+"List" == l.GetKind() {
+							l.Items = append(l.Items, resource)
+						}
+					}
+				}
+				return nil
+			})
+			t.Setenv(operatorConditionNameEnvVarName, "")
+
+			esc := commontest.TestExternalSecretsConfig()
+			if err := r.checkUpgradeable(esc); err != nil {
+				t.Fatalf("checkUpgradeable() unexpected error: %v", err)
+			}
+
+			cond := apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.Upgradeable)
+			if cond == nil || cond.Status != tt.wantStatus {
+				t.Fatalf("%s condition = %+v, want status %s", operatorv1alpha1.Upgradeable, cond, tt.wantStatus)
+			}
+			if len(esc.Status.UpgradeBlockers) != len(tt.wantBlockers) {
+				t.Fatalf("status.upgradeBlockers = %+v, want %+v", esc.Status.UpgradeBlockers, tt.wantBlockers)
+			}
+			for i, blocker := range esc.Status.UpgradeBlockers {
+				if blocker != tt.wantBlockers[i] {
+					t.Errorf("status.upgradeBlockers[%d] = %+v, want %+v", i, blocker, tt.wantBlockers[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckUpgradeableOperatorCondition(t *testing.T) {
+	r := testReconciler(t)
+	mock := &fakes.FakeCtrlClient{}
+	r.CtrlClient = mock
+	r.UncachedClient = mock
+	t.Setenv(operatorConditionNameEnvVarName, "external-secrets-operator.v1.1.0")
+	t.Setenv(operatorNamespaceEnvVarName, "external-secrets-operator")
+
+	mock.ListCalls(func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+		if l, ok := list.(*crdv1.CustomResourceDefinitionList); ok {
+			l.Items = []crdv1.CustomResourceDefinition{testExternalSecretsCRD("ExternalSecret", "v1beta1", "v1")}
+		}
+		return nil
+	})
+	mock.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+		if ns.Name != "external-secrets-operator.v1.1.0" || ns.Namespace != "external-secrets-operator" {
+			t.Errorf("unexpected operatorcondition %s fetched", ns)
+		}
+		obj.(*unstructured.Unstructured).Object["spec"] = map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{
+					"type": "Other", "status": "True", "reason": "Other", "message": "",
+					"lastTransitionTime": "2025-01-01T00:00:00Z",
+				},
+			},
+		}
+		return true, nil
+	})
+
+	esc := commontest.TestExternalSecretsConfig()
+	if err := r.checkUpgradeable(esc); err != nil {
+		t.Fatalf("checkUpgradeable() unexpected error: %v", err)
+	}
+	if mock.UpdateCallCount() != 1 {
+		t.Fatalf("updated operatorcondition %d times, want once", mock.UpdateCallCount())
+	}
+	_, obj, _ := mock.UpdateArgsForCall(0)
+	conditions, _, _ := unstructured.NestedSlice(obj.(*unstructured.Unstructured).Object, "spec", "conditions")
+	if len(conditions) != 2 {
+		t.Fatalf("operatorcondition spec.conditions = %v, want the existing and the Upgradeable conditions", conditions)
+	}
+	upgradeable := conditions[1].(map[string]interface{})
+	if upgradeable["type"] != operatorv1alpha1.Upgradeable || upgradeable["status"] != string(metav1.ConditionFalse) ||
+		upgradeable["reason"] != operatorv1alpha1.ReasonDeprecatedAPIInUse {
+		t.Errorf("operatorcondition %s condition = %v, want False", operatorv1alpha1.Upgradeable, upgradeable)
+	}
+}
+
+func TestCheckUpgradeableInterval(t *testing.T) {
+	r := testReconciler(t)
+	mock := &fakes.FakeCtrlClient{}
+	r.CtrlClient = mock
+	r.UncachedClient = mock
+	t.Setenv(operatorConditionNameEnvVarName, "")
+
+	storedVersions := []string{"v1beta1", "v1"}
+	mock.ListCalls(func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+		if l, ok := list.(*crdv1.CustomResourceDefinitionList); ok {
+			l.Items = []crdv1.CustomResourceDefinition{testExternalSecretsCRD("ClusterSecretStore", storedVersions...)}
+		}
+		return nil
+	})
+	esc := commontest.TestExternalSecretsConfig()
+	esc.SetUID("esc-uid")
+
+	// checkUpgradeable scans the resources and returns whether they were scanned.
+	checkUpgradeable := func(esc *operatorv1alpha1.ExternalSecretsConfig) bool {
+		lists := mock.ListCallCount()
+		if err := r.checkUpgradeable(esc); err != nil {
+			t.Fatalf("checkUpgradeable() unexpected error: %v", err)
+		}
+		return mock.ListCallCount() != lists
+	}
+
+	if !checkUpgradeable(esc) || apimeta.IsStatusConditionTrue(esc.Status.Conditions, operatorv1alpha1.Upgradeable) {
+		t.Fatalf("checkUpgradeable() expected to report the objects stored at the deprecated version")
+	}
+	// the blockers are rescanned until fixed.
+	storedVersions = []string{"v1"}
+	if !checkUpgradeable(esc) || !apimeta.IsStatusConditionTrue(esc.Status.Conditions, operatorv1alpha1.Upgradeable) {
+		t.Fatalf("checkUpgradeable() expected to clear the blockers fixed")
+	}
+	if checkUpgradeable(esc) {
+		t.Errorf("checkUpgradeable() expected to skip the scan within %s", upgradeCheckInterval)
+	}
+
+	esc.SetGeneration(esc.GetGeneration() + 1)
+	if !checkUpgradeable(esc) {
+		t.Errorf("checkUpgradeable() expected to scan for the new generation")
+	}
+
+	recreated := commontest.TestExternalSecretsConfig()
+	recreated.SetUID("recreated-uid")
+	recreated.SetGeneration(esc.GetGeneration())
+	recreated.Status.Conditions = esc.Status.Conditions
+	if !checkUpgradeable(recreated) {
+		t.Errorf("checkUpgradeable() expected to scan for the recreated externalsecretsconfig")
+	}
+}
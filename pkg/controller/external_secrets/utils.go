@@ -6,6 +6,7 @@ import (
 	"os"
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -13,6 +14,7 @@ import (
 
 	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
 	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+	"github.com/openshift/external-secrets-operator/pkg/metrics"
 )
 
 func getNamespace(_ *operatorv1alpha1.ExternalSecretsConfig) string {
@@ -66,6 +68,7 @@ func (r *Reconciler) updateStatus(ctx context.Context, changed *operatorv1alpha1
 		if err := r.StatusUpdate(ctx, current); err != nil {
 			return fmt.Errorf("failed to update externalsecretsconfigs.operator.openshift.io %q status: %w", namespacedName, err)
 		}
+		metrics.RecordExternalSecretsConfigConditions(current.Status.Conditions)
 
 		return nil
 	}); err != nil {
@@ -78,12 +81,23 @@ func (r *Reconciler) updateStatus(ctx context.Context, changed *operatorv1alpha1
 // validateExternalSecretsConfig is for validating the ExternalSecretsConfig CR fields, apart from the
 // CEL validations present in CRD.
 func (r *Reconciler) validateExternalSecretsConfig(esc *operatorv1alpha1.ExternalSecretsConfig) error {
-	if isCertManagerConfigEnabled(esc) {
-		if _, ok := r.optionalResourcesList[certificateCRDGKV]; !ok {
-			return fmt.Errorf("spec.controllerConfig.certProvider.certManager.mode is set, but cert-manager is not installed")
-		}
+	return r.validateOptionalFeatures(esc).ToAggregate()
+}
+
+// validateOptionalFeatures verifies the optional features enabled in the ExternalSecretsConfig CR Spec have
+// the required resources installed on the cluster.
+func (r *Reconciler) validateOptionalFeatures(esc *operatorv1alpha1.ExternalSecretsConfig) field.ErrorList {
+	var errs field.ErrorList
+	controllerConfigPath := field.NewPath("spec", "controllerConfig")
+	if isCertManagerConfigEnabled(esc) && !r.IsCertManagerInstalled() {
+		errs = append(errs, field.Invalid(controllerConfigPath.Child("certProvider", "certManager", "mode"),
+			esc.Spec.ControllerConfig.CertProvider.CertManager.Mode, "cert-manager is not installed"))
 	}
-	return nil
+	if len(esc.Spec.ControllerConfig.CredentialsRequests) > 0 && !r.isCredentialsRequestsInstalled() {
+		errs = append(errs, field.Forbidden(controllerConfigPath.Child("credentialsRequests"),
+			"Cloud Credential Operator is not installed"))
+	}
+	return errs
 }
 
 // isCertManagerConfigEnabled returns whether CertManagerConfig is enabled in ExternalSecretsConfig CR Spec.
@@ -124,6 +138,21 @@ func (r *Reconciler) IsCertManagerInstalled() bool {
 	return ok
 }
 
+func (r *Reconciler) isCredentialsRequestsInstalled() bool {
+	_, ok := r.optionalResourcesList[credentialsRequestCRDGKV]
+	return ok
+}
+
+func (r *Reconciler) isServiceMonitorsInstalled() bool {
+	_, ok := r.optionalResourcesList[serviceMonitorCRDGKV]
+	return ok
+}
+
+func (r *Reconciler) isPrometheusRulesInstalled() bool {
+	_, ok := r.optionalResourcesList[prometheusRuleCRDGKV]
+	return ok
+}
+
 // getProxyConfiguration returns the proxy configuration based on precedence.
 // The precedence order is: ExternalSecretsConfig > ExternalSecretsManager > OLM environment variables.
 func (r *Reconciler) getProxyConfiguration(esc *operatorv1alpha1.ExternalSecretsConfig) *operatorv1alpha1.ProxyConfig {
@@ -16,6 +16,9 @@ func (r *Reconciler) createOrApplyValidatingWebhookConfiguration(esc *operatorv1
 	desiredWebhooks := r.getValidatingWebhookObjects(esc, resourceMetadata)
 
 	for _, desired := range desiredWebhooks {
+		if err := r.applyOverride(esc, desired); err != nil {
+			return err
+		}
 		validatingWebhookName := desired.GetName()
 		r.log.V(4).Info("reconciling validatingWebhook resource", "name", validatingWebhookName)
 		fetched := &webhook.ValidatingWebhookConfiguration{}
@@ -27,10 +30,10 @@ func (r *Reconciler) createOrApplyValidatingWebhookConfiguration(esc *operatorv1
 		if exist && recon {
 			r.eventRecorder.Eventf(esc, corev1.EventTypeWarning, "ResourceAlreadyExists", "%s validatingWebhook resource already exists, maybe from previous installation", validatingWebhookName)
 		}
-		if exist && common.HasObjectChanged(desired, fetched, &resourceMetadata) {
+		if exist && r.hasObjectDrifted(desired, fetched) {
 			r.log.V(1).Info("validatingWebhook has been modified", "updating to desired state", "name", validatingWebhookName)
 			common.RemoveObsoleteAnnotations(desired, resourceMetadata)
-			if err := r.UpdateWithRetry(r.ctx, desired); err != nil {
+			if err := r.Apply(r.ctx, desired); err != nil {
 				return common.FromClientError(err, "failed to update %s validatingWebhook resource with desired state", validatingWebhookName)
 			}
 			r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "validatingWebhook resource %s reconciled back to desired state", validatingWebhookName)
@@ -39,7 +42,7 @@ func (r *Reconciler) createOrApplyValidatingWebhookConfiguration(esc *operatorv1
 		}
 
 		if !exist {
-			if err := r.Create(r.ctx, desired); err != nil {
+			if err := r.Apply(r.ctx, desired); err != nil {
 				return common.FromClientError(err, "failed to create validatingWebhook resource %s", validatingWebhookName)
 			}
 			r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "validatingWebhook resource %s created", validatingWebhookName)
@@ -54,7 +57,7 @@ func (r *Reconciler) getValidatingWebhookObjects(esc *operatorv1alpha1.ExternalS
 
 	// Include cert-manager inject annotation in managed metadata so it's
 	// tracked by the managed-annotations key. This ensures toggling
-	// cert-manager on/off is detected by ObjectMetadataModified and
+	// cert-manager on/off is detected by the drift check and
 	// MergeFetchedAnnotations won't reintroduce a stale value.
 	for _, assetName := range assetNames {
 		validatingWebhook := common.DecodeValidatingWebhookConfigurationObjBytes(assets.MustAsset(assetName))
@@ -52,7 +52,7 @@ func TestCreateOrApplyValidatingWebhookConfiguration(t *testing.T) {
 		{
 			name: "validatingWebhookConfiguration reconciliation fails while updating to desired state",
 			preReq: func(r *Reconciler, m *fakes.FakeCtrlClient) {
-				m.UpdateWithRetryCalls(func(ctx context.Context, obj client.Object, option ...client.UpdateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, option ...client.ApplyOption) error {
 					if _, ok := obj.(*webhook.ValidatingWebhookConfiguration); ok {
 						return commontest.ErrTestClient
 					}
@@ -73,7 +73,7 @@ func TestCreateOrApplyValidatingWebhookConfiguration(t *testing.T) {
 		{
 			name: "validatingWebhookConfiguration reconciliation fails while creating",
 			preReq: func(r *Reconciler, m *fakes.FakeCtrlClient) {
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if _, ok := obj.(*webhook.ValidatingWebhookConfiguration); ok {
 						return commontest.ErrTestClient
 					}
@@ -88,7 +88,7 @@ func TestCreateOrApplyValidatingWebhookConfiguration(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					return nil
 				})
 			},
@@ -99,7 +99,7 @@ func TestCreateOrApplyValidatingWebhookConfiguration(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if vwc, ok := obj.(*webhook.ValidatingWebhookConfiguration); ok {
 						// Verify annotations are applied
 						if vwc.Annotations == nil {
@@ -127,7 +127,7 @@ func TestCreateOrApplyValidatingWebhookConfiguration(t *testing.T) {
 				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
 					return false, nil
 				})
-				m.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, opts ...client.ApplyOption) error {
 					if vwc, ok := obj.(*webhook.ValidatingWebhookConfiguration); ok {
 						// Verify all annotations from spec are present
 						if vwc.Annotations["custom-key"] != "custom-value" {
@@ -0,0 +1,209 @@
+package external_secrets
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"time"
+
+	webhook "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+	"github.com/openshift/external-secrets-operator/pkg/metrics"
+)
+
+const (
+	// webhookCheckCABundle, webhookCheckCertificate, webhookCheckTLSHandshake and webhookCheckAdmission are the
+	// names of the webhook verifications, used as the metrics label value for the failed check.
+	webhookCheckCABundle     = "cabundle"
+	webhookCheckCertificate  = "certificate"
+	webhookCheckTLSHandshake = "tls_handshake"
+	webhookCheckAdmission    = "admission"
+
+	// webhookCheckSecretStoreName is the name of the SecretStore created with a dry-run request for verifying
+	// the webhook serves the admission requests.
+	webhookCheckSecretStoreName = "external-secrets-operator-webhook-check"
+)
+
+// webhookTLSDialTimeout is the timeout for the TLS handshake with the webhook service.
+const webhookTLSDialTimeout = 5 * time.Second
+
+// dialWebhookTLS is for verifying the TLS handshake with the webhook service, replaceable in tests.
+var dialWebhookTLS = func(address string, config *tls.Config) error {
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: webhookTLSDialTimeout}, "tcp", address, config)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// webhookHealthError is the failure of one of the webhook verifications.
+type webhookHealthError struct {
+	check string
+	err   error
+}
+
+func (e *webhookHealthError) Error() string {
+	return e.err.Error()
+}
+
+// checkWebhookHealth verifies the external-secrets webhook is serving the admission requests, and updates the
+// WebhookHealthy condition and the webhook metrics with the result. Failed verifications are only reported,
+// an error is returned only when the status could not be updated.
+func (r *Reconciler) checkWebhookHealth(esc *operatorv1alpha1.ExternalSecretsConfig) error {
+	cond := metav1.Condition{
+		Type:               operatorv1alpha1.WebhookHealthy,
+		Status:             metav1.ConditionTrue,
+		Reason:             operatorv1alpha1.ReasonReady,
+		Message:            "webhook caBundle, serving certificate, TLS handshake and admission request verified",
+		ObservedGeneration: esc.GetGeneration(),
+	}
+
+	if err := r.verifyWebhook(esc); err != nil {
+		healthErr, ok := err.(*webhookHealthError)
+		if !ok {
+			return err
+		}
+		r.log.V(1).Info("external-secrets webhook verification failed", "check", healthErr.check, "reason", healthErr.Error())
+		metrics.WebhookHealthy.Set(0)
+		metrics.WebhookHealthCheckFailures.WithLabelValues(healthErr.check).Inc()
+
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = operatorv1alpha1.ReasonFailed
+		cond.Message = fmt.Sprintf("webhook verification failed: %v", healthErr)
+	} else {
+		metrics.WebhookHealthy.Set(1)
+	}
+
+	if apimeta.SetStatusCondition(&esc.Status.Conditions, cond) {
+		if err := r.updateStatus(r.ctx, esc); err != nil {
+			return common.FromClientError(err, "failed to update %s/%s status with webhook health", esc.GetNamespace(), esc.GetName())
+		}
+	}
+	return nil
+}
+
+// verifyWebhook verifies the caBundle of the ValidatingWebhookConfigurations is configured and is the CA of the
+// webhook serving certificate, the serving certificate is not close to expiry, the TLS handshake with the webhook
+// service succeeds with the caBundle, and the webhook serves an admission request. The verification failures are
+// returned as webhookHealthError.
+func (r *Reconciler) verifyWebhook(esc *operatorv1alpha1.ExternalSecretsConfig) error {
+	caBundles, err := r.getWebhookCABundles(esc)
+	if err != nil {
+		return err
+	}
+
+	servingCert, err := r.getWebhookServingCertificate(esc)
+	if err != nil {
+		return err
+	}
+
+	if remaining := time.Until(servingCert.NotAfter); remaining < webhookCertExpiryThreshold {
+		return &webhookHealthError{check: webhookCheckCertificate, err: fmt.Errorf("webhook serving certificate expires at %s", servingCert.NotAfter.UTC().Format(time.RFC3339))}
+	}
+
+	serverName := fmt.Sprintf(webhookServiceHostFmt, getNamespace(esc))
+	roots := x509.NewCertPool()
+	for _, name := range slices.Sorted(maps.Keys(caBundles)) {
+		pool := x509.NewCertPool()
+		if len(caBundles[name]) == 0 || !pool.AppendCertsFromPEM(caBundles[name]) {
+			return &webhookHealthError{check: webhookCheckCABundle, err: fmt.Errorf("caBundle not configured in %s validatingwebhookconfiguration", name)}
+		}
+		if _, err := servingCert.Verify(x509.VerifyOptions{Roots: pool, DNSName: serverName}); err != nil {
+			return &webhookHealthError{check: webhookCheckCABundle, err: fmt.Errorf("caBundle configured in %s validatingwebhookconfiguration does not match the webhook serving certificate: %w", name, err)}
+		}
+		roots.AppendCertsFromPEM(caBundles[name])
+	}
+
+	tlsConfig := &tls.Config{
+		RootCAs:    roots,
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if err := dialWebhookTLS(net.JoinHostPort(serverName, webhookServicePort), tlsConfig); err != nil {
+		return &webhookHealthError{check: webhookCheckTLSHandshake, err: fmt.Errorf("TLS handshake with webhook service failed: %w", err)}
+	}
+
+	return r.verifyWebhookAdmission(esc)
+}
+
+// verifyWebhookAdmission sends an admission request to the webhook with a dry-run create of a SecretStore, which
+// is validated by the webhook without being persisted. The request fails when the API server could not call the
+// webhook, or when the webhook rejects a valid SecretStore.
+func (r *Reconciler) verifyWebhookAdmission(esc *operatorv1alpha1.ExternalSecretsConfig) error {
+	store := &unstructured.Unstructured{}
+	store.SetGroupVersionKind(secretStoreGVK)
+	store.SetName(webhookCheckSecretStoreName)
+	store.SetNamespace(getNamespace(esc))
+	if err := unstructured.SetNestedSlice(store.Object, []any{}, "spec", "provider", "fake", "data"); err != nil {
+		return fmt.Errorf("failed to build %s/%s secretstore for webhook verification: %w", store.GetNamespace(), store.GetName(), err)
+	}
+
+	if err := r.UncachedClient.Create(r.ctx, store, client.DryRunAll); err != nil && !errors.IsAlreadyExists(err) {
+		return &webhookHealthError{check: webhookCheckAdmission, err: fmt.Errorf("admission request for %s secretstore failed: %w", secretStoreGVK.GroupVersion(), err)}
+	}
+	return nil
+}
+
+// getWebhookCABundles returns the caBundle of every webhook in the ValidatingWebhookConfigurations, keyed by the
+// name of the ValidatingWebhookConfiguration and the webhook.
+func (r *Reconciler) getWebhookCABundles(esc *operatorv1alpha1.ExternalSecretsConfig) (map[string][]byte, error) {
+	caBundles := make(map[string][]byte)
+	for _, desired := range r.getValidatingWebhookObjects(esc, common.ResourceMetadata{}) {
+		fetched := &webhook.ValidatingWebhookConfiguration{}
+		exist, err := r.Exists(r.ctx, client.ObjectKeyFromObject(desired), fetched)
+		if err != nil {
+			return nil, common.FromClientError(err, "failed to fetch %s validatingwebhookconfiguration", desired.GetName())
+		}
+		if !exist {
+			return nil, &webhookHealthError{check: webhookCheckCABundle, err: fmt.Errorf("%s validatingwebhookconfiguration not found", desired.GetName())}
+		}
+		for _, w := range fetched.Webhooks {
+			caBundles[fmt.Sprintf("%s/%s", fetched.GetName(), w.Name)] = w.ClientConfig.CABundle
+		}
+	}
+	return caBundles, nil
+}
+
+// getWebhookServingCertificate returns the webhook serving certificate from the secret mounted in the webhook
+// deployment, which is created either by the cert-controller or by cert-manager.
+func (r *Reconciler) getWebhookServingCertificate(esc *operatorv1alpha1.ExternalSecretsConfig) (*x509.Certificate, error) {
+	secretName := webhookTLSSecretName
+	if isCertManagerConfigEnabled(esc) {
+		secretName = certmanagerTLSSecretWebhook
+	}
+	key := types.NamespacedName{Name: secretName, Namespace: getNamespace(esc)}
+
+	secret := &corev1.Secret{}
+	exist, err := r.UncachedClient.Exists(r.ctx, key, secret)
+	if err != nil {
+		return nil, common.FromClientError(err, "failed to fetch %s webhook TLS secret", key)
+	}
+	if !exist || len(secret.Data[corev1.TLSCertKey]) == 0 {
+		return nil, &webhookHealthError{check: webhookCheckCertificate, err: fmt.Errorf("%s not found in %s secret", corev1.TLSCertKey, key)}
+	}
+
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		return nil, &webhookHealthError{check: webhookCheckCertificate, err: fmt.Errorf("%s in %s secret is not PEM encoded", corev1.TLSCertKey, key)}
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, &webhookHealthError{check: webhookCheckCertificate, err: fmt.Errorf("failed to parse %s in %s secret: %w", corev1.TLSCertKey, key, err)}
+	}
+	metrics.WebhookCertificateExpiryTimestamp.Set(float64(cert.NotAfter.Unix()))
+
+	return cert, nil
+}
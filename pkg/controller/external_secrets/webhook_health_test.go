@@ -0,0 +1,215 @@
+package external_secrets
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	webhook "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+// testWebhookCertificates returns a PEM encoded CA certificate and a webhook serving certificate signed by it,
+// valid until the given time.
+func testWebhookCertificates(t *testing.T, notAfter time.Time) ([]byte, []byte) {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate CA key: %v", err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "external-secrets"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("failed to create CA certificate: %v", err)
+	}
+
+	servingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate serving key: %v", err)
+	}
+	servingTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "external-secrets-webhook"},
+		DNSNames:     []string{"external-secrets-webhook.external-secrets.svc"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	servingDER, err := x509.CreateCertificate(rand.Reader, servingTemplate, caTemplate, &servingKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("failed to create serving certificate: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: servingDER})
+}
+
+func TestCheckWebhookHealth(t *testing.T) {
+	validCA, validCert := testWebhookCertificates(t, time.Now().Add(90*24*time.Hour))
+	otherCA, _ := testWebhookCertificates(t, time.Now().Add(90*24*time.Hour))
+	expiringCA, expiringCert := testWebhookCertificates(t, time.Now().Add(24*time.Hour))
+
+	tests := []struct {
+		name        string
+		caBundle    []byte
+		servingCert []byte
+		dialErr     error
+		createErr   error
+		wantStatus  metav1.ConditionStatus
+		wantMessage string
+	}{
+		{
+			name:        "webhook healthy",
+			caBundle:    validCA,
+			servingCert: validCert,
+			wantStatus:  metav1.ConditionTrue,
+			wantMessage: "webhook caBundle, serving certificate, TLS handshake and admission request verified",
+		},
+		{
+			name:        "caBundle not injected",
+			servingCert: validCert,
+			wantStatus:  metav1.ConditionFalse,
+			wantMessage: "webhook verification failed: caBundle not configured in externalsecret-validate/validate.externalsecret.external-secrets.io validatingwebhookconfiguration",
+		},
+		{
+			name:        "caBundle not matching serving certificate",
+			caBundle:    otherCA,
+			servingCert: validCert,
+			wantStatus:  metav1.ConditionFalse,
+		},
+		{
+			name:        "serving certificate not found",
+			caBundle:    validCA,
+			wantStatus:  metav1.ConditionFalse,
+			wantMessage: "webhook verification failed: tls.crt not found in external-secrets/external-secrets-webhook secret",
+		},
+		{
+			name:        "serving certificate close to expiry",
+			caBundle:    expiringCA,
+			servingCert: expiringCert,
+			wantStatus:  metav1.ConditionFalse,
+		},
+		{
+			name:        "TLS handshake fails",
+			caBundle:    validCA,
+			servingCert: validCert,
+			dialErr:     errors.New("connection refused"),
+			wantStatus:  metav1.ConditionFalse,
+			wantMessage: "webhook verification failed: TLS handshake with webhook service failed: connection refused",
+		},
+		{
+			name:        "admission request fails",
+			caBundle:    validCA,
+			servingCert: validCert,
+			createErr:   apierrors.NewInternalError(errors.New(`failed calling webhook "validate.secretstore.external-secrets.io"`)),
+			wantStatus:  metav1.ConditionFalse,
+			wantMessage: `webhook verification failed: admission request for external-secrets.io/v1 secretstore failed: Internal error occurred: failed calling webhook "validate.secretstore.external-secrets.io"`,
+		},
+		{
+			name:        "admission request for existing secretstore",
+			caBundle:    validCA,
+			servingCert: validCert,
+			createErr:   apierrors.NewAlreadyExists(schema.GroupResource{Group: "external-secrets.io", Resource: "secretstores"}, webhookCheckSecretStoreName),
+			wantStatus:  metav1.ConditionTrue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			mock := &fakes.FakeCtrlClient{}
+			r.CtrlClient = mock
+			r.UncachedClient = mock
+			mock.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+				switch o := obj.(type) {
+				case *webhook.ValidatingWebhookConfiguration:
+					assetName := validatingWebhookExternalSecretCRDAssetName
+					if ns.Name != "externalsecret-validate" {
+						assetName = validatingWebhookSecretStoreCRDAssetName
+					}
+					testValidatingWebhookConfiguration(assetName).DeepCopyInto(o)
+					for i := range o.Webhooks {
+						o.Webhooks[i].ClientConfig.CABundle = tt.caBundle
+					}
+					return true, nil
+				case *corev1.Secret:
+					if tt.servingCert == nil {
+						return false, nil
+					}
+					o.Data = map[string][]byte{corev1.TLSCertKey: tt.servingCert}
+					return true, nil
+				}
+				return false, nil
+			})
+
+			mock.CreateCalls(func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+				if dryRun := (&client.CreateOptions{}).ApplyOptions(opts).DryRun; len(dryRun) == 0 {
+					t.Errorf("unexpected create of %s without dry-run", obj.GetName())
+				}
+				if gvk := obj.GetObjectKind().GroupVersionKind(); gvk != secretStoreGVK || obj.GetNamespace() != "external-secrets" {
+					t.Errorf("unexpected admission request for %s %s/%s", gvk, obj.GetNamespace(), obj.GetName())
+				}
+				return tt.createErr
+			})
+
+			dialed := false
+			origDial := dialWebhookTLS
+			dialWebhookTLS = func(address string, config *tls.Config) error {
+				dialed = true
+				if address != "external-secrets-webhook.external-secrets.svc:443" {
+					t.Errorf("unexpected webhook address %s", address)
+				}
+				return tt.dialErr
+			}
+			defer func() { dialWebhookTLS = origDial }()
+
+			esc := commontest.TestExternalSecretsConfig()
+			if err := r.checkWebhookHealth(esc); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			cond := apimeta.FindStatusCondition(esc.Status.Conditions, operatorv1alpha1.WebhookHealthy)
+			if cond == nil {
+				t.Fatalf("expected %s condition, not found", operatorv1alpha1.WebhookHealthy)
+			}
+			if cond.Status != tt.wantStatus {
+				t.Errorf("condition status = %s, want %s: %s", cond.Status, tt.wantStatus, cond.Message)
+			}
+			if tt.wantMessage != "" && cond.Message != tt.wantMessage {
+				t.Errorf("condition message = %q, want %q", cond.Message, tt.wantMessage)
+			}
+			if wantDial := tt.wantStatus == metav1.ConditionTrue || tt.dialErr != nil || tt.createErr != nil; dialed != wantDial {
+				t.Errorf("TLS handshake attempted: %v, want %v", dialed, wantDial)
+			}
+		})
+	}
+}
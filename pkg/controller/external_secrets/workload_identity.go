@@ -0,0 +1,393 @@
+package external_secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+)
+
+// gcpCredentialConfig is the external account credential configuration read by the Google Cloud
+// client libraries for exchanging the projected ServiceAccount token.
+type gcpCredentialConfig struct {
+	Type                           string                    `json:"type"`
+	Audience                       string                    `json:"audience"`
+	SubjectTokenType               string                    `json:"subject_token_type"`
+	TokenURL                       string                    `json:"token_url"`
+	ServiceAccountImpersonationURL string                    `json:"service_account_impersonation_url"`
+	CredentialSource               gcpCredentialConfigSource `json:"credential_source"`
+}
+
+type gcpCredentialConfigSource struct {
+	File   string                          `json:"file"`
+	Format gcpCredentialConfigSourceFormat `json:"format"`
+}
+
+type gcpCredentialConfigSourceFormat struct {
+	Type string `json:"type"`
+}
+
+// getWorkloadIdentityConfig returns the workload identity configuration of the given component, or nil
+// when not configured. For the core controller, the configuration derived from the cluster is merged.
+func (r *Reconciler) getWorkloadIdentityConfig(esc *operatorv1alpha1.ExternalSecretsConfig, componentName operatorv1alpha1.ComponentName) *operatorv1alpha1.WorkloadIdentityConfig {
+	var wi *operatorv1alpha1.WorkloadIdentityConfig
+	for _, c := range esc.Spec.ControllerConfig.ComponentConfigs {
+		if c.ComponentName == componentName {
+			wi = c.WorkloadIdentity
+			break
+		}
+	}
+	if componentName == operatorv1alpha1.CoreController {
+		return mergeWorkloadIdentityConfig(r.clusterWorkloadIdentity, wi)
+	}
+	return wi
+}
+
+// getWorkloadIdentityServiceAccountAnnotations returns the annotations to be set on the component's ServiceAccount
+// for the configured cloud provider, with the user provided annotations merged.
+func getWorkloadIdentityServiceAccountAnnotations(wi *operatorv1alpha1.WorkloadIdentityConfig) map[string]string {
+	annotations := make(map[string]string)
+	if wi == nil {
+		return annotations
+	}
+
+	maps.Copy(annotations, wi.ServiceAccountAnnotations)
+	switch {
+	case wi.AWS != nil:
+		annotations[awsRoleARNAnnotation] = wi.AWS.RoleARN
+	case wi.Azure != nil:
+		annotations[azureClientIDAnnotation] = wi.Azure.ClientID
+		if wi.Azure.TenantID != "" {
+			annotations[azureTenantIDAnnotation] = wi.Azure.TenantID
+		}
+	case wi.GCP != nil:
+		annotations[gcpServiceAccountAnnotation] = wi.GCP.ServiceAccountEmail
+	}
+	return annotations
+}
+
+// updateServiceAccountWorkloadIdentity sets the workload identity annotations and labels on the desired ServiceAccount.
+// The keys of the annotations set are tracked on the ServiceAccount itself, and the returned ResourceMetadata has
+// the previously set keys which are no longer desired added to DeletedAnnotationKeys, so that they get removed.
+func updateServiceAccountWorkloadIdentity(desired, fetched *corev1.ServiceAccount, wi *operatorv1alpha1.WorkloadIdentityConfig, resourceMetadata common.ResourceMetadata) (common.ResourceMetadata, error) {
+	saMetadata := common.ResourceMetadata{
+		Labels:                resourceMetadata.Labels,
+		Annotations:           resourceMetadata.Annotations,
+		DeletedAnnotationKeys: slices.Clone(resourceMetadata.DeletedAnnotationKeys),
+	}
+
+	if wi != nil && len(wi.ServiceAccountLabels) > 0 {
+		labels := desired.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string, len(wi.ServiceAccountLabels))
+		}
+		for k, v := range wi.ServiceAccountLabels {
+			// operator managed labels take precedence.
+			if _, exist := labels[k]; !exist {
+				labels[k] = v
+			}
+		}
+		desired.SetLabels(labels)
+	}
+
+	wiAnnotations := getWorkloadIdentityServiceAccountAnnotations(wi)
+	if fetched != nil {
+		previousKeys, err := common.GetPreviouslyAppliedAnnotationKeys(fetched.GetAnnotations(), workloadIdentityManagedAnnotationsKey)
+		if err != nil {
+			return saMetadata, fmt.Errorf("failed to read workload identity annotation keys of serviceaccount %s/%s: %w", fetched.GetNamespace(), fetched.GetName(), err)
+		}
+		for _, k := range previousKeys {
+			// keys also configured through ControllerConfig.Annotations are tracked separately.
+			if _, ok := wiAnnotations[k]; !ok && desired.GetAnnotations()[k] == "" {
+				saMetadata.DeletedAnnotationKeys = append(saMetadata.DeletedAnnotationKeys, k)
+			}
+		}
+		if _, exist := fetched.GetAnnotations()[workloadIdentityManagedAnnotationsKey]; exist && len(wiAnnotations) == 0 {
+			saMetadata.DeletedAnnotationKeys = append(saMetadata.DeletedAnnotationKeys, workloadIdentityManagedAnnotationsKey)
+		}
+	}
+
+	if len(wiAnnotations) == 0 {
+		return saMetadata, nil
+	}
+
+	keys := slices.Sorted(maps.Keys(wiAnnotations))
+	trackingValue, err := common.EncodeDataToB64Json(keys)
+	if err != nil {
+		return saMetadata, fmt.Errorf("failed to encode workload identity annotation keys: %w", err)
+	}
+	wiAnnotations[workloadIdentityManagedAnnotationsKey] = trackingValue
+	common.UpdateResourceAnnotations(desired, wiAnnotations)
+
+	return saMetadata, nil
+}
+
+// updateWorkloadIdentityConfig adds the projected ServiceAccount token volume, the volume mount and the cloud provider
+// specific environment variables to the component container.
+func (r *Reconciler) updateWorkloadIdentityConfig(deployment *appsv1.Deployment, esc *operatorv1alpha1.ExternalSecretsConfig, assetName string) error {
+	componentName, containerName, err := getComponentNameFromAsset(assetName)
+	if err != nil {
+		return err
+	}
+
+	wi := r.getWorkloadIdentityConfig(esc, componentName)
+	if wi == nil {
+		return nil
+	}
+
+	// without a projected token, the workload identity webhook of the platform is expected to inject the
+	// token and the environment variables, which for Azure must be requested with a pod label.
+	if wi.ServiceAccountToken == nil {
+		if wi.Azure != nil {
+			updatePodTemplateLabels(deployment, map[string]string{azureWorkloadIdentityUseLabel: "true"})
+		}
+		return nil
+	}
+
+	mountPath := getWorkloadIdentityTokenMountPath(wi.ServiceAccountToken)
+	tokenFile := path.Join(mountPath, workloadIdentityTokenFileName)
+	expirationSeconds := wi.ServiceAccountToken.ExpirationSeconds
+	if expirationSeconds == 0 {
+		expirationSeconds = defaultWorkloadIdentityTokenExpirationSeconds
+	}
+
+	volume := corev1.Volume{
+		Name: workloadIdentityTokenVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          wi.ServiceAccountToken.Audience,
+							ExpirationSeconds: ptr.To(expirationSeconds),
+							Path:              workloadIdentityTokenFileName,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	var env []corev1.EnvVar
+	switch {
+	case wi.AWS != nil:
+		env = append(env,
+			corev1.EnvVar{Name: awsRoleARNEnvVar, Value: wi.AWS.RoleARN},
+			corev1.EnvVar{Name: awsWebIdentityTokenFileEnvVar, Value: tokenFile},
+		)
+		if wi.AWS.Region != "" {
+			env = append(env, corev1.EnvVar{Name: awsRegionEnvVar, Value: wi.AWS.Region})
+		}
+	case wi.Azure != nil:
+		env = append(env,
+			corev1.EnvVar{Name: azureClientIDEnvVar, Value: wi.Azure.ClientID},
+			corev1.EnvVar{Name: azureFederatedTokenFileEnvVar, Value: tokenFile},
+		)
+		if wi.Azure.TenantID != "" {
+			env = append(env, corev1.EnvVar{Name: azureTenantIDEnvVar, Value: wi.Azure.TenantID})
+		}
+	case wi.GCP != nil && wi.GCP.WorkloadIdentityProvider != "":
+		volume.Projected.Sources = append(volume.Projected.Sources, corev1.VolumeProjection{
+			ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: getGCPCredentialConfigMapName(deployment.GetName()),
+				},
+				Items: []corev1.KeyToPath{
+					{
+						Key:  gcpCredentialConfigFileName,
+						Path: gcpCredentialConfigFileName,
+					},
+				},
+			},
+		})
+		env = append(env, corev1.EnvVar{Name: gcpApplicationCredentialsEnvVar, Value: path.Join(mountPath, gcpCredentialConfigFileName)})
+	}
+
+	updateVolume(deployment, volume)
+	for i := range deployment.Spec.Template.Spec.Containers {
+		container := &deployment.Spec.Template.Spec.Containers[i]
+		if container.Name != containerName {
+			continue
+		}
+		updateVolumeMount(container, corev1.VolumeMount{
+			Name:      workloadIdentityTokenVolumeName,
+			MountPath: mountPath,
+			ReadOnly:  true,
+		})
+		mergeEnvVars(container, env)
+		break
+	}
+
+	return nil
+}
+
+// createOrApplyWorkloadIdentityConfigMaps ensures the ConfigMaps holding the Google Cloud external account
+// credential configuration exist for the components configured with a workload identity pool provider, and
+// removes the ones no longer required.
+func (r *Reconciler) createOrApplyWorkloadIdentityConfigMaps(esc *operatorv1alpha1.ExternalSecretsConfig, resourceMetadata common.ResourceMetadata) error {
+	components := []struct {
+		componentName  operatorv1alpha1.ComponentName
+		deploymentName string
+	}{
+		{
+			componentName:  operatorv1alpha1.CoreController,
+			deploymentName: controllerDeploymentName,
+		},
+		{
+			componentName:  operatorv1alpha1.Webhook,
+			deploymentName: webhookDeploymentName,
+		},
+		{
+			componentName:  operatorv1alpha1.CertController,
+			deploymentName: certControllerDeploymentName,
+		},
+		{
+			componentName:  operatorv1alpha1.BitwardenSDKServer,
+			deploymentName: bitwardenDeploymentName,
+		},
+	}
+
+	for _, c := range components {
+		desired, err := getGCPCredentialConfigMapObject(esc, r.getWorkloadIdentityConfig(esc, c.componentName), c.deploymentName, resourceMetadata)
+		if err != nil {
+			return err
+		}
+		if desired == nil {
+			if err := r.deleteGCPCredentialConfigMap(esc, c.deploymentName); err != nil {
+				return err
+			}
+			continue
+		}
+
+		configMapName := fmt.Sprintf("%s/%s", desired.GetNamespace(), desired.GetName())
+		r.log.V(4).Info("reconciling gcp credential configuration configmap resource", "name", configMapName)
+
+		fetched := &corev1.ConfigMap{}
+		exist, err := r.Exists(r.ctx, client.ObjectKeyFromObject(desired), fetched)
+		if err != nil {
+			return common.FromClientError(err, "failed to check %s configmap resource already exists", configMapName)
+		}
+
+		switch {
+		case exist && r.hasObjectDrifted(desired, fetched):
+			r.log.V(1).Info("configmap has been modified, updating to desired state", "name", configMapName)
+			common.RemoveObsoleteAnnotations(desired, resourceMetadata)
+			if err := r.Apply(r.ctx, desired); err != nil {
+				return common.FromClientError(err, "failed to update %s configmap resource", configMapName)
+			}
+			r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "configmap resource %s updated", configMapName)
+		case !exist:
+			if err := r.Apply(r.ctx, desired); err != nil {
+				return common.FromClientError(err, "failed to create %s configmap resource", configMapName)
+			}
+			r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "configmap resource %s created", configMapName)
+		default:
+			r.log.V(4).Info("configmap resource already exists and is in expected state", "name", configMapName)
+		}
+	}
+
+	return nil
+}
+
+// deleteGCPCredentialConfigMap removes the Google Cloud credential configuration ConfigMap of a component, if exists.
+func (r *Reconciler) deleteGCPCredentialConfigMap(esc *operatorv1alpha1.ExternalSecretsConfig, deploymentName string) error {
+	key := client.ObjectKey{
+		Name:      getGCPCredentialConfigMapName(deploymentName),
+		Namespace: getNamespace(esc),
+	}
+	fetched := &corev1.ConfigMap{}
+	exist, err := r.Exists(r.ctx, key, fetched)
+	if err != nil {
+		return common.FromClientError(err, "failed to check %s configmap resource already exists", key)
+	}
+	if !exist {
+		return nil
+	}
+	if err := r.Delete(r.ctx, fetched); err != nil {
+		return common.FromClientError(err, "failed to delete %s configmap resource", key)
+	}
+	r.eventRecorder.Eventf(esc, corev1.EventTypeNormal, "Reconciled", "configmap resource %s deleted", key)
+	return nil
+}
+
+// getGCPCredentialConfigMapObject returns the ConfigMap with the external account credential configuration for the
+// component, or nil when the component is not configured with a workload identity pool provider.
+func getGCPCredentialConfigMapObject(esc *operatorv1alpha1.ExternalSecretsConfig, wi *operatorv1alpha1.WorkloadIdentityConfig, deploymentName string, resourceMetadata common.ResourceMetadata) (*corev1.ConfigMap, error) {
+	if wi == nil || wi.GCP == nil || wi.GCP.WorkloadIdentityProvider == "" || wi.ServiceAccountToken == nil {
+		return nil, nil
+	}
+
+	credentialConfig := gcpCredentialConfig{
+		Type:                           "external_account",
+		Audience:                       wi.GCP.WorkloadIdentityProvider,
+		SubjectTokenType:               "urn:ietf:params:oauth:token-type:jwt",
+		TokenURL:                       "https://sts.googleapis.com/v1/token",
+		ServiceAccountImpersonationURL: fmt.Sprintf("https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/%s:generateAccessToken", wi.GCP.ServiceAccountEmail),
+		CredentialSource: gcpCredentialConfigSource{
+			File: path.Join(getWorkloadIdentityTokenMountPath(wi.ServiceAccountToken), workloadIdentityTokenFileName),
+			Format: gcpCredentialConfigSourceFormat{
+				Type: "text",
+			},
+		},
+	}
+	data, err := json.MarshalIndent(credentialConfig, "", "  ")
+	if err != nil {
+		return nil, common.NewIrrecoverableError(err, "failed to generate gcp credential configuration for %s", deploymentName)
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getGCPCredentialConfigMapName(deploymentName),
+			Namespace: getNamespace(esc),
+		},
+		Data: map[string]string{
+			gcpCredentialConfigFileName: string(data),
+		},
+	}
+	common.ApplyResourceMetadata(configMap, resourceMetadata)
+
+	return configMap, nil
+}
+
+func getGCPCredentialConfigMapName(deploymentName string) string {
+	return deploymentName + "-gcp-credentials"
+}
+
+func getWorkloadIdentityTokenMountPath(token *operatorv1alpha1.ProjectedServiceAccountToken) string {
+	if token.MountPath != "" {
+		return token.MountPath
+	}
+	return defaultWorkloadIdentityTokenMountPath
+}
+
+// updateVolume adds the volume to the pod spec, replacing the volume with the same name if exists.
+func updateVolume(deployment *appsv1.Deployment, volume corev1.Volume) {
+	for i := range deployment.Spec.Template.Spec.Volumes {
+		if deployment.Spec.Template.Spec.Volumes[i].Name == volume.Name {
+			deployment.Spec.Template.Spec.Volumes[i] = volume
+			return
+		}
+	}
+	deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, volume)
+}
+
+// updateVolumeMount adds the volume mount to the container, replacing the mount with the same name if exists.
+func updateVolumeMount(container *corev1.Container, volumeMount corev1.VolumeMount) {
+	for i := range container.VolumeMounts {
+		if container.VolumeMounts[i].Name == volumeMount.Name {
+			container.VolumeMounts[i] = volumeMount
+			return
+		}
+	}
+	container.VolumeMounts = append(container.VolumeMounts, volumeMount)
+}
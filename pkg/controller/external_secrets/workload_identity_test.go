@@ -0,0 +1,433 @@
+package external_secrets
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+	"github.com/openshift/external-secrets-operator/pkg/operator/assets"
+)
+
+const (
+	testRoleARN             = "arn:aws:iam::123456789012:role/external-secrets"
+	testAzureClientID       = "00000000-0000-0000-0000-000000000001"
+	testAzureTenantID       = "00000000-0000-0000-0000-000000000002"
+	testGCPServiceAccount   = "external-secrets@project.iam.gserviceaccount.com"
+	testGCPIdentityProvider = "//iam.googleapis.com/projects/123456/locations/global/workloadIdentityPools/pool/providers/provider"
+)
+
+// escWithWorkloadIdentity returns an ESC update function configuring workload identity for the given component.
+func escWithWorkloadIdentity(componentName operatorv1alpha1.ComponentName, wi *operatorv1alpha1.WorkloadIdentityConfig) func(*operatorv1alpha1.ExternalSecretsConfig) {
+	return func(esc *operatorv1alpha1.ExternalSecretsConfig) {
+		esc.Spec.ControllerConfig.ComponentConfigs = []operatorv1alpha1.ComponentConfig{
+			{
+				ComponentName:    componentName,
+				WorkloadIdentity: wi,
+			},
+		}
+	}
+}
+
+func TestUpdateWorkloadIdentityConfig(t *testing.T) {
+	tests := []struct {
+		name                        string
+		assetName                   string
+		updateExternalSecretsConfig func(*operatorv1alpha1.ExternalSecretsConfig)
+		wantEnv                     []corev1.EnvVar
+		wantVolumeSources           int
+		wantMountPath               string
+		wantPodLabels               map[string]string
+	}{
+		{
+			name:      "no workload identity configured",
+			assetName: controllerDeploymentAssetName,
+		},
+		{
+			name:      "workload identity configured for another component",
+			assetName: controllerDeploymentAssetName,
+			updateExternalSecretsConfig: escWithWorkloadIdentity(operatorv1alpha1.Webhook, &operatorv1alpha1.WorkloadIdentityConfig{
+				AWS:                 &operatorv1alpha1.AWSWorkloadIdentity{RoleARN: testRoleARN},
+				ServiceAccountToken: &operatorv1alpha1.ProjectedServiceAccountToken{Audience: "sts.amazonaws.com"},
+			}),
+		},
+		{
+			name:      "aws with projected token",
+			assetName: controllerDeploymentAssetName,
+			updateExternalSecretsConfig: escWithWorkloadIdentity(operatorv1alpha1.CoreController, &operatorv1alpha1.WorkloadIdentityConfig{
+				AWS:                 &operatorv1alpha1.AWSWorkloadIdentity{RoleARN: testRoleARN, Region: "us-east-1"},
+				ServiceAccountToken: &operatorv1alpha1.ProjectedServiceAccountToken{Audience: "sts.amazonaws.com"},
+			}),
+			wantEnv: []corev1.EnvVar{
+				{Name: awsRoleARNEnvVar, Value: testRoleARN},
+				{Name: awsWebIdentityTokenFileEnvVar, Value: "/var/run/secrets/openshift/serviceaccount/token"},
+				{Name: awsRegionEnvVar, Value: "us-east-1"},
+			},
+			wantVolumeSources: 1,
+			wantMountPath:     defaultWorkloadIdentityTokenMountPath,
+		},
+		{
+			name:      "azure with projected token and custom mount path",
+			assetName: webhookDeploymentAssetName,
+			updateExternalSecretsConfig: escWithWorkloadIdentity(operatorv1alpha1.Webhook, &operatorv1alpha1.WorkloadIdentityConfig{
+				Azure: &operatorv1alpha1.AzureWorkloadIdentity{ClientID: testAzureClientID, TenantID: testAzureTenantID},
+				ServiceAccountToken: &operatorv1alpha1.ProjectedServiceAccountToken{
+					Audience:  "api://AzureADTokenExchange",
+					MountPath: "/var/run/secrets/azure/tokens",
+				},
+			}),
+			wantEnv: []corev1.EnvVar{
+				{Name: azureClientIDEnvVar, Value: testAzureClientID},
+				{Name: azureFederatedTokenFileEnvVar, Value: "/var/run/secrets/azure/tokens/token"},
+				{Name: azureTenantIDEnvVar, Value: testAzureTenantID},
+			},
+			wantVolumeSources: 1,
+			wantMountPath:     "/var/run/secrets/azure/tokens",
+		},
+		{
+			name:      "azure without projected token requests webhook injection",
+			assetName: controllerDeploymentAssetName,
+			updateExternalSecretsConfig: escWithWorkloadIdentity(operatorv1alpha1.CoreController, &operatorv1alpha1.WorkloadIdentityConfig{
+				Azure: &operatorv1alpha1.AzureWorkloadIdentity{ClientID: testAzureClientID},
+			}),
+			wantPodLabels: map[string]string{azureWorkloadIdentityUseLabel: "true"},
+		},
+		{
+			name:      "gcp with workload identity provider",
+			assetName: controllerDeploymentAssetName,
+			updateExternalSecretsConfig: escWithWorkloadIdentity(operatorv1alpha1.CoreController, &operatorv1alpha1.WorkloadIdentityConfig{
+				GCP: &operatorv1alpha1.GCPWorkloadIdentity{
+					ServiceAccountEmail:      testGCPServiceAccount,
+					WorkloadIdentityProvider: testGCPIdentityProvider,
+				},
+				ServiceAccountToken: &operatorv1alpha1.ProjectedServiceAccountToken{Audience: "openshift"},
+			}),
+			wantEnv: []corev1.EnvVar{
+				{Name: gcpApplicationCredentialsEnvVar, Value: "/var/run/secrets/openshift/serviceaccount/credential-configuration.json"},
+			},
+			wantVolumeSources: 2,
+			wantMountPath:     defaultWorkloadIdentityTokenMountPath,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			esc := commontest.TestExternalSecretsConfig()
+			if tt.updateExternalSecretsConfig != nil {
+				tt.updateExternalSecretsConfig(esc)
+			}
+
+			deployment := common.DecodeDeploymentObjBytes(assets.MustAsset(tt.assetName))
+			if err := r.updateWorkloadIdentityConfig(deployment, esc, tt.assetName); err != nil {
+				t.Fatalf("updateWorkloadIdentityConfig() unexpected error: %v", err)
+			}
+			_, containerName, _ := getComponentNameFromAsset(tt.assetName)
+
+			var volume *corev1.Volume
+			for i := range deployment.Spec.Template.Spec.Volumes {
+				if deployment.Spec.Template.Spec.Volumes[i].Name == workloadIdentityTokenVolumeName {
+					volume = &deployment.Spec.Template.Spec.Volumes[i]
+				}
+			}
+			if tt.wantVolumeSources == 0 {
+				if volume != nil {
+					t.Errorf("unexpected workload identity volume %v", volume)
+				}
+			} else {
+				if volume == nil || volume.Projected == nil {
+					t.Fatalf("expected projected workload identity volume, got %v", volume)
+				}
+				if len(volume.Projected.Sources) != tt.wantVolumeSources {
+					t.Errorf("projected volume sources = %d, want %d", len(volume.Projected.Sources), tt.wantVolumeSources)
+				}
+				token := volume.Projected.Sources[0].ServiceAccountToken
+				if token == nil || token.ExpirationSeconds == nil || *token.ExpirationSeconds != defaultWorkloadIdentityTokenExpirationSeconds {
+					t.Errorf("unexpected serviceaccount token projection %v", token)
+				}
+			}
+
+			for _, container := range deployment.Spec.Template.Spec.Containers {
+				if container.Name != containerName {
+					continue
+				}
+				var mountPath string
+				for _, m := range container.VolumeMounts {
+					if m.Name == workloadIdentityTokenVolumeName {
+						mountPath = m.MountPath
+					}
+				}
+				if mountPath != tt.wantMountPath {
+					t.Errorf("token mount path = %q, want %q", mountPath, tt.wantMountPath)
+				}
+				for _, want := range tt.wantEnv {
+					found := false
+					for _, env := range container.Env {
+						if reflect.DeepEqual(env, want) {
+							found = true
+						}
+					}
+					if !found {
+						t.Errorf("expected env var %v in %v", want, container.Env)
+					}
+				}
+			}
+
+			for k, v := range tt.wantPodLabels {
+				if deployment.Spec.Template.Labels[k] != v {
+					t.Errorf("pod template label %q = %q, want %q", k, deployment.Spec.Template.Labels[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestOverrideEnvTakesPrecedenceOverWorkloadIdentityEnv(t *testing.T) {
+	r := testReconciler(t)
+	esc := commontest.TestExternalSecretsConfig()
+	esc.Spec.ControllerConfig.ComponentConfigs = []operatorv1alpha1.ComponentConfig{
+		{
+			ComponentName: operatorv1alpha1.CoreController,
+			OverrideEnv: []corev1.EnvVar{
+				{Name: awsRegionEnvVar, Value: "eu-west-1"},
+			},
+			WorkloadIdentity: &operatorv1alpha1.WorkloadIdentityConfig{
+				AWS:                 &operatorv1alpha1.AWSWorkloadIdentity{RoleARN: testRoleARN, Region: "us-east-1"},
+				ServiceAccountToken: &operatorv1alpha1.ProjectedServiceAccountToken{Audience: "sts.amazonaws.com"},
+			},
+		},
+	}
+	t.Setenv(externalsecretsImageEnvVarName, commontest.TestExternalSecretsImageName)
+	t.Setenv(bitwardenImageEnvVarName, commontest.TestBitwardenImageName)
+
+	deployment, err := r.getDeploymentObject(controllerDeploymentAssetName, esc, testResourceMetadata(esc))
+	if err != nil {
+		t.Fatalf("getDeploymentObject() unexpected error: %v", err)
+	}
+	for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == awsRegionEnvVar && env.Value != "eu-west-1" {
+			t.Errorf("%s = %q, want overridden value %q", awsRegionEnvVar, env.Value, "eu-west-1")
+		}
+	}
+}
+
+func TestUpdateServiceAccountWorkloadIdentity(t *testing.T) {
+	previousKeys, err := common.EncodeDataToB64Json([]string{awsRoleARNAnnotation, "custom/annotation"})
+	if err != nil {
+		t.Fatalf("failed to encode keys: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		wi              *operatorv1alpha1.WorkloadIdentityConfig
+		fetched         *corev1.ServiceAccount
+		wantAnnotations map[string]string
+		wantLabels      map[string]string
+		wantDeletedKeys []string
+	}{
+		{
+			name:            "no workload identity and serviceaccount not yet created",
+			wantAnnotations: map[string]string{},
+		},
+		{
+			name: "aws annotation with user annotations and labels",
+			wi: &operatorv1alpha1.WorkloadIdentityConfig{
+				AWS:                       &operatorv1alpha1.AWSWorkloadIdentity{RoleARN: testRoleARN},
+				ServiceAccountAnnotations: map[string]string{"custom/annotation": "value"},
+				ServiceAccountLabels:      map[string]string{"team": "platform", "app": "not-allowed"},
+			},
+			wantAnnotations: map[string]string{
+				awsRoleARNAnnotation: testRoleARN,
+				"custom/annotation":  "value",
+			},
+			wantLabels: map[string]string{"team": "platform", "app": externalsecretsCommonName},
+		},
+		{
+			name: "azure and gcp annotations",
+			wi: &operatorv1alpha1.WorkloadIdentityConfig{
+				Azure: &operatorv1alpha1.AzureWorkloadIdentity{ClientID: testAzureClientID, TenantID: testAzureTenantID},
+			},
+			wantAnnotations: map[string]string{
+				azureClientIDAnnotation: testAzureClientID,
+				azureTenantIDAnnotation: testAzureTenantID,
+			},
+		},
+		{
+			name: "previously set annotations no longer desired are removed",
+			wi: &operatorv1alpha1.WorkloadIdentityConfig{
+				GCP: &operatorv1alpha1.GCPWorkloadIdentity{ServiceAccountEmail: testGCPServiceAccount},
+			},
+			fetched: &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						awsRoleARNAnnotation:                  testRoleARN,
+						"custom/annotation":                   "value",
+						workloadIdentityManagedAnnotationsKey: previousKeys,
+					},
+				},
+			},
+			wantAnnotations: map[string]string{gcpServiceAccountAnnotation: testGCPServiceAccount},
+			wantDeletedKeys: []string{awsRoleARNAnnotation, "custom/annotation"},
+		},
+		{
+			name: "tracking annotation removed when workload identity is removed",
+			fetched: &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						awsRoleARNAnnotation:                  testRoleARN,
+						workloadIdentityManagedAnnotationsKey: previousKeys,
+					},
+				},
+			},
+			wantAnnotations: map[string]string{},
+			wantDeletedKeys: []string{awsRoleARNAnnotation, "custom/annotation", workloadIdentityManagedAnnotationsKey},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			esc := commontest.TestExternalSecretsConfig()
+			resourceMetadata := testResourceMetadata(esc)
+			desired := testServiceAccount(controllerServiceAccountAssetName)
+			common.ApplyResourceMetadata(desired, resourceMetadata)
+
+			saMetadata, err := updateServiceAccountWorkloadIdentity(desired, tt.fetched, tt.wi, resourceMetadata)
+			if err != nil {
+				t.Fatalf("updateServiceAccountWorkloadIdentity() unexpected error: %v", err)
+			}
+
+			for k, v := range tt.wantAnnotations {
+				if desired.Annotations[k] != v {
+					t.Errorf("annotation %q = %q, want %q", k, desired.Annotations[k], v)
+				}
+			}
+			if len(tt.wantAnnotations) > 0 {
+				keys, err := common.GetPreviouslyAppliedAnnotationKeys(desired.Annotations, workloadIdentityManagedAnnotationsKey)
+				if err != nil {
+					t.Fatalf("failed to read tracked keys: %v", err)
+				}
+				if len(keys) != len(tt.wantAnnotations) {
+					t.Errorf("tracked keys = %v, want keys of %v", keys, tt.wantAnnotations)
+				}
+			} else if _, exist := desired.Annotations[workloadIdentityManagedAnnotationsKey]; exist {
+				t.Errorf("tracking annotation should not be set when no workload identity annotations are desired")
+			}
+			for k, v := range tt.wantLabels {
+				if desired.Labels[k] != v {
+					t.Errorf("label %q = %q, want %q", k, desired.Labels[k], v)
+				}
+			}
+			if !reflect.DeepEqual(saMetadata.DeletedAnnotationKeys, append([]string{}, tt.wantDeletedKeys...)) {
+				t.Errorf("DeletedAnnotationKeys = %v, want %v", saMetadata.DeletedAnnotationKeys, tt.wantDeletedKeys)
+			}
+		})
+	}
+}
+
+func TestCreateOrApplyWorkloadIdentityConfigMaps(t *testing.T) {
+	tests := []struct {
+		name                        string
+		preReq                      func(*Reconciler, *fakes.FakeCtrlClient)
+		updateExternalSecretsConfig func(*operatorv1alpha1.ExternalSecretsConfig)
+		wantApply                   int
+		wantDelete                  int
+		wantErr                     string
+	}{
+		{
+			name: "no configmaps required and none exist",
+			preReq: func(r *Reconciler, m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(doesNotExist())
+			},
+		},
+		{
+			name: "stale configmap deleted",
+			preReq: func(r *Reconciler, m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+					return ns.Name == "external-secrets-gcp-credentials", nil
+				})
+			},
+			wantDelete: 1,
+		},
+		{
+			name: "configmap created for gcp workload identity provider",
+			preReq: func(r *Reconciler, m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(doesNotExist())
+				m.ApplyCalls(func(ctx context.Context, obj client.Object, _ ...client.ApplyOption) error {
+					cm, ok := obj.(*corev1.ConfigMap)
+					if !ok || cm.GetName() != "external-secrets-gcp-credentials" {
+						t.Errorf("unexpected object created %T %s", obj, obj.GetName())
+						return nil
+					}
+					config := gcpCredentialConfig{}
+					if err := json.Unmarshal([]byte(cm.Data[gcpCredentialConfigFileName]), &config); err != nil {
+						t.Errorf("invalid credential configuration: %v", err)
+					}
+					if config.Audience != testGCPIdentityProvider ||
+						config.CredentialSource.File != "/var/run/secrets/openshift/serviceaccount/token" {
+						t.Errorf("unexpected credential configuration %+v", config)
+					}
+					return nil
+				})
+			},
+			updateExternalSecretsConfig: escWithWorkloadIdentity(operatorv1alpha1.CoreController, &operatorv1alpha1.WorkloadIdentityConfig{
+				GCP: &operatorv1alpha1.GCPWorkloadIdentity{
+					ServiceAccountEmail:      testGCPServiceAccount,
+					WorkloadIdentityProvider: testGCPIdentityProvider,
+				},
+				ServiceAccountToken: &operatorv1alpha1.ProjectedServiceAccountToken{Audience: "openshift"},
+			}),
+			wantApply: 1,
+		},
+		{
+			name: "configmap deletion fails",
+			preReq: func(r *Reconciler, m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+					return true, nil
+				})
+				m.DeleteReturns(errTest)
+			},
+			wantDelete: 1,
+			wantErr:    "failed to delete external-secrets/external-secrets-gcp-credentials configmap resource: test client error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			mock := &fakes.FakeCtrlClient{}
+			r.CtrlClient = mock
+			if tt.preReq != nil {
+				tt.preReq(r, mock)
+			}
+
+			esc := commontest.TestExternalSecretsConfig()
+			if tt.updateExternalSecretsConfig != nil {
+				tt.updateExternalSecretsConfig(esc)
+			}
+
+			err := r.createOrApplyWorkloadIdentityConfigMaps(esc, testResourceMetadata(esc))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Expected error: %v, got: %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if mock.ApplyCallCount() != tt.wantApply {
+				t.Errorf("Apply called %d times, want %d", mock.ApplyCallCount(), tt.wantApply)
+			}
+			if mock.DeleteCallCount() != tt.wantDelete {
+				t.Errorf("Delete called %d times, want %d", mock.DeleteCallCount(), tt.wantDelete)
+			}
+		})
+	}
+}
@@ -0,0 +1,359 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret_store_template
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/go-logr/logr"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	operatorclient "github.com/openshift/external-secrets-operator/pkg/controller/client"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+	"github.com/openshift/external-secrets-operator/pkg/metrics"
+)
+
+const (
+	ControllerName = "secret-store-template"
+
+	// finalizer name for secretstoretemplates.operator.openshift.io resource.
+	finalizer = "secretstoretemplates.operator.openshift.io/" + ControllerName
+
+	// templateLabelKey is the label key name added to the resources created from a SecretStoreTemplate, with the
+	// name of the SecretStoreTemplate as value, used for filtering reconcile events and for cleaning up the resources.
+	templateLabelKey = "secretstoretemplates.operator.openshift.io/name"
+
+	// namespaceVariable is the variable in the templated values replaced with the name of the namespace.
+	namespaceVariable = "$(NAMESPACE)"
+)
+
+var (
+	// secretStoreGVK is the group/version/kind of the SecretStore resource.
+	secretStoreGVK = schema.GroupVersionKind{Group: "external-secrets.io", Version: "v1", Kind: "SecretStore"}
+
+	// resourceLabels are the labels added to all the resources created from a SecretStoreTemplate.
+	resourceLabels = map[string]string{
+		"app.kubernetes.io/managed-by": "external-secrets-operator",
+	}
+
+	// bindableClusterRoles are the ClusterRoles a SecretStoreTemplate can bind to the ServiceAccount, which the
+	// operator is granted the bind permission for. Must be kept in sync with the RBAC marker of the Reconciler.
+	bindableClusterRoles = []string{"system:auth-delegator"}
+
+	// systemNamespacePrefixes are the prefixes of the namespaces reserved for the platform components, which are
+	// never selected by a SecretStoreTemplate.
+	systemNamespacePrefixes = []string{"openshift-", "kube-"}
+)
+
+// Reconciler reconciles secretstoretemplates.operator.openshift.io CR.
+type Reconciler struct {
+	operatorclient.CtrlClient
+
+	UncachedClient operatorclient.CtrlClient
+	Scheme         *runtime.Scheme
+	cache          cache.Cache
+	eventRecorder  record.EventRecorder
+	log            logr.Logger
+}
+
+// +kubebuilder:rbac:groups=operator.openshift.io,resources=secretstoretemplates,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=operator.openshift.io,resources=secretstoretemplates/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operator.openshift.io,resources=secretstoretemplates/finalizers,verbs=update
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind,resourceNames="system:auth-delegator"
+
+// New is for building the reconciler instance consumed by the Reconcile method.
+func New(ctx context.Context, mgr ctrl.Manager) (*Reconciler, error) {
+	r := &Reconciler{
+		Scheme:        mgr.GetScheme(),
+		eventRecorder: mgr.GetEventRecorderFor(ControllerName),
+		log:           ctrl.Log.WithName(ControllerName),
+	}
+	c, customCache, err := BuildCustomClient(ctx, mgr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build custom client: %w", err)
+	}
+	r.CtrlClient = &operatorclient.CtrlClientImpl{
+		Client: c,
+	}
+	r.cache = customCache
+
+	// the cache holds only the resources created from the templates, the uncached client is used for
+	// checking whether a resource of the same name is created by the users.
+	uc, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create uncached client: %w", err)
+	}
+	r.UncachedClient = &operatorclient.CtrlClientImpl{
+		Client: uc,
+	}
+	return r, nil
+}
+
+// managedResources returns the empty objects of the resources created from a SecretStoreTemplate.
+func managedResources() []client.Object {
+	secretStore := &unstructured.Unstructured{}
+	secretStore.SetGroupVersionKind(secretStoreGVK)
+	return []client.Object{
+		&corev1.ServiceAccount{},
+		&rbacv1.RoleBinding{},
+		secretStore,
+	}
+}
+
+// BuildCustomClient creates a custom client with a custom cache of required objects.
+// All namespaces are cached for evaluating the namespace selectors, and the resources
+// created by the controller are cached based on the template label.
+func BuildCustomClient(ctx context.Context, mgr ctrl.Manager) (client.Client, cache.Cache, error) {
+	templateLabelReq, _ := labels.NewRequirement(templateLabelKey, selection.Exists, nil)
+	templateLabelReqSelector := labels.NewSelector().Add(*templateLabelReq)
+
+	objectList := map[client.Object]cache.ByObject{
+		&operatorv1alpha1.SecretStoreTemplate{}: {},
+		&corev1.Namespace{}:                     {},
+	}
+	for _, res := range managedResources() {
+		objectList[res] = cache.ByObject{
+			Label: templateLabelReqSelector,
+		}
+	}
+
+	customCacheOpts := cache.Options{
+		HTTPClient:                  mgr.GetHTTPClient(),
+		Scheme:                      mgr.GetScheme(),
+		Mapper:                      mgr.GetRESTMapper(),
+		ByObject:                    objectList,
+		ReaderFailOnMissingInformer: true,
+	}
+	customCache, err := cache.New(mgr.GetConfig(), customCacheOpts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build custom cache: %w", err)
+	}
+	for obj := range objectList {
+		if _, err = customCache.GetInformer(ctx, obj); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err = mgr.Add(customCache); err != nil {
+		return nil, nil, err
+	}
+
+	customClient, err := client.New(mgr.GetConfig(), client.Options{
+		HTTPClient: mgr.GetHTTPClient(),
+		Scheme:     mgr.GetScheme(),
+		Mapper:     mgr.GetRESTMapper(),
+		Cache: &client.CacheOptions{
+			Reader:       customCache,
+			Unstructured: true,
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return customClient, customCache, nil
+}
+
+// SetupWithManager is for creating a controller instance with predicates and event filters.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// namespace label changes can change the namespaces selected by any of the templates.
+	namespaceMapFunc := func(ctx context.Context, ns *corev1.Namespace) []reconcile.Request {
+		r.log.V(4).Info("received namespace event", "name", ns.GetName())
+
+		templates := &operatorv1alpha1.SecretStoreTemplateList{}
+		if err := r.List(ctx, templates); err != nil {
+			r.log.Error(err, "failed to list secretstoretemplates.operator.openshift.io for namespace event", "name", ns.GetName())
+			return []reconcile.Request{}
+		}
+		requests := make([]reconcile.Request, 0, len(templates.Items))
+		for _, t := range templates.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: t.GetName()}})
+		}
+		return requests
+	}
+
+	mapFunc := func(ctx context.Context, obj client.Object) []reconcile.Request {
+		r.log.V(4).Info("received reconcile event", "object", fmt.Sprintf("%T", obj), "name", obj.GetName(), "namespace", obj.GetNamespace())
+
+		if name := obj.GetLabels()[templateLabelKey]; name != "" {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name}}}
+		}
+		r.log.V(4).Info("object not of interest, ignoring reconcile event", "object", fmt.Sprintf("%T", obj), "name", obj.GetName(), "namespace", obj.GetNamespace())
+		return []reconcile.Request{}
+	}
+
+	mgrBuilder := ctrl.NewControllerManagedBy(mgr).
+		Named(ControllerName).
+		WatchesRawSource(source.Kind(r.cache, &operatorv1alpha1.SecretStoreTemplate{},
+			&handler.TypedEnqueueRequestForObject[*operatorv1alpha1.SecretStoreTemplate]{},
+			predicate.TypedGenerationChangedPredicate[*operatorv1alpha1.SecretStoreTemplate]{})).
+		WatchesRawSource(source.Kind(r.cache, &corev1.Namespace{},
+			handler.TypedEnqueueRequestsFromMapFunc(namespaceMapFunc),
+			predicate.TypedLabelChangedPredicate[*corev1.Namespace]{}))
+
+	for _, res := range managedResources() {
+		mgrBuilder.WatchesRawSource(source.Kind(r.cache, res, handler.EnqueueRequestsFromMapFunc(mapFunc)))
+	}
+
+	return mgrBuilder.Complete(r)
+}
+
+// Reconcile is the reconciliation loop to manage the resources created from the secretstoretemplates CR.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.log.V(1).Info("reconciling", "request", req)
+
+	sst := &operatorv1alpha1.SecretStoreTemplate{}
+	if err := r.Get(ctx, req.NamespacedName, sst); err != nil {
+		if errors.IsNotFound(err) {
+			// NotFound errors, would mean the object has been deleted after the
+			// resources were cleaned up, and is not required to be reconciled.
+			r.log.V(1).Info("secretstoretemplates.operator.openshift.io object not found, skipping reconciliation", "key", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to fetch secretstoretemplates.operator.openshift.io %q during reconciliation: %w", req.NamespacedName, err)
+	}
+
+	if !sst.DeletionTimestamp.IsZero() {
+		r.log.V(1).Info("secretstoretemplates.operator.openshift.io is marked for deletion", "key", req.NamespacedName)
+
+		if err := r.cleanUp(ctx, sst, nil); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to clean up resources created from %q: %w", req.NamespacedName, err)
+		}
+		if err := common.RemoveFinalizer(ctx, sst, r.CtrlClient, finalizer); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		r.log.V(1).Info("removed finalizer, cleanup complete", "key", req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	if err := common.AddFinalizer(ctx, sst, r.CtrlClient, finalizer); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update %q secretstoretemplates.operator.openshift.io with finalizers: %w", req.NamespacedName, err)
+	}
+
+	return r.processReconcileRequest(ctx, sst)
+}
+
+// processReconcileRequest is the reconciliation handler to manage the resources.
+func (r *Reconciler) processReconcileRequest(ctx context.Context, sst *operatorv1alpha1.SecretStoreTemplate) (ctrl.Result, error) {
+	namespaces, conflicts, err := r.reconcileTemplate(ctx, sst)
+	if err != nil {
+		r.log.Error(err, "failed to reconcile secretstoretemplate", "name", sst.GetName())
+		if common.IsIrrecoverableError(err) {
+			metrics.ReconcileErrors.WithLabelValues(ControllerName, metrics.ErrorTypeIrrecoverable).Inc()
+		} else {
+			metrics.ReconcileErrors.WithLabelValues(ControllerName, metrics.ErrorTypeRetryable).Inc()
+		}
+	}
+
+	if uErr := r.updateCondition(ctx, sst, namespaces, conflicts, err); uErr != nil {
+		return ctrl.Result{}, utilerrors.NewAggregate([]error{uErr, err})
+	}
+
+	// the conflicting resources are not watched, and are checked again
+	// for creating the resources once removed by the users.
+	if err == nil && len(conflicts) > 0 {
+		return ctrl.Result{RequeueAfter: common.DefaultRequeueTime}, nil
+	}
+
+	// irrecoverable errors require the template to be corrected, which would
+	// generate a new event and hence not required to be retried.
+	if common.IsIrrecoverableError(err) {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{}, err
+}
+
+func (r *Reconciler) updateCondition(ctx context.Context, sst *operatorv1alpha1.SecretStoreTemplate, namespaces, conflicts []string, err error) error {
+	cond := metav1.Condition{
+		Type:               operatorv1alpha1.Ready,
+		ObservedGeneration: sst.GetGeneration(),
+	}
+
+	statusUpdated := false
+	if err != nil {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = operatorv1alpha1.ReasonFailed
+		cond.Message = fmt.Sprintf("failed to create resources: %v", err)
+	} else {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = operatorv1alpha1.ReasonReady
+		cond.Message = fmt.Sprintf("resources created in %d namespaces", len(namespaces))
+		if len(conflicts) > 0 {
+			cond.Status = metav1.ConditionFalse
+			cond.Reason = operatorv1alpha1.ReasonFailed
+			cond.Message = conflictsMessage(conflicts)
+		}
+		if !slices.Equal(sst.Status.Namespaces, namespaces) {
+			sst.Status.Namespaces = namespaces
+			statusUpdated = true
+		}
+	}
+
+	if apimeta.SetStatusCondition(&sst.Status.Conditions, cond) || statusUpdated {
+		return r.updateStatus(ctx, sst)
+	}
+
+	return nil
+}
+
+// updateStatus is for updating the status subresource of secretstoretemplates.operator.openshift.io.
+func (r *Reconciler) updateStatus(ctx context.Context, changed *operatorv1alpha1.SecretStoreTemplate) error {
+	namespacedName := client.ObjectKeyFromObject(changed)
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		r.log.V(4).Info("updating secretstoretemplates.operator.openshift.io status", "request", namespacedName)
+		current := &operatorv1alpha1.SecretStoreTemplate{}
+		if err := r.Get(ctx, namespacedName, current); err != nil {
+			return fmt.Errorf("failed to fetch secretstoretemplates.operator.openshift.io %q for status update: %w", namespacedName, err)
+		}
+		changed.Status.DeepCopyInto(&current.Status)
+
+		if err := r.StatusUpdate(ctx, current); err != nil {
+			return fmt.Errorf("failed to update secretstoretemplates.operator.openshift.io %q status: %w", namespacedName, err)
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
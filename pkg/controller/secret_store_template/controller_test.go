@@ -0,0 +1,441 @@
+package secret_store_template
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-logr/logr/testr"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	operatorclient "github.com/openshift/external-secrets-operator/pkg/controller/client"
+	"github.com/openshift/external-secrets-operator/pkg/controller/client/fakes"
+	"github.com/openshift/external-secrets-operator/pkg/controller/commontest"
+)
+
+const (
+	testTemplateName = "vault-tenants"
+	testNamespace    = "team-a"
+)
+
+// testReconciler returns a sample Reconciler instance.
+func testReconciler(t *testing.T) *Reconciler {
+	return &Reconciler{
+		Scheme:        runtime.NewScheme(),
+		eventRecorder: record.NewFakeRecorder(100),
+		log:           testr.New(t),
+	}
+}
+
+// testApplied returns the object with the managed fields recorded by the API server when the object is applied by
+// the operator with server-side apply.
+func testApplied(obj client.Object) client.Object {
+	content, err := operatorclient.ApplyConfiguration(obj, nil)
+	if err != nil {
+		panic(err)
+	}
+	entry, err := operatorclient.AppliedManagedFieldsEntry(content)
+	if err != nil {
+		panic(err)
+	}
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{entry})
+	return obj
+}
+
+// testSecretStoreTemplate returns a sample SecretStoreTemplate object.
+func testSecretStoreTemplate() *operatorv1alpha1.SecretStoreTemplate {
+	return &operatorv1alpha1.SecretStoreTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testTemplateName,
+			Finalizers: []string{finalizer},
+		},
+		Spec: operatorv1alpha1.SecretStoreTemplateSpec{
+			NamespaceSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"secrets.example.com/tenant": "true"},
+			},
+			ServiceAccount: &operatorv1alpha1.SecretStoreTemplateServiceAccount{
+				Name: "vault-auth",
+			},
+			RoleBinding: &operatorv1alpha1.SecretStoreTemplateRoleBinding{
+				Name: "vault-auth-token-reviewer",
+				RoleRef: operatorv1alpha1.SecretStoreTemplateRoleRef{
+					Kind: "ClusterRole",
+					Name: "system:auth-delegator",
+				},
+			},
+			SecretStore: operatorv1alpha1.SecretStoreTemplateSecretStore{
+				Name: "vault",
+				Spec: runtime.RawExtension{
+					Raw: []byte(`{"provider":{"vault":{"server":"https://vault.example.com","path":"$(NAMESPACE)","auth":{"kubernetes":{"role":"$(NAMESPACE)","serviceAccountRef":{"name":"vault-auth"}}}}}}`),
+				},
+			},
+		},
+	}
+}
+
+// testNamespaceList returns a NamespaceList with the given namespace names.
+func testNamespaceList(names ...string) corev1.NamespaceList {
+	list := corev1.NamespaceList{}
+	for _, n := range names {
+		list.Items = append(list.Items, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: n}})
+	}
+	return list
+}
+
+func TestGetTemplateObjects(t *testing.T) {
+	objects, err := getTemplateObjects(testSecretStoreTemplate(), testNamespace)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(objects) != 3 {
+		t.Fatalf("expected 3 objects, got %d", len(objects))
+	}
+
+	for _, obj := range objects {
+		if obj.GetNamespace() != testNamespace {
+			t.Errorf("%T created in namespace %q, want %q", obj, obj.GetNamespace(), testNamespace)
+		}
+		if obj.GetLabels()[templateLabelKey] != testTemplateName {
+			t.Errorf("%T missing template label, got %v", obj, obj.GetLabels())
+		}
+	}
+
+	rb, ok := objects[1].(*rbacv1.RoleBinding)
+	if !ok {
+		t.Fatalf("expected RoleBinding, got %T", objects[1])
+	}
+	wantSubjects := []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "vault-auth", Namespace: testNamespace}}
+	if !reflect.DeepEqual(rb.Subjects, wantSubjects) {
+		t.Errorf("rolebinding subjects = %v, want %v", rb.Subjects, wantSubjects)
+	}
+
+	ss, ok := objects[2].(*unstructured.Unstructured)
+	if !ok {
+		t.Fatalf("expected unstructured SecretStore, got %T", objects[2])
+	}
+	path, _, _ := unstructured.NestedString(ss.Object, "spec", "provider", "vault", "path")
+	role, _, _ := unstructured.NestedString(ss.Object, "spec", "provider", "vault", "auth", "kubernetes", "role")
+	if path != testNamespace || role != testNamespace {
+		t.Errorf("namespace variable not expanded, path: %q, role: %q", path, role)
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	tests := []struct {
+		name           string
+		template       func() *operatorv1alpha1.SecretStoreTemplate
+		preReq         func(*fakes.FakeCtrlClient)
+		uncachedPreReq func(*fakes.FakeCtrlClient)
+		wantApply      int
+		wantUpdate     int
+		wantDelete     int
+		wantCondition  *metav1.Condition
+		wantNamespaces []string
+		wantRequeue    bool
+		wantErr        string
+	}{
+		{
+			name:     "resources created in matching namespace",
+			template: testSecretStoreTemplate,
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ListCalls(func(ctx context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					if l, ok := list.(*corev1.NamespaceList); ok {
+						*l = testNamespaceList(testNamespace)
+					}
+					return nil
+				})
+			},
+			wantApply:      3,
+			wantCondition:  &metav1.Condition{Type: operatorv1alpha1.Ready, Status: metav1.ConditionTrue, Reason: operatorv1alpha1.ReasonReady},
+			wantNamespaces: []string{testNamespace},
+		},
+		{
+			name:     "resources in desired state are not updated",
+			template: testSecretStoreTemplate,
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ListCalls(func(ctx context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					if l, ok := list.(*corev1.NamespaceList); ok {
+						*l = testNamespaceList(testNamespace)
+					}
+					return nil
+				})
+				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+					objects, _ := getTemplateObjects(testSecretStoreTemplate(), testNamespace)
+					for _, o := range objects {
+						if reflect.TypeOf(o) == reflect.TypeOf(obj) {
+							reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(testApplied(o)).Elem())
+						}
+					}
+					return true, nil
+				})
+			},
+			wantCondition:  &metav1.Condition{Type: operatorv1alpha1.Ready, Status: metav1.ConditionTrue, Reason: operatorv1alpha1.ReasonReady},
+			wantNamespaces: []string{testNamespace},
+		},
+		{
+			name:     "rolebinding with modified roleRef is recreated",
+			template: testSecretStoreTemplate,
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ListCalls(func(ctx context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					if l, ok := list.(*corev1.NamespaceList); ok {
+						*l = testNamespaceList(testNamespace)
+					}
+					return nil
+				})
+				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+					objects, _ := getTemplateObjects(testSecretStoreTemplate(), testNamespace)
+					for _, o := range objects {
+						if reflect.TypeOf(o) == reflect.TypeOf(obj) {
+							reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(testApplied(o)).Elem())
+						}
+					}
+					if rb, ok := obj.(*rbacv1.RoleBinding); ok {
+						rb.RoleRef.Name = "view"
+					}
+					return true, nil
+				})
+			},
+			wantApply:      1,
+			wantDelete:     1,
+			wantCondition:  &metav1.Condition{Type: operatorv1alpha1.Ready, Status: metav1.ConditionTrue, Reason: operatorv1alpha1.ReasonReady},
+			wantNamespaces: []string{testNamespace},
+		},
+		{
+			name:     "modified secretstore is updated",
+			template: testSecretStoreTemplate,
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ListCalls(func(ctx context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					if l, ok := list.(*corev1.NamespaceList); ok {
+						*l = testNamespaceList(testNamespace)
+					}
+					return nil
+				})
+				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+					objects, _ := getTemplateObjects(testSecretStoreTemplate(), testNamespace)
+					for _, o := range objects {
+						if reflect.TypeOf(o) == reflect.TypeOf(obj) {
+							reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(testApplied(o)).Elem())
+						}
+					}
+					if u, ok := obj.(*unstructured.Unstructured); ok {
+						_ = unstructured.SetNestedField(u.Object, "other", "spec", "provider", "vault", "path")
+					}
+					return true, nil
+				})
+			},
+			wantApply:      1,
+			wantCondition:  &metav1.Condition{Type: operatorv1alpha1.Ready, Status: metav1.ConditionTrue, Reason: operatorv1alpha1.ReasonReady},
+			wantNamespaces: []string{testNamespace},
+		},
+		{
+			name:     "resources removed from namespace no longer matching",
+			template: testSecretStoreTemplate,
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ListCalls(func(ctx context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					if l, ok := list.(*corev1.ServiceAccountList); ok {
+						l.Items = []corev1.ServiceAccount{{ObjectMeta: metav1.ObjectMeta{Name: "vault-auth", Namespace: testNamespace}}}
+					}
+					return nil
+				})
+			},
+			wantDelete:    1,
+			wantCondition: &metav1.Condition{Type: operatorv1alpha1.Ready, Status: metav1.ConditionTrue, Reason: operatorv1alpha1.ReasonReady},
+		},
+		{
+			name: "resources removed when template is deleted",
+			template: func() *operatorv1alpha1.SecretStoreTemplate {
+				sst := testSecretStoreTemplate()
+				sst.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				return sst
+			},
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ListCalls(func(ctx context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					if l, ok := list.(*unstructured.UnstructuredList); ok {
+						ss := unstructured.Unstructured{}
+						ss.SetGroupVersionKind(secretStoreGVK)
+						ss.SetName("vault")
+						ss.SetNamespace(testNamespace)
+						l.Items = []unstructured.Unstructured{ss}
+					}
+					return nil
+				})
+			},
+			wantDelete:    1,
+			wantUpdate:    1,
+			wantCondition: nil,
+		},
+		{
+			name: "invalid namespace selector",
+			template: func() *operatorv1alpha1.SecretStoreTemplate {
+				sst := testSecretStoreTemplate()
+				sst.Spec.NamespaceSelector = metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tenant", Operator: "Unknown"}},
+				}
+				return sst
+			},
+			wantCondition: &metav1.Condition{Type: operatorv1alpha1.Ready, Status: metav1.ConditionFalse, Reason: operatorv1alpha1.ReasonFailed},
+		},
+		{
+			name:     "resources in system namespaces are not created",
+			template: testSecretStoreTemplate,
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ListCalls(func(ctx context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					if l, ok := list.(*corev1.NamespaceList); ok {
+						*l = testNamespaceList("openshift", "openshift-config", "kube-system", testNamespace)
+					}
+					return nil
+				})
+			},
+			wantApply:      3,
+			wantCondition:  &metav1.Condition{Type: operatorv1alpha1.Ready, Status: metav1.ConditionTrue, Reason: operatorv1alpha1.ReasonReady},
+			wantNamespaces: []string{testNamespace},
+		},
+		{
+			name:     "resource created by the users is not adopted",
+			template: testSecretStoreTemplate,
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ListCalls(func(ctx context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					if l, ok := list.(*corev1.NamespaceList); ok {
+						*l = testNamespaceList(testNamespace)
+					}
+					return nil
+				})
+			},
+			// the unlabeled secretstore is not cached, and is found with the API server.
+			uncachedPreReq: func(m *fakes.FakeCtrlClient) {
+				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+					_, ok := obj.(*unstructured.Unstructured)
+					return ok, nil
+				})
+			},
+			wantApply:      2,
+			wantRequeue:    true,
+			wantCondition:  &metav1.Condition{Type: operatorv1alpha1.Ready, Status: metav1.ConditionFalse, Reason: operatorv1alpha1.ReasonFailed},
+			wantNamespaces: []string{testNamespace},
+		},
+		{
+			name:     "resource created from another template is not adopted",
+			template: testSecretStoreTemplate,
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ListCalls(func(ctx context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					if l, ok := list.(*corev1.NamespaceList); ok {
+						*l = testNamespaceList(testNamespace)
+					}
+					return nil
+				})
+				m.ExistsCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) (bool, error) {
+					sa, ok := obj.(*corev1.ServiceAccount)
+					if !ok {
+						return false, nil
+					}
+					sa.SetLabels(map[string]string{templateLabelKey: "other"})
+					return true, nil
+				})
+			},
+			wantApply:      2,
+			wantRequeue:    true,
+			wantCondition:  &metav1.Condition{Type: operatorv1alpha1.Ready, Status: metav1.ConditionFalse, Reason: operatorv1alpha1.ReasonFailed},
+			wantNamespaces: []string{testNamespace},
+		},
+		{
+			name: "clusterrole not allowed to be bound",
+			template: func() *operatorv1alpha1.SecretStoreTemplate {
+				sst := testSecretStoreTemplate()
+				sst.Spec.RoleBinding.RoleRef.Name = "cluster-admin"
+				return sst
+			},
+			wantCondition: &metav1.Condition{Type: operatorv1alpha1.Ready, Status: metav1.ConditionFalse, Reason: operatorv1alpha1.ReasonFailed},
+		},
+		{
+			name:     "resource creation fails",
+			template: testSecretStoreTemplate,
+			preReq: func(m *fakes.FakeCtrlClient) {
+				m.ListCalls(func(ctx context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					if l, ok := list.(*corev1.NamespaceList); ok {
+						*l = testNamespaceList(testNamespace)
+					}
+					return nil
+				})
+				m.ApplyReturns(commontest.ErrTestClient)
+			},
+			wantApply:     1,
+			wantCondition: &metav1.Condition{Type: operatorv1alpha1.Ready, Status: metav1.ConditionFalse, Reason: operatorv1alpha1.ReasonFailed},
+			wantErr:       "failed to create team-a/vault-auth serviceaccount resource: test client error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testReconciler(t)
+			mock := &fakes.FakeCtrlClient{}
+			r.CtrlClient = mock
+			r.UncachedClient = mock
+			if tt.uncachedPreReq != nil {
+				uncached := &fakes.FakeCtrlClient{}
+				tt.uncachedPreReq(uncached)
+				r.UncachedClient = uncached
+			}
+			sst := tt.template()
+			mock.GetCalls(func(ctx context.Context, ns types.NamespacedName, obj client.Object) error {
+				if o, ok := obj.(*operatorv1alpha1.SecretStoreTemplate); ok {
+					sst.DeepCopyInto(o)
+				}
+				return nil
+			})
+			var updated *operatorv1alpha1.SecretStoreTemplate
+			mock.StatusUpdateCalls(func(ctx context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+				updated = obj.(*operatorv1alpha1.SecretStoreTemplate).DeepCopy()
+				return nil
+			})
+			if tt.preReq != nil {
+				tt.preReq(mock)
+			}
+
+			result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: testTemplateName}})
+			if (tt.wantErr != "" || err != nil) && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Expected error: %v, got: %v", tt.wantErr, err)
+			}
+			if mock.ApplyCallCount() != tt.wantApply {
+				t.Errorf("Apply called %d times, want %d", mock.ApplyCallCount(), tt.wantApply)
+			}
+			if got := result.RequeueAfter > 0; got != tt.wantRequeue {
+				t.Errorf("requeued = %v, want %v", got, tt.wantRequeue)
+			}
+			if mock.UpdateWithRetryCallCount() != tt.wantUpdate {
+				t.Errorf("UpdateWithRetry called %d times, want %d", mock.UpdateWithRetryCallCount(), tt.wantUpdate)
+			}
+			if mock.DeleteCallCount() != tt.wantDelete {
+				t.Errorf("Delete called %d times, want %d", mock.DeleteCallCount(), tt.wantDelete)
+			}
+
+			if tt.wantCondition == nil {
+				if updated != nil {
+					t.Errorf("unexpected status update %v", updated.Status)
+				}
+				return
+			}
+			if updated == nil {
+				t.Fatalf("expected status update with condition %v", tt.wantCondition)
+			}
+			cond := apimeta.FindStatusCondition(updated.Status.Conditions, tt.wantCondition.Type)
+			if cond == nil || cond.Status != tt.wantCondition.Status || cond.Reason != tt.wantCondition.Reason {
+				t.Errorf("condition = %v, want %v", cond, tt.wantCondition)
+			}
+			if !reflect.DeepEqual(updated.Status.Namespaces, tt.wantNamespaces) {
+				t.Errorf("status namespaces = %v, want %v", updated.Status.Namespaces, tt.wantNamespaces)
+			}
+		})
+	}
+}
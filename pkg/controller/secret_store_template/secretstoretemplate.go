@@ -0,0 +1,370 @@
+package secret_store_template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+)
+
+// errResourceConflict is returned when a resource configured in the SecretStoreTemplate exists and is not created
+// from the SecretStoreTemplate.
+var errResourceConflict = errors.New("resource exists and is not created from the secretstoretemplate")
+
+// maxConflictsInMessage is the maximum number of the conflicting resources listed in the condition message.
+const maxConflictsInMessage = 10
+
+// reconcileTemplate creates or updates the resources in the namespaces matching the namespace selector, removes the
+// resources from the namespaces which no longer match, and returns the names of the matching namespaces and the
+// resources which exist and are not created from the SecretStoreTemplate.
+func (r *Reconciler) reconcileTemplate(ctx context.Context, sst *operatorv1alpha1.SecretStoreTemplate) ([]string, []string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&sst.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, nil, common.NewIrrecoverableError(err, "invalid namespaceSelector configured in %s", sst.GetName())
+	}
+	if rb := sst.Spec.RoleBinding; rb != nil && rb.RoleRef.Kind == "ClusterRole" && !slices.Contains(bindableClusterRoles, rb.RoleRef.Name) {
+		return nil, nil, common.NewIrrecoverableError(fmt.Errorf("clusterrole %s cannot be bound, allowed clusterroles: %s", rb.RoleRef.Name, strings.Join(bindableClusterRoles, ", ")),
+			"invalid roleBinding configured in %s", sst.GetName())
+	}
+
+	namespaceList := &corev1.NamespaceList{}
+	if err := r.List(ctx, namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, nil, common.FromClientError(err, "failed to list namespaces matching %s", selector.String())
+	}
+
+	namespaces := make([]string, 0, len(namespaceList.Items))
+	var conflicts []string
+	for _, ns := range namespaceList.Items {
+		if !ns.DeletionTimestamp.IsZero() || ns.Status.Phase == corev1.NamespaceTerminating || isSystemNamespace(ns.GetName()) {
+			continue
+		}
+		objects, err := getTemplateObjects(sst, ns.GetName())
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, obj := range objects {
+			if err := r.createOrApply(ctx, sst, obj); err != nil {
+				if !errors.Is(err, errResourceConflict) {
+					return nil, nil, err
+				}
+				conflicts = append(conflicts, fmt.Sprintf("%s %s/%s", resourceKind(obj), obj.GetNamespace(), obj.GetName()))
+			}
+		}
+		namespaces = append(namespaces, ns.GetName())
+	}
+	slices.Sort(namespaces)
+	slices.Sort(conflicts)
+
+	if err := r.cleanUp(ctx, sst, namespaces); err != nil {
+		return nil, nil, err
+	}
+
+	return namespaces, conflicts, nil
+}
+
+// createOrApply creates the resource when it does not exist, or updates it when it has drifted from the desired state,
+// with server-side apply. A resource of the same name not created from the SecretStoreTemplate is not adopted, and
+// errResourceConflict is returned.
+func (r *Reconciler) createOrApply(ctx context.Context, sst *operatorv1alpha1.SecretStoreTemplate, desired client.Object) error {
+	kind := resourceKind(desired)
+	name := fmt.Sprintf("%s/%s", desired.GetNamespace(), desired.GetName())
+	r.log.V(4).Info("reconciling resource", "kind", kind, "name", name)
+
+	fetched := emptyObject(desired)
+	exist, err := r.Exists(ctx, client.ObjectKeyFromObject(desired), fetched)
+	if err != nil {
+		return common.FromClientError(err, "failed to check %s %s resource already exists", name, kind)
+	}
+	// only the resources with the template label are cached, the resources created by the users
+	// are looked up with the API server.
+	if !exist {
+		userCreated, err := r.UncachedClient.Exists(ctx, client.ObjectKeyFromObject(desired), emptyObject(desired))
+		if err != nil {
+			return common.FromClientError(err, "failed to check %s %s resource already exists", name, kind)
+		}
+		if userCreated {
+			r.log.V(1).Info("resource not created from the template exists, skipping", "kind", kind, "name", name)
+			r.eventRecorder.Eventf(sst, corev1.EventTypeWarning, "ResourceConflict", "%s resource %s exists and is not created from the template", kind, name)
+			return errResourceConflict
+		}
+	}
+	if owner := fetched.GetLabels()[templateLabelKey]; exist && owner != sst.GetName() {
+		r.log.V(1).Info("resource created from another template exists, skipping", "kind", kind, "name", name, "template", owner)
+		r.eventRecorder.Eventf(sst, corev1.EventTypeWarning, "ResourceConflict", "%s resource %s exists and is created from the template %s", kind, name, owner)
+		return errResourceConflict
+	}
+
+	switch {
+	case exist && roleRefModified(desired, fetched):
+		// roleRef of a RoleBinding is immutable, which requires the RoleBinding to be recreated.
+		r.log.V(1).Info("rolebinding roleRef has been modified, recreating", "name", name)
+		if err := r.Delete(ctx, fetched); err != nil {
+			return common.FromClientError(err, "failed to delete %s %s resource", name, kind)
+		}
+		if err := r.Apply(ctx, desired); err != nil {
+			return common.FromClientError(err, "failed to create %s %s resource", name, kind)
+		}
+		r.eventRecorder.Eventf(sst, corev1.EventTypeNormal, "Reconciled", "%s resource %s recreated", kind, name)
+	case exist && common.HasObjectDrifted(desired, fetched):
+		r.log.V(1).Info("resource has been modified, updating to desired state", "kind", kind, "name", name)
+		if err := r.Apply(ctx, desired); err != nil {
+			return common.FromClientError(err, "failed to update %s %s resource", name, kind)
+		}
+		r.eventRecorder.Eventf(sst, corev1.EventTypeNormal, "Reconciled", "%s resource %s updated", kind, name)
+	case !exist:
+		if err := r.Apply(ctx, desired); err != nil {
+			return common.FromClientError(err, "failed to create %s %s resource", name, kind)
+		}
+		r.eventRecorder.Eventf(sst, corev1.EventTypeNormal, "Reconciled", "%s resource %s created", kind, name)
+	default:
+		r.log.V(4).Info("resource already exists and is in expected state", "kind", kind, "name", name)
+	}
+
+	return nil
+}
+
+// cleanUp removes the resources created from the SecretStoreTemplate which are not in the given namespaces,
+// or are no longer configured in the SecretStoreTemplate. All the resources are removed when namespaces is empty.
+func (r *Reconciler) cleanUp(ctx context.Context, sst *operatorv1alpha1.SecretStoreTemplate, namespaces []string) error {
+	lists := []client.ObjectList{
+		&corev1.ServiceAccountList{},
+		&rbacv1.RoleBindingList{},
+		secretStoreList(),
+	}
+	for _, list := range lists {
+		if err := r.List(ctx, list, client.MatchingLabels{templateLabelKey: sst.GetName()}); err != nil {
+			return common.FromClientError(err, "failed to list resources created from %s", sst.GetName())
+		}
+		items, err := listItems(list)
+		if err != nil {
+			return common.NewIrrecoverableError(err, "failed to extract resources created from %s", sst.GetName())
+		}
+		for _, obj := range items {
+			if slices.Contains(namespaces, obj.GetNamespace()) && obj.GetName() == configuredName(sst, obj) {
+				continue
+			}
+			kind := resourceKind(obj)
+			name := fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
+			if err := r.Delete(ctx, obj); err != nil {
+				return common.FromClientError(err, "failed to delete %s %s resource", name, kind)
+			}
+			r.eventRecorder.Eventf(sst, corev1.EventTypeNormal, "Reconciled", "%s resource %s deleted", kind, name)
+		}
+	}
+	return nil
+}
+
+// getTemplateObjects returns the resources configured in the SecretStoreTemplate for the namespace.
+func getTemplateObjects(sst *operatorv1alpha1.SecretStoreTemplate, namespace string) ([]client.Object, error) {
+	objects := make([]client.Object, 0, 3)
+
+	if sa := sst.Spec.ServiceAccount; sa != nil {
+		serviceAccount := &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      sa.Name,
+				Namespace: namespace,
+			},
+		}
+		if len(sa.Annotations) > 0 {
+			annotations := make(map[string]string, len(sa.Annotations))
+			for k, v := range sa.Annotations {
+				annotations[k] = expandNamespace(v, namespace)
+			}
+			serviceAccount.SetAnnotations(annotations)
+		}
+		objects = append(objects, serviceAccount)
+	}
+
+	if rb := sst.Spec.RoleBinding; rb != nil && sst.Spec.ServiceAccount != nil {
+		objects = append(objects, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      rb.Name,
+				Namespace: namespace,
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     rb.RoleRef.Kind,
+				Name:     rb.RoleRef.Name,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:      rbacv1.ServiceAccountKind,
+					Name:      sst.Spec.ServiceAccount.Name,
+					Namespace: namespace,
+				},
+			},
+		})
+	}
+
+	spec := make(map[string]interface{})
+	if len(sst.Spec.SecretStore.Spec.Raw) > 0 {
+		if err := utiljson.Unmarshal(sst.Spec.SecretStore.Spec.Raw, &spec); err != nil {
+			return nil, common.NewIrrecoverableError(err, "failed to decode secretStore spec configured in %s", sst.GetName())
+		}
+	}
+	secretStore := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": expandNamespaceInFields(spec, namespace),
+		},
+	}
+	secretStore.SetGroupVersionKind(secretStoreGVK)
+	secretStore.SetName(sst.Spec.SecretStore.Name)
+	secretStore.SetNamespace(namespace)
+	objects = append(objects, secretStore)
+
+	for _, obj := range objects {
+		l := maps.Clone(resourceLabels)
+		l[templateLabelKey] = sst.GetName()
+		obj.SetLabels(l)
+	}
+
+	return objects, nil
+}
+
+// isSystemNamespace returns whether the namespace is reserved for the platform components.
+func isSystemNamespace(name string) bool {
+	if name == "openshift" {
+		return true
+	}
+	for _, prefix := range systemNamespacePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// conflictsMessage returns the condition message listing the resources not created from the template, limited to
+// maxConflictsInMessage entries.
+func conflictsMessage(conflicts []string) string {
+	listed := conflicts
+	if len(listed) > maxConflictsInMessage {
+		listed = listed[:maxConflictsInMessage]
+	}
+	msg := fmt.Sprintf("resources exist and are not created from the template: %s", strings.Join(listed, ", "))
+	if more := len(conflicts) - len(listed); more > 0 {
+		msg += fmt.Sprintf(" and %d more", more)
+	}
+	return msg
+}
+
+// configuredName returns the name configured in the SecretStoreTemplate for the kind of the object, or an
+// empty string when the kind is not configured.
+func configuredName(sst *operatorv1alpha1.SecretStoreTemplate, obj client.Object) string {
+	switch obj.(type) {
+	case *corev1.ServiceAccount:
+		if sst.Spec.ServiceAccount != nil {
+			return sst.Spec.ServiceAccount.Name
+		}
+	case *rbacv1.RoleBinding:
+		if sst.Spec.RoleBinding != nil && sst.Spec.ServiceAccount != nil {
+			return sst.Spec.RoleBinding.Name
+		}
+	case *unstructured.Unstructured:
+		return sst.Spec.SecretStore.Name
+	}
+	return ""
+}
+
+// expandNamespace replaces the namespace variable in the value with the name of the namespace.
+func expandNamespace(value, namespace string) string {
+	return strings.ReplaceAll(value, namespaceVariable, namespace)
+}
+
+// expandNamespaceInFields replaces the namespace variable in all the string values of the unstructured field.
+func expandNamespaceInFields(field any, namespace string) any {
+	switch f := field.(type) {
+	case map[string]interface{}:
+		for k, v := range f {
+			f[k] = expandNamespaceInFields(v, namespace)
+		}
+		return f
+	case []interface{}:
+		for i, v := range f {
+			f[i] = expandNamespaceInFields(v, namespace)
+		}
+		return f
+	case string:
+		return expandNamespace(f, namespace)
+	default:
+		return f
+	}
+}
+
+// roleRefModified returns whether the roleRef of the desired and the fetched RoleBinding differ.
+func roleRefModified(desired, fetched client.Object) bool {
+	d, ok := desired.(*rbacv1.RoleBinding)
+	if !ok {
+		return false
+	}
+	//nolint:forcetypeassert // fetched is always created of the same type as desired.
+	return !reflect.DeepEqual(d.RoleRef, fetched.(*rbacv1.RoleBinding).RoleRef)
+}
+
+// emptyObject returns an empty object of the same type as the given object, for fetching the resource.
+func emptyObject(obj client.Object) client.Object {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		empty := &unstructured.Unstructured{}
+		empty.SetGroupVersionKind(u.GroupVersionKind())
+		return empty
+	}
+	//nolint:forcetypeassert // all the managed resource types implement client.Object.
+	return reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
+}
+
+// resourceKind returns the kind of the object in lower case, for logs and events.
+func resourceKind(obj client.Object) string {
+	switch obj.(type) {
+	case *corev1.ServiceAccount:
+		return "serviceaccount"
+	case *rbacv1.RoleBinding:
+		return "rolebinding"
+	default:
+		return "secretstore"
+	}
+}
+
+// secretStoreList returns an empty SecretStore list object.
+func secretStoreList() *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(secretStoreGVK.GroupVersion().WithKind(secretStoreGVK.Kind + "List"))
+	return list
+}
+
+// listItems returns the items of the list object.
+func listItems(list client.ObjectList) ([]client.Object, error) {
+	switch l := list.(type) {
+	case *corev1.ServiceAccountList:
+		items := make([]client.Object, 0, len(l.Items))
+		for i := range l.Items {
+			items = append(items, &l.Items[i])
+		}
+		return items, nil
+	case *rbacv1.RoleBindingList:
+		items := make([]client.Object, 0, len(l.Items))
+		for i := range l.Items {
+			items = append(items, &l.Items[i])
+		}
+		return items, nil
+	case *unstructured.UnstructuredList:
+		items := make([]client.Object, 0, len(l.Items))
+		for i := range l.Items {
+			items = append(items, &l.Items[i])
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("unsupported list type %T", list)
+}
@@ -0,0 +1,107 @@
+package diagnostics
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// bundle is a gzip compressed tar archive with all the files placed in a top level directory.
+type bundle struct {
+	dir      string
+	modTime  time.Time
+	gzWriter *gzip.Writer
+	writer   *tar.Writer
+
+	// err is the first error writing the archive, after which all the writes are skipped.
+	err error
+}
+
+// newBundle returns a bundle writing the archive to out, with the files placed in the directory.
+func newBundle(out io.Writer, dir string, modTime time.Time) *bundle {
+	gzWriter := gzip.NewWriter(out)
+	return &bundle{
+		dir:      dir,
+		modTime:  modTime,
+		gzWriter: gzWriter,
+		writer:   tar.NewWriter(gzWriter),
+	}
+}
+
+// add adds the file with the content at the path relative to the bundle directory.
+func (b *bundle) add(name string, data []byte) {
+	if b.err != nil {
+		return
+	}
+	if err := b.writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     path.Join(b.dir, name),
+		Mode:     0o644,
+		Size:     int64(len(data)),
+		ModTime:  b.modTime,
+	}); err != nil {
+		b.err = fmt.Errorf("failed to write %s header: %w", name, err)
+		return
+	}
+	if _, err := b.writer.Write(data); err != nil {
+		b.err = fmt.Errorf("failed to write %s: %w", name, err)
+	}
+}
+
+// addError adds the error encountered when collecting the data for the file at the path, in place of the file.
+func (b *bundle) addError(name string, err error) {
+	b.add(name+".error.txt", []byte(err.Error()+"\n"))
+}
+
+// addObject adds the object encoded as YAML at the path, without the managed fields of the object or the
+// items of the list.
+func (b *bundle) addObject(name string, obj runtime.Object) {
+	if meta.IsListType(obj) {
+		_ = meta.EachListItem(obj, func(item runtime.Object) error {
+			removeManagedFields(item)
+			return nil
+		})
+	} else {
+		removeManagedFields(obj)
+	}
+	b.addYAML(name, obj)
+}
+
+// addYAML adds the value encoded as YAML at the path.
+func (b *bundle) addYAML(name string, value interface{}) {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		b.addError(name, fmt.Errorf("failed to encode: %w", err))
+		return
+	}
+	b.add(name, data)
+}
+
+// Close flushes the archive and returns the first error writing the archive.
+func (b *bundle) Close() error {
+	if b.err != nil {
+		return b.err
+	}
+	if err := b.writer.Close(); err != nil {
+		return fmt.Errorf("failed to close archive: %w", err)
+	}
+	if err := b.gzWriter.Close(); err != nil {
+		return fmt.Errorf("failed to close archive: %w", err)
+	}
+	return nil
+}
+
+// removeManagedFields removes the managed fields from the object metadata, which are not useful for diagnosing
+// and make up most of the size of the objects.
+func removeManagedFields(obj runtime.Object) {
+	if accessor, err := meta.Accessor(obj); err == nil {
+		accessor.SetManagedFields(nil)
+	}
+}
@@ -0,0 +1,70 @@
+package diagnostics
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestBundle verifies the files are written to the archive in the bundle directory, with the managed fields
+// removed from the objects.
+func TestBundle(t *testing.T) {
+	var buf bytes.Buffer
+	b := newBundle(&buf, "diagnostics", time.Now())
+	b.add("version.txt", []byte("v1\n"))
+	b.addError("resources/test.yaml", errors.New("not found"))
+	b.addObject("namespaces/test/pods.yaml", &corev1.PodList{Items: []corev1.Pod{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "pod",
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "test"}},
+		},
+	}}})
+	if err := b.Close(); err != nil {
+		t.Fatalf("Close() unexpected error: %v", err)
+	}
+
+	gzReader, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("failed to read archive: %v", err)
+	}
+	files := make(map[string]string)
+	reader := tar.NewReader(gzReader)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read archive: %v", err)
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", header.Name, err)
+		}
+		files[header.Name] = string(data)
+	}
+
+	want := map[string]string{
+		"diagnostics/version.txt":                   "v1\n",
+		"diagnostics/resources/test.yaml.error.txt": "not found\n",
+		"diagnostics/namespaces/test/pods.yaml": `items:
+- metadata:
+    name: pod
+  spec:
+    containers: null
+  status: {}
+metadata: {}
+`,
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("archive files = %v, want %v", files, want)
+	}
+}
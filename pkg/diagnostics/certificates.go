@@ -0,0 +1,62 @@
+package diagnostics
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// certificateDataKeySuffixes are the suffixes of the keys of the secret data holding PEM encoded certificates.
+var certificateDataKeySuffixes = []string{".crt", ".pem"}
+
+// isCertificateDataKey returns whether the key of the secret data holds PEM encoded certificates.
+func isCertificateDataKey(key string) bool {
+	for _, suffix := range certificateDataKeySuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// describeCertificates writes the subject, the issuer, the DNS names and the validity of each PEM encoded
+// certificate in the data to sb, under the source heading. Nothing is written when the data has no certificates.
+func describeCertificates(sb *strings.Builder, source string, data []byte, now time.Time) {
+	var described bool
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if !described {
+			fmt.Fprintf(sb, "%s:\n", source)
+			described = true
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			fmt.Fprintf(sb, "  - error: failed to parse certificate: %v\n", err)
+			continue
+		}
+		fmt.Fprintf(sb, "  - subject: %s\n", cert.Subject)
+		fmt.Fprintf(sb, "    issuer: %s\n", cert.Issuer)
+		if len(cert.DNSNames) > 0 {
+			fmt.Fprintf(sb, "    dnsNames: %s\n", strings.Join(cert.DNSNames, ", "))
+		}
+		fmt.Fprintf(sb, "    notBefore: %s\n", cert.NotBefore.UTC().Format(time.RFC3339))
+		fmt.Fprintf(sb, "    notAfter: %s (%s)\n", cert.NotAfter.UTC().Format(time.RFC3339), describeExpiry(cert.NotAfter.Sub(now)))
+	}
+}
+
+// describeExpiry returns the time remaining until the expiry of a certificate, in days when over a day.
+func describeExpiry(remaining time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case remaining < 0:
+		return "expired"
+	case remaining >= day:
+		return fmt.Sprintf("expires in %d days", remaining/day)
+	default:
+		return fmt.Sprintf("expires in %s", remaining.Truncate(time.Minute))
+	}
+}
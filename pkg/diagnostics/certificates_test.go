@@ -0,0 +1,100 @@
+package diagnostics
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCertificatePEM returns a PEM encoded self-signed certificate valid from notBefore until notAfter.
+func testCertificatePEM(t *testing.T, notBefore, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "external-secrets-webhook"},
+		DNSNames:     []string{"external-secrets-webhook.external-secrets.svc"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// TestDescribeCertificates verifies the certificates in the PEM data are described with their expiry.
+func TestDescribeCertificates(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		data      []byte
+		wantLines []string
+	}{
+		{
+			name: "valid certificate",
+			data: testCertificatePEM(t, now.Add(-24*time.Hour), now.Add(90*24*time.Hour)),
+			wantLines: []string{
+				"test:",
+				"  - subject: CN=external-secrets-webhook",
+				"    issuer: CN=external-secrets-webhook",
+				"    dnsNames: external-secrets-webhook.external-secrets.svc",
+				"    notBefore: 2025-05-31T00:00:00Z",
+				"    notAfter: 2025-08-30T00:00:00Z (expires in 90 days)",
+			},
+		},
+		{
+			name: "certificate expiring within a day",
+			data: testCertificatePEM(t, now.Add(-24*time.Hour), now.Add(90*time.Minute)),
+			wantLines: []string{
+				"    notAfter: 2025-06-01T01:30:00Z (expires in 1h30m0s)",
+			},
+		},
+		{
+			name: "expired certificate",
+			data: testCertificatePEM(t, now.Add(-48*time.Hour), now.Add(-24*time.Hour)),
+			wantLines: []string{
+				"    notAfter: 2025-05-31T00:00:00Z (expired)",
+			},
+		},
+		{
+			name: "bundle with an invalid certificate",
+			data: append(testCertificatePEM(t, now, now.Add(48*time.Hour)), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("invalid")})...),
+			wantLines: []string{
+				"    notAfter: 2025-06-03T00:00:00Z (expires in 2 days)",
+				"  - error: failed to parse certificate:",
+			},
+		},
+		{
+			name: "data without certificates",
+			data: []byte("not a certificate"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			describeCertificates(&sb, "test", tt.data, now)
+			if len(tt.wantLines) == 0 && sb.Len() != 0 {
+				t.Errorf("describeCertificates() = %q, want empty", sb.String())
+			}
+			for _, line := range tt.wantLines {
+				if !strings.Contains(sb.String(), line) {
+					t.Errorf("describeCertificates() = %q, want line %q", sb.String(), line)
+				}
+			}
+		})
+	}
+}
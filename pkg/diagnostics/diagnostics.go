@@ -0,0 +1,375 @@
+// Package diagnostics collects the state of the operator and the external-secrets operand in a cluster into a
+// support bundle.
+package diagnostics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/controller/common"
+	escontroller "github.com/openshift/external-secrets-operator/pkg/controller/external_secrets"
+	"github.com/openshift/external-secrets-operator/pkg/version"
+)
+
+// operandImageEnvVars are the environment variables of the operator deployment with the operand images, required
+// for computing the desired operand resources.
+var operandImageEnvVars = []string{
+	"RELATED_IMAGE_EXTERNAL_SECRETS",
+	"OPERAND_EXTERNAL_SECRETS_IMAGE_VERSION",
+	"RELATED_IMAGE_BITWARDEN_SDK_SERVER",
+	"BITWARDEN_SDK_SERVER_IMAGE_VERSION",
+}
+
+// Options are the options for collecting the diagnostics.
+type Options struct {
+	// OperatorNamespace is the namespace the operator is installed in.
+	OperatorNamespace string
+
+	// OperandNamespace is the namespace the external-secrets operand is installed in.
+	OperandNamespace string
+
+	// Namespaces are the namespaces collected in addition to the operator and the operand namespaces, like the
+	// namespaces of the ExternalSecrets being diagnosed.
+	Namespaces []string
+
+	// LogTailLines is the number of the most recent lines collected from the logs of each container.
+	LogTailLines int64
+}
+
+// collector collects the diagnostics from the cluster into the bundle.
+type collector struct {
+	ctx       context.Context
+	config    *rest.Config
+	scheme    *runtime.Scheme
+	client    client.Client
+	clientset kubernetes.Interface
+	opts      Options
+	bundle    *bundle
+	now       time.Time
+
+	// certificates is the description of the certificates found in the collected resources.
+	certificates strings.Builder
+}
+
+// Collect writes a gzip compressed tar archive to out with the state of the operator and the operand in the cluster:
+// the version of the cluster, the operator custom resources, the pods, the container logs, the deployments, the
+// services, the events, the network policies, the ExternalSecrets, the PushSecrets and the secrets in the operator,
+// the operand and the additional namespaces, the
+// validating webhook configurations, a summary of the certificates with their expiry, and the changes the operator
+// would make to the operand resources. The data of the secrets is replaced with its size. A failure to collect
+// any of the data is recorded in the archive in place of the data, and only the failures to write the archive
+// are returned.
+func Collect(ctx context.Context, config *rest.Config, scheme *runtime.Scheme, opts Options, out io.Writer) error {
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create clientset: %w", err)
+	}
+
+	now := time.Now().UTC()
+	d := &collector{
+		ctx:       ctx,
+		config:    config,
+		scheme:    scheme,
+		client:    c,
+		clientset: clientset,
+		opts:      opts,
+		bundle:    newBundle(out, "external-secrets-diagnostics-"+now.Format("20060102-150405"), now),
+		now:       now,
+	}
+	d.collectVersion()
+	d.collectOperatorResources()
+	for _, namespace := range d.namespaces() {
+		d.collectNamespace(namespace)
+	}
+	d.collectWebhookConfigurations()
+	d.collectDiff()
+	d.bundle.add("certificates.txt", []byte(d.certificates.String()))
+	return d.bundle.Close()
+}
+
+// namespaces returns the distinct namespaces of the operator, the operand and the additional namespaces.
+func (d *collector) namespaces() []string {
+	var namespaces []string
+	for _, namespace := range append([]string{d.opts.OperatorNamespace, d.opts.OperandNamespace}, d.opts.Namespaces...) {
+		if namespace != "" && !slices.Contains(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
+
+// collectVersion collects the version of the cluster and of the diagnose command.
+func (d *collector) collectVersion() {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "collected: %s\n", d.now.Format(time.RFC3339))
+	fmt.Fprintf(&sb, "client: %s\n", version.String())
+	serverVersion, err := d.clientset.Discovery().ServerVersion()
+	if err != nil {
+		fmt.Fprintf(&sb, "server: failed to fetch version: %v\n", err)
+	} else {
+		fmt.Fprintf(&sb, "server: %s\n", serverVersion.GitVersion)
+	}
+	d.bundle.add("version.txt", []byte(sb.String()))
+}
+
+// collectOperatorResources collects the cluster scoped resources configuring the operator and the operand.
+func (d *collector) collectOperatorResources() {
+	for _, resource := range []struct {
+		name, objectName string
+		obj              client.Object
+	}{
+		{name: "resources/externalsecretsconfig.yaml", objectName: common.ExternalSecretsConfigObjectName, obj: &operatorv1alpha1.ExternalSecretsConfig{}},
+		{name: "resources/externalsecretsmanager.yaml", objectName: common.ExternalSecretsManagerObjectName, obj: &operatorv1alpha1.ExternalSecretsManager{}},
+	} {
+		if err := d.client.Get(d.ctx, types.NamespacedName{Name: resource.objectName}, resource.obj); err != nil {
+			d.bundle.addError(resource.name, err)
+			continue
+		}
+		d.bundle.addObject(resource.name, resource.obj)
+	}
+
+	d.addList("resources/clustersecretstores.yaml", unstructuredList("external-secrets.io/v1", "ClusterSecretStoreList"))
+}
+
+// unstructuredList returns an empty list of the resources of the external-secrets APIs, which are not in the scheme.
+func unstructuredList(apiVersion, kind string) *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetAPIVersion(apiVersion)
+	list.SetKind(kind)
+	return list
+}
+
+// collectNamespace collects the resources and the container logs in the namespace.
+func (d *collector) collectNamespace(namespace string) {
+	dir := path.Join("namespaces", namespace)
+
+	pods := &corev1.PodList{}
+	if d.addList(path.Join(dir, "pods.yaml"), pods, client.InNamespace(namespace)) {
+		for _, pod := range pods.Items {
+			d.collectLogs(path.Join(dir, "logs", pod.Name), &pod)
+		}
+	}
+	d.addList(path.Join(dir, "deployments.yaml"), &appsv1.DeploymentList{}, client.InNamespace(namespace))
+	d.addList(path.Join(dir, "services.yaml"), &corev1.ServiceList{}, client.InNamespace(namespace))
+	d.addList(path.Join(dir, "networkpolicies.yaml"), &networkingv1.NetworkPolicyList{}, client.InNamespace(namespace))
+	d.addList(path.Join(dir, "certificates.yaml"), &certmanagerv1.CertificateList{}, client.InNamespace(namespace))
+	d.addList(path.Join(dir, "externalsecrets.yaml"), unstructuredList("external-secrets.io/v1", "ExternalSecretList"), client.InNamespace(namespace))
+	d.addList(path.Join(dir, "pushsecrets.yaml"), unstructuredList("external-secrets.io/v1alpha1", "PushSecretList"), client.InNamespace(namespace))
+	d.collectEvents(path.Join(dir, "events.txt"), namespace)
+	d.collectSecrets(path.Join(dir, "secrets.yaml"), namespace)
+}
+
+// addList lists the resources and adds them to the bundle, and returns whether the resources were listed.
+func (d *collector) addList(name string, list client.ObjectList, opts ...client.ListOption) bool {
+	if err := d.client.List(d.ctx, list, opts...); err != nil {
+		d.bundle.addError(name, err)
+		return false
+	}
+	d.bundle.addObject(name, list)
+	return true
+}
+
+// collectLogs collects the most recent logs of the containers of the pod, and of the previous instance of the
+// containers which were restarted.
+func (d *collector) collectLogs(dir string, pod *corev1.Pod) {
+	restarted := make(map[string]bool)
+	for _, status := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+		restarted[status.Name] = status.RestartCount > 0
+	}
+	var containers []string
+	for _, container := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+		containers = append(containers, container.Name)
+	}
+	for _, container := range containers {
+		d.collectContainerLogs(path.Join(dir, container+".log"), pod, container, false)
+		if restarted[container] {
+			d.collectContainerLogs(path.Join(dir, container+".previous.log"), pod, container, true)
+		}
+	}
+}
+
+// collectContainerLogs collects the most recent logs of the container of the pod.
+func (d *collector) collectContainerLogs(name string, pod *corev1.Pod, container string, previous bool) {
+	logs, err := d.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: container,
+		TailLines: &d.opts.LogTailLines,
+		Previous:  previous,
+	}).DoRaw(d.ctx)
+	if err != nil {
+		d.bundle.addError(name, err)
+		return
+	}
+	d.bundle.add(name, logs)
+}
+
+// collectEvents collects the events in the namespace, ordered by the time of the last occurrence.
+func (d *collector) collectEvents(name, namespace string) {
+	events := &corev1.EventList{}
+	if err := d.client.List(d.ctx, events, client.InNamespace(namespace)); err != nil {
+		d.bundle.addError(name, err)
+		return
+	}
+	sort.SliceStable(events.Items, func(i, j int) bool {
+		return eventTime(&events.Items[i]).Before(eventTime(&events.Items[j]))
+	})
+	var sb strings.Builder
+	for _, event := range events.Items {
+		fmt.Fprintf(&sb, "%s\t%s\t%s\t%s/%s\t%s\n", eventTime(&event).UTC().Format(time.RFC3339), event.Type,
+			event.Reason, strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name, strings.TrimSpace(event.Message))
+	}
+	d.bundle.add(name, []byte(sb.String()))
+}
+
+// eventTime returns the time of the last occurrence of the event.
+func eventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case event.Series != nil:
+		return event.Series.LastObservedTime.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// collectSecrets collects the secrets in the namespace with the data redacted, and describes the certificates
+// found in the data.
+func (d *collector) collectSecrets(name, namespace string) {
+	secrets := &corev1.SecretList{}
+	if err := d.client.List(d.ctx, secrets, client.InNamespace(namespace)); err != nil {
+		d.bundle.addError(name, err)
+		return
+	}
+	redacted := make([]map[string]interface{}, 0, len(secrets.Items))
+	for _, secret := range secrets.Items {
+		content, err := redactSecret(&secret)
+		if err != nil {
+			d.bundle.addError(name, err)
+			return
+		}
+		redacted = append(redacted, content)
+		for _, key := range slices.Sorted(maps.Keys(secret.Data)) {
+			if isCertificateDataKey(key) {
+				describeCertificates(&d.certificates, fmt.Sprintf("secret %s/%s %s", namespace, secret.Name, key), secret.Data[key], d.now)
+			}
+		}
+	}
+	d.bundle.addYAML(name, redacted)
+}
+
+// collectWebhookConfigurations collects the validating webhook configurations calling the services in the
+// collected namespaces, and describes the CA certificates of the webhooks.
+func (d *collector) collectWebhookConfigurations() {
+	const name = "resources/validatingwebhookconfigurations.yaml"
+
+	configurations := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	if err := d.client.List(d.ctx, configurations); err != nil {
+		d.bundle.addError(name, err)
+		return
+	}
+	namespaces := make(map[string]bool)
+	for _, namespace := range d.namespaces() {
+		namespaces[namespace] = true
+	}
+	selected := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	for _, configuration := range configurations.Items {
+		var matched bool
+		for _, webhook := range configuration.Webhooks {
+			if webhook.ClientConfig.Service == nil || !namespaces[webhook.ClientConfig.Service.Namespace] {
+				continue
+			}
+			matched = true
+			describeCertificates(&d.certificates, fmt.Sprintf("validatingwebhookconfiguration %s webhook %s caBundle", configuration.Name, webhook.Name),
+				webhook.ClientConfig.CABundle, d.now)
+		}
+		if matched {
+			selected.Items = append(selected.Items, configuration)
+		}
+	}
+	d.bundle.addObject(name, selected)
+}
+
+// collectDiff collects the changes the operator would make to the operand resources in the cluster for the
+// configuration in the cluster, with the secret data redacted.
+func (d *collector) collectDiff() {
+	const name = "diff.txt"
+
+	esc := &operatorv1alpha1.ExternalSecretsConfig{}
+	if err := d.client.Get(d.ctx, types.NamespacedName{Name: common.ExternalSecretsConfigObjectName}, esc); err != nil {
+		d.bundle.addError(name, err)
+		return
+	}
+	esm := &operatorv1alpha1.ExternalSecretsManager{}
+	if err := d.client.Get(d.ctx, types.NamespacedName{Name: common.ExternalSecretsManagerObjectName}, esm); err != nil && !errors.IsNotFound(err) {
+		d.bundle.addError(name, err)
+		return
+	}
+	if err := d.setOperandImageEnvVars(); err != nil {
+		d.bundle.addError(name, err)
+		return
+	}
+
+	changes, err := escontroller.Plan(d.ctx, d.config, d.scheme, esc, esm)
+	if err != nil {
+		d.bundle.addError(name, err)
+		return
+	}
+	redactChanges(changes)
+	var buf bytes.Buffer
+	if err := escontroller.WriteChanges(&buf, changes); err != nil {
+		d.bundle.addError(name, err)
+		return
+	}
+	d.bundle.add(name, buf.Bytes())
+}
+
+// setOperandImageEnvVars sets the environment variables with the operand images not already set to the values
+// in the operator deployment, for computing the operand resources with the images used by the operator.
+func (d *collector) setOperandImageEnvVars() error {
+	deployments := &appsv1.DeploymentList{}
+	if err := d.client.List(d.ctx, deployments, client.InNamespace(d.opts.OperatorNamespace)); err != nil {
+		return fmt.Errorf("failed to list operator deployments: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			for _, env := range container.Env {
+				if !slices.Contains(operandImageEnvVars, env.Name) || env.Value == "" || os.Getenv(env.Name) != "" {
+					continue
+				}
+				if err := os.Setenv(env.Name, env.Value); err != nil {
+					return fmt.Errorf("failed to set %s environment variable: %w", env.Name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
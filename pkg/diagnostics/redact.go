@@ -0,0 +1,88 @@
+package diagnostics
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	escontroller "github.com/openshift/external-secrets-operator/pkg/controller/external_secrets"
+)
+
+// redactedValueFormat is the format of the value replacing the secret data, with the size of the data.
+const redactedValueFormat = "<redacted> (%d bytes)"
+
+// redactSecret returns the secret as unstructured content with the values of the data replaced by their size,
+// and without the last applied configuration annotation which may contain the data.
+func redactSecret(secret *corev1.Secret) (map[string]interface{}, error) {
+	redacted := secret.DeepCopy()
+	delete(redacted.Annotations, corev1.LastAppliedConfigAnnotation)
+	redacted.ManagedFields = nil
+	redacted.Data, redacted.StringData = nil, nil
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(redacted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert secret %s/%s: %w", secret.GetNamespace(), secret.GetName(), err)
+	}
+	if len(secret.Data) > 0 {
+		data := make(map[string]interface{}, len(secret.Data))
+		for key, value := range secret.Data {
+			data[key] = fmt.Sprintf(redactedValueFormat, len(value))
+		}
+		content["data"] = data
+	}
+	if len(secret.StringData) > 0 {
+		stringData := make(map[string]interface{}, len(secret.StringData))
+		for key, value := range secret.StringData {
+			stringData[key] = fmt.Sprintf(redactedValueFormat, len(value))
+		}
+		content["stringData"] = stringData
+	}
+	return content, nil
+}
+
+// redactChanges replaces the current and the desired values of the data fields of the secrets in the changes
+// with their size.
+func redactChanges(changes []escontroller.ResourceChange) {
+	for _, change := range changes {
+		if change.Object.GroupVersionKind().Group != "" || change.Object.GetKind() != "Secret" {
+			continue
+		}
+		for i, field := range change.Fields {
+			if !isSecretDataPath(field.Path) {
+				continue
+			}
+			change.Fields[i].Current = redactValue(field.Current)
+			change.Fields[i].Desired = redactValue(field.Desired)
+		}
+	}
+}
+
+// isSecretDataPath returns whether the path is of a field holding the secret data.
+func isSecretDataPath(path string) bool {
+	for _, field := range []string{"data", "stringData"} {
+		if path == field || strings.HasPrefix(path, field+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// redactValue returns the placeholder for a secret value, or nil when the value is not set.
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return fmt.Sprintf(redactedValueFormat, len(v))
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			redacted[key] = redactValue(item)
+		}
+		return redacted
+	default:
+		return "<redacted>"
+	}
+}
@@ -0,0 +1,99 @@
+package diagnostics
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	escontroller "github.com/openshift/external-secrets-operator/pkg/controller/external_secrets"
+)
+
+// TestRedactSecret verifies the secret data and the last applied configuration are removed from the secret,
+// and the other fields are kept.
+func TestRedactSecret(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "external-secrets",
+			Labels:    map[string]string{"app": "external-secrets"},
+			Annotations: map[string]string{
+				corev1.LastAppliedConfigAnnotation: `{"data":{"password":"c2VjcmV0"}}`,
+				"test":                             "value",
+			},
+		},
+		Type:       corev1.SecretTypeOpaque,
+		Data:       map[string][]byte{"password": []byte("secret")},
+		StringData: map[string]string{"token": "abcd"},
+	}
+
+	content, err := redactSecret(secret)
+	if err != nil {
+		t.Fatalf("redactSecret() unexpected error: %v", err)
+	}
+	if got, want := content["data"], map[string]interface{}{"password": "<redacted> (6 bytes)"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("data = %v, want %v", got, want)
+	}
+	if got, want := content["stringData"], map[string]interface{}{"token": "<redacted> (4 bytes)"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("stringData = %v, want %v", got, want)
+	}
+	annotations, _, _ := unstructured.NestedStringMap(content, "metadata", "annotations")
+	if want := map[string]string{"test": "value"}; !reflect.DeepEqual(annotations, want) {
+		t.Errorf("annotations = %v, want %v", annotations, want)
+	}
+	if got := content["type"]; got != string(corev1.SecretTypeOpaque) {
+		t.Errorf("type = %v, want %v", got, corev1.SecretTypeOpaque)
+	}
+	if string(secret.Data["password"]) != "secret" {
+		t.Errorf("redactSecret() modified the secret data")
+	}
+}
+
+// TestRedactChanges verifies only the data fields of the secrets are redacted in the changes.
+func TestRedactChanges(t *testing.T) {
+	secret := &unstructured.Unstructured{}
+	secret.SetAPIVersion("v1")
+	secret.SetKind("Secret")
+	configMap := &unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+
+	changes := []escontroller.ResourceChange{
+		{
+			Object: secret,
+			Action: operatorv1alpha1.PlannedActionUpdate,
+			Fields: []escontroller.FieldChange{
+				{Path: "data.tls.crt", Current: "b2xk", Desired: "bmV3dmFsdWU="},
+				{Path: "data.ca.crt", Desired: "Y2E="},
+				{Path: "metadata.labels.app", Current: "old", Desired: "external-secrets"},
+			},
+		},
+		{
+			Object: configMap,
+			Action: operatorv1alpha1.PlannedActionUpdate,
+			Fields: []escontroller.FieldChange{
+				{Path: "data.key", Current: "old", Desired: "new"},
+			},
+		},
+	}
+	redactChanges(changes)
+
+	want := [][]escontroller.FieldChange{
+		{
+			{Path: "data.tls.crt", Current: "<redacted> (4 bytes)", Desired: "<redacted> (12 bytes)"},
+			{Path: "data.ca.crt", Desired: "<redacted> (4 bytes)"},
+			{Path: "metadata.labels.app", Current: "old", Desired: "external-secrets"},
+		},
+		{
+			{Path: "data.key", Current: "old", Desired: "new"},
+		},
+	}
+	for i, change := range changes {
+		if !reflect.DeepEqual(change.Fields, want[i]) {
+			t.Errorf("changes[%d].Fields = %v, want %v", i, change.Fields, want[i])
+		}
+	}
+}
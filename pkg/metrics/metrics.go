@@ -0,0 +1,158 @@
+// Package metrics provides the operator metrics, registered with the controller-runtime metrics registry
+// and served on the manager metrics endpoint.
+package metrics
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/openshift/external-secrets-operator/pkg/version"
+)
+
+const (
+	// namespace is the prefix of all the operator metrics names.
+	namespace = "external_secrets_operator"
+
+	// webhookSubsystem is the subsystem of the metrics about the external-secrets webhook.
+	webhookSubsystem = "webhook"
+)
+
+// Operations on the managed resources, used as the label value of ResourceOperations.
+const (
+	// OperationCreate is the creation of a managed resource.
+	OperationCreate = "create"
+
+	// OperationUpdate is the update of a managed resource for a change in the operator configuration.
+	OperationUpdate = "update"
+
+	// OperationDriftCorrection is the update of a managed resource modified outside the operator, with no
+	// change in the operator configuration.
+	OperationDriftCorrection = "drift_correction"
+)
+
+// Reconciliation error types, used as the label value of ReconcileErrors.
+const (
+	// ErrorTypeIrrecoverable is the error type not retried until the configuration is changed.
+	ErrorTypeIrrecoverable = "irrecoverable"
+
+	// ErrorTypeRetryable is the error type retried by requeuing the reconcile request.
+	ErrorTypeRetryable = "retryable"
+)
+
+var (
+	// WebhookHealthy reports whether the last verification of the external-secrets webhook succeeded,
+	// with 1 for healthy and 0 for unhealthy.
+	WebhookHealthy = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: webhookSubsystem,
+		Name:      "healthy",
+		Help:      "Whether the last verification of the external-secrets webhook succeeded (1) or failed (0).",
+	})
+
+	// WebhookCertificateExpiryTimestamp reports the expiry time of the external-secrets webhook serving certificate.
+	WebhookCertificateExpiryTimestamp = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: webhookSubsystem,
+		Name:      "certificate_expiry_timestamp_seconds",
+		Help:      "Expiry time of the external-secrets webhook serving certificate in seconds since the Unix epoch.",
+	})
+
+	// WebhookHealthCheckFailures counts the failed verifications of the external-secrets webhook, partitioned
+	// by the check which failed.
+	WebhookHealthCheckFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: webhookSubsystem,
+		Name:      "health_check_failures_total",
+		Help:      "Total number of failed verifications of the external-secrets webhook, by the failed check.",
+	}, []string{"check"})
+
+	// ResourceOperations counts the operations on the resources managed by the operator, partitioned by the
+	// kind of the resource and the operation.
+	ResourceOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "managed_resource_operations_total",
+		Help:      "Total number of create, update and drift correction operations on the managed resources, by kind.",
+	}, []string{"kind", "operation"})
+
+	// ReconcileErrors counts the failed reconciliations, partitioned by the controller and the error type.
+	ReconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconcile_errors_total",
+		Help:      "Total number of failed reconciliations, by controller and irrecoverable or retryable error type.",
+	}, []string{"controller", "type"})
+
+	// ExternalSecretsConfigCondition reports the current state of the ExternalSecretsConfig conditions, with 1 for
+	// the current status of the condition type and 0 for the other statuses.
+	ExternalSecretsConfigCondition = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "externalsecretsconfig_condition",
+		Help:      "Current state of the ExternalSecretsConfig conditions, 1 for the current status of the condition type.",
+	}, []string{"type", "status"})
+
+	// ReconcilePhaseDuration observes the duration of the phases of the external-secrets reconciliation.
+	ReconcilePhaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "reconcile_phase_duration_seconds",
+		Help:      "Duration of the phases of the external-secrets reconciliation in seconds.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+	}, []string{"phase"})
+
+	// BuildInfo reports the build information of the operator, with a constant value of 1.
+	BuildInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "build_info",
+		Help:      "Build information of the operator, with a constant value of 1.",
+	}, []string{"git_version", "git_commit", "build_date", "go_version", "platform"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		WebhookHealthy,
+		WebhookCertificateExpiryTimestamp,
+		WebhookHealthCheckFailures,
+		ResourceOperations,
+		ReconcileErrors,
+		ExternalSecretsConfigCondition,
+		ReconcilePhaseDuration,
+		BuildInfo,
+	)
+
+	info := version.Get()
+	BuildInfo.WithLabelValues(info.GitVersion, info.GitCommit, info.BuildDate, runtime.Version(), runtime.GOOS+"/"+runtime.GOARCH).Set(1)
+}
+
+// RecordExternalSecretsConfigConditions updates ExternalSecretsConfigCondition with the current conditions, the
+// condition types no longer present are removed.
+func RecordExternalSecretsConfigConditions(conditions []metav1.Condition) {
+	ExternalSecretsConfigCondition.Reset()
+	for _, cond := range conditions {
+		for _, status := range []metav1.ConditionStatus{metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown} {
+			value := 0.0
+			if cond.Status == status {
+				value = 1
+			}
+			ExternalSecretsConfigCondition.WithLabelValues(cond.Type, string(status)).Set(value)
+		}
+	}
+}
+
+// PhaseTimer observes the duration of the consecutive phases of a reconciliation in ReconcilePhaseDuration.
+type PhaseTimer struct {
+	start time.Time
+}
+
+// NewPhaseTimer returns a PhaseTimer with the first phase starting now.
+func NewPhaseTimer() *PhaseTimer {
+	return &PhaseTimer{start: time.Now()}
+}
+
+// ObservePhase records the duration of the completed phase, and starts the next phase.
+func (t *PhaseTimer) ObservePhase(phase string) {
+	now := time.Now()
+	ReconcilePhaseDuration.WithLabelValues(phase).Observe(now.Sub(t.start).Seconds())
+	t.start = now
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRecordExternalSecretsConfigConditions(t *testing.T) {
+	RecordExternalSecretsConfigConditions([]metav1.Condition{
+		{Type: "Degraded", Status: metav1.ConditionTrue},
+	})
+	RecordExternalSecretsConfigConditions([]metav1.Condition{
+		{Type: "Ready", Status: metav1.ConditionTrue},
+		{Type: "WebhookHealthy", Status: metav1.ConditionFalse},
+	})
+
+	want := map[string]map[metav1.ConditionStatus]float64{
+		"Ready":          {metav1.ConditionTrue: 1, metav1.ConditionFalse: 0, metav1.ConditionUnknown: 0},
+		"WebhookHealthy": {metav1.ConditionTrue: 0, metav1.ConditionFalse: 1, metav1.ConditionUnknown: 0},
+	}
+	for condType, statuses := range want {
+		for status, value := range statuses {
+			m := &dto.Metric{}
+			if err := ExternalSecretsConfigCondition.WithLabelValues(condType, string(status)).Write(m); err != nil {
+				t.Fatalf("failed to read metric: %v", err)
+			}
+			if m.GetGauge().GetValue() != value {
+				t.Errorf("%s condition with status %s = %v, want %v", condType, status, m.GetGauge().GetValue(), value)
+			}
+		}
+	}
+
+	// the gauge of the condition type no longer present must be removed.
+	if removed := ExternalSecretsConfigCondition.DeleteLabelValues("Degraded", string(metav1.ConditionTrue)); removed {
+		t.Errorf("expected Degraded condition to be removed from the metrics")
+	}
+}
@@ -9,9 +9,10 @@ import (
 	crdannotator "github.com/openshift/external-secrets-operator/pkg/controller/crd_annotator"
 	escontroller "github.com/openshift/external-secrets-operator/pkg/controller/external_secrets"
 	esmcontroller "github.com/openshift/external-secrets-operator/pkg/controller/external_secrets_manager"
+	sstcontroller "github.com/openshift/external-secrets-operator/pkg/controller/secret_store_template"
 )
 
-func StartControllers(ctx context.Context, mgr ctrl.Manager) error {
+func StartControllers(ctx context.Context, mgr ctrl.Manager, enableWebhooks bool) error {
 	logger := ctrl.Log.WithName("setup")
 
 	if err := esmcontroller.New(ctx, mgr).SetupWithManager(mgr); err != nil {
@@ -30,6 +31,13 @@ func StartControllers(ctx context.Context, mgr ctrl.Manager) error {
 			"controller", escontroller.ControllerName)
 		return err
 	}
+	if enableWebhooks {
+		if err = externalSecretsConfig.SetupWebhookWithManager(mgr); err != nil {
+			logger.Error(err, "failed to set up admission webhooks with manager",
+				"controller", escontroller.ControllerName)
+			return err
+		}
+	}
 
 	if externalSecretsConfig.IsCertManagerInstalled() {
 		crdAnnotator, err := crdannotator.New(ctx, mgr)
@@ -44,6 +52,17 @@ func StartControllers(ctx context.Context, mgr ctrl.Manager) error {
 		}
 	}
 
+	secretStoreTemplate, err := sstcontroller.New(ctx, mgr)
+	if err != nil {
+		logger.Error(err, "failed to create secret store template controller", "controller", sstcontroller.ControllerName)
+		return err
+	}
+	if err = secretStoreTemplate.SetupWithManager(mgr); err != nil {
+		logger.Error(err, "failed to set up secret_store_template controller with manager",
+			"controller", sstcontroller.ControllerName)
+		return err
+	}
+
 	uncachedClient, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()})
 	if err != nil {
 		logger.Error(err, "failed to create uncached client")
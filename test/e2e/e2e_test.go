@@ -145,7 +145,7 @@ var _ = Describe("External Secrets Operator End-to-End test scenarios", Ordered,
 		}
 		artifactDir := getTestDir()
 		By(fmt.Sprintf("Test failed: dumping logs and resources to %s/e2e-artifacts/", artifactDir))
-		if err := utils.DumpE2EArtifacts(ctx, clientset, dynamicClient, operatorNamespace, operandNamespace, testNamespace, artifactDir); err != nil {
+		if err := utils.DumpE2EArtifacts(ctx, cfg, operatorNamespace, operandNamespace, testNamespace, artifactDir); err != nil {
 			_, _ = fmt.Fprintf(GinkgoWriter, "warning: failed to dump e2e artifacts: %v\n", err)
 		}
 	})
@@ -24,163 +24,57 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
-	"strings"
 	"time"
 
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/meta"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/restmapper"
-	"sigs.k8s.io/yaml"
-)
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	crdv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 
-const (
-	artifactLogTailLines   = 500
-	externalSecretsGroup   = "external-secrets.io"
-	operatorOpenShiftGroup = "operator.openshift.io"
-	esoV1                  = "v1"
-	esoV1alpha1            = "v1alpha1"
+	operatorv1alpha1 "github.com/openshift/external-secrets-operator/api/v1alpha1"
+	"github.com/openshift/external-secrets-operator/pkg/diagnostics"
 )
 
-// DumpE2EArtifacts writes logs, pod describes, events, and ESO resources when a test fails.
-// Call from AfterEach when CurrentSpecReport().Failed(). outputDir is the base directory (e.g. getTestDir(): ARTIFACT_DIR in CI, or repo _output when running locally). Dump is written to outputDir/e2e-artifacts/failure-<timestamp>/.
-func DumpE2EArtifacts(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, operatorNamespace, operandNamespace, testNamespace, outputDir string) error {
+const artifactLogTailLines = 500
+
+// DumpE2EArtifacts writes the diagnostics bundle of the operator and the operand, along with the test namespace,
+// when a test fails. Call from AfterEach when CurrentSpecReport().Failed(). outputDir is the base directory (e.g.
+// getTestDir(): ARTIFACT_DIR in CI, or repo _output when running locally). The bundle is written to
+// outputDir/e2e-artifacts/failure-<timestamp>.tar.gz.
+func DumpE2EArtifacts(ctx context.Context, config *rest.Config, operatorNamespace, operandNamespace, testNamespace, outputDir string) error {
 	if outputDir == "" {
 		return nil
 	}
-	ts := time.Now().Format("20060102-150405")
-	base := filepath.Join(outputDir, "e2e-artifacts", fmt.Sprintf("failure-%s", ts))
-	if err := os.MkdirAll(base, 0755); err != nil {
+	dir := filepath.Join(outputDir, "e2e-artifacts")
+	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("mkdir e2e-artifacts: %w", err)
 	}
 
-	namespaces := []string{operatorNamespace, operandNamespace}
-	if testNamespace != "" {
-		namespaces = append(namespaces, testNamespace)
-	}
-
-	// Pod logs and describes
-	podsDir := filepath.Join(base, "pods")
-	_ = os.MkdirAll(podsDir, 0755)
-	for _, ns := range namespaces {
-		podList, err := clientset.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			writeFile(filepath.Join(podsDir, ns+"_list_error.txt"), []byte(err.Error()))
-			continue
-		}
-		for _, pod := range podList.Items {
-			name := pod.Name
-			// Logs
-			opts := &corev1.PodLogOptions{TailLines: int64Ptr(artifactLogTailLines)}
-			req := clientset.CoreV1().Pods(ns).GetLogs(name, opts)
-			logBytes, err := req.DoRaw(ctx)
-			if err != nil {
-				writeFile(filepath.Join(podsDir, ns+"_"+name+".log"), []byte(fmt.Sprintf("failed to get logs: %v\n", err)))
-			} else {
-				writeFile(filepath.Join(podsDir, ns+"_"+name+".log"), logBytes)
-			}
-			// Describe
-			podDesc, err := clientset.CoreV1().Pods(ns).Get(ctx, name, metav1.GetOptions{})
-			if err != nil {
-				writeFile(filepath.Join(podsDir, ns+"_"+name+"_describe.txt"), []byte(err.Error()))
-				continue
-			}
-			descYaml, _ := yaml.Marshal(podDesc)
-			writeFile(filepath.Join(podsDir, ns+"_"+name+"_describe.yaml"), descYaml)
-		}
-	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(certmanagerv1.AddToScheme(scheme))
+	utilruntime.Must(crdv1.AddToScheme(scheme))
+	utilruntime.Must(operatorv1alpha1.AddToScheme(scheme))
 
-	// Events per namespace
-	eventsDir := filepath.Join(base, "events")
-	_ = os.MkdirAll(eventsDir, 0755)
-	for _, ns := range namespaces {
-		evList, err := clientset.CoreV1().Events(ns).List(ctx, metav1.ListOptions{Limit: 500})
-		if err != nil {
-			writeFile(filepath.Join(eventsDir, ns+"_events.txt"), []byte(err.Error()))
-			continue
-		}
-		var b strings.Builder
-		for _, ev := range evList.Items {
-			b.WriteString(fmt.Sprintf("%s %s %s: %s\n", ev.LastTimestamp.Format(time.RFC3339), ev.InvolvedObject.Kind, ev.InvolvedObject.Name, ev.Message))
-		}
-		writeFile(filepath.Join(eventsDir, ns+"_events.txt"), []byte(b.String()))
+	opts := diagnostics.Options{
+		OperatorNamespace: operatorNamespace,
+		OperandNamespace:  operandNamespace,
+		LogTailLines:      artifactLogTailLines,
 	}
-
-	// ESO resources (ClusterSecretStore, ExternalSecret, PushSecret) and operator ExternalSecretsConfig
-	resDir := filepath.Join(base, "resources")
-	_ = os.MkdirAll(resDir, 0755)
-	gr, err := restmapper.GetAPIGroupResources(clientset.Discovery())
-	if err != nil {
-		writeFile(filepath.Join(resDir, "discovery_error.txt"), []byte(err.Error()))
-	} else {
-		mapper := restmapper.NewDiscoveryRESTMapper(gr)
-		// ExternalSecretsConfig (operator.openshift.io, cluster-scoped)
-		dumpESOResource(ctx, dynamicClient, mapper, resDir, operatorOpenShiftGroup, esoV1alpha1, "ExternalSecretsConfig", "")
-		// ClusterSecretStores (cluster-scoped)
-		dumpESOResource(ctx, dynamicClient, mapper, resDir, externalSecretsGroup, esoV1, "ClusterSecretStore", "")
-		// ExternalSecrets and PushSecrets in operand and test namespace
-		for _, ns := range []string{operandNamespace, testNamespace} {
-			if ns == "" {
-				continue
-			}
-			dumpESOResource(ctx, dynamicClient, mapper, resDir, externalSecretsGroup, esoV1, "ExternalSecret", ns)
-			dumpESOResource(ctx, dynamicClient, mapper, resDir, externalSecretsGroup, esoV1alpha1, "PushSecret", ns)
-		}
+	if testNamespace != "" {
+		opts.Namespaces = []string{testNamespace}
 	}
 
-	return nil
-}
-
-func dumpESOResource(ctx context.Context, dynamicClient dynamic.Interface, mapper meta.RESTMapper, resDir, group, version, kind, namespace string) {
-	gv := schema.GroupVersion{Group: group, Version: version}
-	gk := schema.GroupKind{Group: group, Kind: kind}
-	mapping, err := mapper.RESTMapping(gk, gv.Version)
+	name := filepath.Join(dir, fmt.Sprintf("failure-%s.tar.gz", time.Now().Format("20060102-150405")))
+	file, err := os.Create(name)
 	if err != nil {
-		writeFile(filepath.Join(resDir, kind+"_mapping_error.txt"), []byte(err.Error()))
-		return
+		return fmt.Errorf("failed to create %s: %w", name, err)
 	}
-	var list *unstructured.UnstructuredList
-	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
-		list, err = dynamicClient.Resource(mapping.Resource).List(ctx, metav1.ListOptions{})
-	} else {
-		list, err = dynamicClient.Resource(mapping.Resource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err := diagnostics.Collect(ctx, config, scheme, opts, file); err != nil {
+		_ = file.Close()
+		return err
 	}
-	if err != nil {
-		writeFile(filepath.Join(resDir, kind+"_"+namespace+"_list_error.txt"), []byte(err.Error()))
-		return
-	}
-	outName := kind
-	if namespace != "" {
-		outName = kind + "_" + namespace
-	}
-	outName = sanitizeFilename(outName) + ".yaml"
-	var b strings.Builder
-	for _, item := range list.Items {
-		bb, _ := yaml.Marshal(item.Object)
-		b.Write(bb)
-		b.WriteString("---\n")
-	}
-	writeFile(filepath.Join(resDir, outName), []byte(b.String()))
-}
-
-func sanitizeFilename(s string) string {
-	s = regexp.MustCompile(`[^a-zA-Z0-9_-]`).ReplaceAllString(s, "_")
-	if len(s) > 100 {
-		s = s[:100]
-	}
-	return s
-}
-
-func writeFile(path string, data []byte) {
-	_ = os.WriteFile(path, data, 0644)
-}
-
-func int64Ptr(n int) *int64 {
-	v := int64(n)
-	return &v
+	return file.Close()
 }